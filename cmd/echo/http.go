@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
 	"go-skeleton-code/config"
+	middlewareHTTP "go-skeleton-code/internal/app/middleware/http/echo"
 	"go-skeleton-code/pkg/log"
 	middlewareLog "go-skeleton-code/pkg/log/middleware/echo"
 )
@@ -28,9 +31,17 @@ func NewHTTPServer(cfg *config.Config) *HTTPServer {
 }
 
 func (s *HTTPServer) Run() chan bool {
+	// Client IP address used for rate limit, forwarded header only accepted from known proxy
+	ipExtractor, err := newIPExtractor(s.cfg.App.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatalf("invalid trusted proxies, %v", err)
+	}
+	s.Server.IPExtractor = ipExtractor
+
 	// Apply middleware
 	s.Server.Use(middlewareLog.Recover())
 	s.Server.Use(middlewareLog.SetLogRequest()) // Mandatory
+	s.Server.Use(middlewareHTTP.SaveClientInfo())
 	s.Server.Use(middleware.BodyDumpWithConfig(middleware.BodyDumpConfig{
 		Handler: middlewareLog.SaveLogRequest(),
 		Skipper: func(c echo.Context) bool {
@@ -72,3 +83,36 @@ func (s *HTTPServer) Run() chan bool {
 
 	return serverExitSignal
 }
+
+// newIPExtractor trusts X-Forwarded-For only from the proxies, IP address or CIDR, the remote address used
+// when there is no proxy.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	trustOptions := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		trustOptions = append(trustOptions, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(trustOptions...), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"go-skeleton-code/config"
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
 	"go-skeleton-code/pkg/log"
 	middlewareLog "go-skeleton-code/pkg/log/middleware/gin"
 )
@@ -28,6 +29,7 @@ func NewHTTPServer(cfg *config.Config) *HTTPServer {
 	server.Use(gin.Recovery())                 // Built-in Gin recovery middleware
 	server.Use(middlewareLog.SetLogRequest())  // Custom Logging middleware
 	server.Use(middlewareLog.SaveLogRequest()) // Custom Request and response logging
+	server.Use(middleware.SaveClientInfo())    // Client IP address and user agent
 
 	return &HTTPServer{
 		cfg:    cfg,
@@ -36,6 +38,11 @@ func NewHTTPServer(cfg *config.Config) *HTTPServer {
 }
 
 func (s *HTTPServer) Run() chan bool {
+	// Client IP address used for rate limit, forwarded header only accepted from known proxy
+	if err := s.Server.SetTrustedProxies(s.cfg.App.HTTP.TrustedProxies); err != nil {
+		log.Fatalf("invalid trusted proxies, %v", err)
+	}
+
 	// Health check route
 	s.Server.GET("/", func(c *gin.Context) {
 		c.String(http.StatusOK, "OK")
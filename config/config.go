@@ -15,6 +15,7 @@ const (
 type Config struct {
 	App          App
 	Security     Security
+	Trading      Trading
 	Dependencies Dependencies
 }
 
@@ -27,9 +28,10 @@ type App struct {
 }
 
 type HTTP struct {
-	Host       string
-	Port       string
-	CtxTimeout time.Duration
+	Host           string
+	Port           string
+	CtxTimeout     time.Duration
+	TrustedProxies []string // Proxy allowed to set the client IP address header, none trusted when empty
 }
 
 type GRPC struct {
@@ -48,25 +50,168 @@ type Logging struct {
 }
 
 type Security struct {
-	Jwt struct {
-		Key      string
-		Duration time.Duration
+	Jwt           Jwt
+	EncryptionKey string // Base64 encoded AES key for data encrypted at rest
+	TOTP          struct {
+		Issuer        string        // Shown in the authenticator app
+		Skew          int           // Accepted time step before and after current time step
+		LoginTTL      time.Duration // Lifetime of the token between password and OTP verification
+		MaxAttempts   int           // Maximum OTP attempts for a single login
+		RecoveryCodes int           // Total recovery code generated when 2FA enabled
 	}
+	EmailVerification struct {
+		TTL            time.Duration // Verification token lifetime
+		ResendInterval time.Duration // Minimum interval between verification email for the same address
+		URL            string        // Verification link sent to the user, %v replaced with the token
+	}
+	LoginProtection struct {
+		Window           time.Duration // Failed login counted within this period
+		BackoffThreshold int           // Failed login per email before backoff applied
+		IPThreshold      int           // Failed login per IP address before backoff applied
+		BackoffBase      time.Duration // First backoff duration, doubled on every following failure
+		BackoffMax       time.Duration
+		LockoutThreshold int // Failed login per email and IP address before the account locked for that IP address
+		LockoutDuration  time.Duration
+	}
+	PasswordReset struct {
+		TTL             time.Duration // Reset token lifetime
+		RequestInterval time.Duration // Minimum interval between reset email for the same address
+		URL             string        // Reset link sent to the user, %v replaced with the token
+	}
+	PhoneOTP struct {
+		Length          int           // Digits of the OTP
+		TTL             time.Duration // OTP lifetime
+		MaxAttempts     int           // Wrong OTP per phone number within login protection window before the phone number locked
+		LockoutDuration time.Duration
+		ResendInterval  time.Duration // Minimum interval between SMS for the same phone number
+		DailyLimit      int           // SMS sent to the same phone number per day
+		IPLimit         int           // SMS requested from the same IP address per hour
+		LoginEnabled    bool          // Allow passwordless login using verified phone number
+	}
+	Password Password
+	APIKey   APIKey
+	OIDC     OIDC
+}
+
+type Password struct {
+	Algorithm     string // argon2id or bcrypt, used for new hash and rehash on login
+	BcryptCost    int
+	MaxConcurrent int // Hash computed at the same time, limits memory used by argon2id, number of CPU when zero
+	Argon2id      struct {
+		Memory      uint32 // KiB
+		Iterations  uint32
+		Parallelism uint8
+		SaltLength  uint32 // Bytes
+		KeyLength   uint32 // Bytes
+	}
+}
+
+type OIDC struct {
+	StateTTL  time.Duration // Time limit for completing login on the provider page
+	Timeout   time.Duration // Timeout of request to the provider
+	Providers []OIDCProvider
+}
+
+type OIDCProvider struct {
+	Name         string // Used in the login path, e.g. google
+	Issuer       string // Metadata discovered from the issuer well-known configuration
+	ClientID     string
+	ClientSecret string   // Empty for public client
+	RedirectURL  string   // Page receiving the code and state, must be registered on the provider
+	Scopes       []string // Default to openid, email and profile
+}
+
+type APIKey struct {
+	MaxKeys      int           // Maximum active API key per user
+	ReplayWindow time.Duration // Accepted difference between request timestamp and server time
+}
+
+type Jwt struct {
+	Key             string        // Shared secret for HS256, leave empty to stop accepting HS256 token
+	SigningKeyID    string        // Key used for signing new token, empty means signing with HS256 shared secret
+	Keys            []JwtKey      // Every key listed here accepted for verification
+	Issuer          string        // Required iss claim, skipped when empty
+	Audience        string        // Required aud claim, skipped when empty
+	Leeway          time.Duration // Allowed clock skew when checking exp, nbf and iat
+	Duration        time.Duration // Access token lifetime
+	RefreshDuration time.Duration // Refresh token lifetime
+	Revocation      struct {
+		LocalSize int           // Maximum entry of token, session and user revocation result cached in memory
+		LocalTTL  time.Duration // Lifetime of cached result, revocation from other instance applies after this period
+	}
+}
+
+type JwtKey struct {
+	ID         string // Published as kid header
+	Algorithm  string // RS256, RS384, RS512, ES256, ES384 or ES512
+	PrivateKey string // PEM file location, only required for the signing key
+	PublicKey  string // PEM file location, derived from private key when empty
+}
+
+type Trading struct {
+	CircuitBreaker CircuitBreaker
+	KYC            KYC
+}
+
+type KYC struct {
+	MaxDocumentSize  int64  // Bytes
+	NotionalCurrency string // Symbol of the currency MaxOrderNotional is in
+	Tiers            struct {
+		Unverified KYCTier
+		Basic      KYCTier
+		Full       KYCTier
+	}
+}
+
+// KYCTier is the limit applied to user with the KYC level.
+type KYCTier struct {
+	Trading          bool
+	MaxOrderNotional float64 // Maximum price multiplied by quantity of a single order, 0 means unlimited
+	Withdrawal       bool
+}
+
+type CircuitBreaker struct {
+	Enabled   bool
+	Threshold float64       // Maximum price movement in percent before trading halted
+	Window    time.Duration // Price movement observation period
 }
 
 type Dependencies struct {
 	Cache         Cache
 	MessageBroker MessageBroker
+	Mail          Mail
+	SMS           SMS
+	Storage       Storage
 	Database      struct {
 		Read  Database
 		Write Database
 	}
 }
 
+type SMS struct {
+	Sender string // Only log supported, implement Sender for the SMS provider
+}
+
+type Storage struct {
+	Driver string // Only local supported
+	Local  struct {
+		Path string // Directory of the stored file
+	}
+}
+
 type Cache struct {
 	Address  string
 	Password string
 	Database int
+	TTL      time.Duration // Lifetime of cached data in redis
+	Local    struct {
+		Size int           // Maximum entry kept in memory per cached data type
+		TTL  time.Duration // Lifetime of cached data in memory
+	}
+	Channel struct {
+		PairUpdate   string
+		CryptoUpdate string
+	}
 }
 
 type MessageBroker struct {
@@ -79,6 +224,15 @@ type MessageBroker struct {
 	}
 }
 
+type Mail struct {
+	Sender   string // log or smtp
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
 type Database struct {
 	Host     string
 	Port     int
@@ -123,6 +277,9 @@ func ParseConfigFile(configName string) *Config {
 	if env := os.Getenv("KAFKA_ADDRESS"); env != "" {
 		config.Dependencies.MessageBroker.Brokers = env
 	}
+	if env := os.Getenv("ENCRYPTION_KEY"); env != "" {
+		config.Security.EncryptionKey = env
+	}
 
 	return config
 }
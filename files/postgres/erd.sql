@@ -16,12 +16,22 @@ CREATE TABLE users (
     email                           VARCHAR(128) NOT NULL,
     phone_number                    VARCHAR(128) NOT NULL DEFAULT '',
     password                        VARCHAR(512) NOT NULL,
+    role                            VARCHAR(32) NOT NULL DEFAULT 'user',
+    permissions                     TEXT NOT NULL DEFAULT '',
     status                          BOOLEAN NOT NULL DEFAULT true,
+    email_verified_at               TIMESTAMP WITH TIME ZONE,
+    phone_verified_at               TIMESTAMP WITH TIME ZONE,
+    totp_secret                     TEXT NOT NULL DEFAULT '',
+    totp_enabled_at                 TIMESTAMP WITH TIME ZONE,
+    kyc_level                       VARCHAR(32) NOT NULL DEFAULT 'UNVERIFIED',
     created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     deleted_at                      TIMESTAMP WITH TIME ZONE 
 );
 
+-- Email compared case insensitive, stored in lower case by the application
+CREATE UNIQUE INDEX users_email_idx ON users (LOWER(email));
+CREATE UNIQUE INDEX users_verified_phone_idx ON users (phone_number) WHERE phone_verified_at IS NOT NULL;
 CREATE TRIGGER users BEFORE UPDATE ON users FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
 
 INSERT INTO users (
@@ -35,3 +45,207 @@ INSERT INTO users (
 ) VALUES (0, '', '', '', '', false, now());
 
 ---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE user_recovery_codes (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL REFERENCES users (id),
+    code_hash                       VARCHAR(64) NOT NULL,
+    used_at                         TIMESTAMP WITH TIME ZONE,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX user_recovery_codes_code_idx ON user_recovery_codes (user_id, code_hash);
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE user_identities (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL REFERENCES users (id),
+    provider                        VARCHAR(64) NOT NULL,
+    subject                         VARCHAR(255) NOT NULL,
+    email                           VARCHAR(255) NOT NULL DEFAULT '',
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX user_identities_subject_idx ON user_identities (provider, subject);
+CREATE INDEX user_identities_user_idx ON user_identities (user_id);
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE user_sessions (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL REFERENCES users (id),
+    family_id                       VARCHAR(64) NOT NULL,
+    ip_address                      VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent                      TEXT NOT NULL DEFAULT '',
+    last_seen_at                    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at                      TIMESTAMP WITH TIME ZONE,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX user_sessions_family_idx ON user_sessions (family_id);
+CREATE INDEX user_sessions_user_idx ON user_sessions (user_id, last_seen_at) WHERE revoked_at IS NULL;
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE api_keys (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL REFERENCES users (id),
+    key_id                          VARCHAR(64) NOT NULL,
+    secret                          TEXT NOT NULL,
+    label                           VARCHAR(64) NOT NULL DEFAULT '',
+    scopes                          TEXT NOT NULL DEFAULT '',
+    ip_allowlist                    TEXT NOT NULL DEFAULT '',
+    last_used_at                    TIMESTAMP WITH TIME ZONE,
+    revoked_at                      TIMESTAMP WITH TIME ZONE,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX api_keys_key_id_idx ON api_keys (key_id);
+CREATE INDEX api_keys_user_idx ON api_keys (user_id);
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE auth_events (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL DEFAULT 0,
+    email                           VARCHAR(255) NOT NULL DEFAULT '',
+    type                            VARCHAR(32) NOT NULL,
+    ip_address                      VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent                      TEXT NOT NULL DEFAULT '',
+    detail                          TEXT NOT NULL DEFAULT '',
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX auth_events_user_idx ON auth_events (user_id, id);
+
+-- Append only, recorded event never changed or removed
+CREATE RULE auth_events_no_update AS ON UPDATE TO auth_events DO INSTEAD NOTHING;
+CREATE RULE auth_events_no_delete AS ON DELETE TO auth_events DO INSTEAD NOTHING;
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE kyc_submissions (
+    id                              SERIAL PRIMARY KEY,
+    user_id                         INTEGER NOT NULL REFERENCES users (id),
+    level                           VARCHAR(32) NOT NULL,
+    document_type                   VARCHAR(32) NOT NULL,
+    document_key                    VARCHAR(255) NOT NULL,
+    content_type                    VARCHAR(64) NOT NULL,
+    status                          VARCHAR(32) NOT NULL DEFAULT 'PENDING',
+    reviewer_id                     INTEGER NOT NULL DEFAULT 0,
+    review_note                     TEXT NOT NULL DEFAULT '',
+    reviewed_at                     TIMESTAMP WITH TIME ZONE,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX kyc_submissions_user_idx ON kyc_submissions (user_id, id);
+CREATE INDEX kyc_submissions_status_idx ON kyc_submissions (status, id);
+CREATE TRIGGER kyc_submissions BEFORE UPDATE ON kyc_submissions FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE admin_audit_logs (
+    id                              SERIAL PRIMARY KEY,
+    admin_id                        INTEGER NOT NULL,
+    action                          VARCHAR(32) NOT NULL,
+    target_user_id                  INTEGER NOT NULL DEFAULT 0,
+    reason                          TEXT NOT NULL DEFAULT '',
+    ip_address                      VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent                      TEXT NOT NULL DEFAULT '',
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX admin_audit_logs_target_user_idx ON admin_audit_logs (target_user_id, id);
+CREATE INDEX admin_audit_logs_admin_idx ON admin_audit_logs (admin_id, id);
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE order_events (
+    id                              SERIAL PRIMARY KEY,
+    order_id                        INTEGER NOT NULL,
+    user_id                         INTEGER NOT NULL,
+    order_sequence                  BIGINT NOT NULL,
+    user_sequence                   BIGINT NOT NULL,
+    type                            VARCHAR(32) NOT NULL,
+    status                          VARCHAR(32) NOT NULL,
+    quantity                        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price                           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    filled_quantity                 DOUBLE PRECISION NOT NULL DEFAULT 0,
+    transaction_time                BIGINT NOT NULL DEFAULT 0,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX order_events_order_sequence_idx ON order_events (order_id, order_sequence);
+CREATE UNIQUE INDEX order_events_user_sequence_idx ON order_events (user_id, user_sequence);
+
+-- Append only, events must never be changed after written
+CREATE RULE order_events_no_update AS ON UPDATE TO order_events DO INSTEAD NOTHING;
+CREATE RULE order_events_no_delete AS ON DELETE TO order_events DO INSTEAD NOTHING;
+
+CREATE TABLE order_event_sequences (
+    scope                           VARCHAR(16) NOT NULL,
+    scope_id                        INTEGER NOT NULL,
+    value                           BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (scope, scope_id)
+);
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE cryptos (
+    id                              SERIAL PRIMARY KEY,
+    symbol                          VARCHAR(32) NOT NULL,
+    name                            VARCHAR(255) NOT NULL DEFAULT '',
+    decimals                        INTEGER NOT NULL DEFAULT 8,
+    status                          BOOLEAN NOT NULL DEFAULT true,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX cryptos_symbol_idx ON cryptos (symbol) WHERE deleted_at IS NULL;
+CREATE TRIGGER cryptos BEFORE UPDATE ON cryptos FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE pairs (
+    id                              SERIAL PRIMARY KEY,
+    code                            VARCHAR(255) NOT NULL,
+    primary_crypto_id               INTEGER NOT NULL REFERENCES cryptos (id),
+    secondary_crypto_id             INTEGER NOT NULL REFERENCES cryptos (id),
+    status                          VARCHAR(32) NOT NULL DEFAULT 'TRADING',
+    halt_reason                     TEXT NOT NULL DEFAULT '',
+    min_quantity                    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_quantity                    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    quantity_step                   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price_tick                      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    min_notional                    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX pairs_code_idx ON pairs (code) WHERE deleted_at IS NULL;
+CREATE TRIGGER pairs BEFORE UPDATE ON pairs FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+---------------------------------------------------------------------------------------------------------------------
+
+CREATE TABLE pending_trades (
+    id                              SERIAL PRIMARY KEY,
+    pair_id                         INTEGER NOT NULL,
+    taker_order_id                  INTEGER NOT NULL,
+    maker_order_id                  INTEGER NOT NULL,
+    quantity                        DOUBLE PRECISION NOT NULL,
+    price                           DOUBLE PRECISION NOT NULL,
+    side                            VARCHAR(32) NOT NULL,
+    trade_time                      BIGINT NOT NULL DEFAULT 0,
+    settled_at                      TIMESTAMP WITH TIME ZONE,
+    failed_at                       TIMESTAMP WITH TIME ZONE,
+    fail_reason                     TEXT NOT NULL DEFAULT '',
+    created_at                      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX pending_trades_pair_idx ON pending_trades (pair_id, id) WHERE settled_at IS NULL AND failed_at IS NULL;
+
+---------------------------------------------------------------------------------------------------------------------
@@ -0,0 +1,5 @@
+-- Admin KYC endpoints require the kyc:review permission, keep access of every existing admin.
+-- Revoke afterwards from admin not supposed to see identity documents.
+UPDATE users
+SET permissions = CASE WHEN permissions = '' THEN 'kyc:review' ELSE permissions || ',kyc:review' END
+WHERE role = 'admin' AND ',' || permissions || ',' NOT LIKE '%,kyc:review,%';
@@ -0,0 +1,6 @@
+-- Login requires verified email, account registered before the verification existed treated as verified.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users
+SET email_verified_at = COALESCE(created_at, now())
+WHERE email_verified_at IS NULL AND id <> 0;
@@ -0,0 +1,15 @@
+-- Email compared case insensitive, existing duplicate must be resolved before the unique index created.
+-- The earliest account keeps the email, later duplicate deactivated and renamed for manual review.
+BEGIN;
+
+UPDATE users duplicate
+SET email = 'duplicate-' || duplicate.id || '-' || LOWER(TRIM(duplicate.email)), status = false
+FROM users keeper
+WHERE LOWER(TRIM(keeper.email)) = LOWER(TRIM(duplicate.email)) AND keeper.id < duplicate.id;
+
+-- Application stores email in lower case from now on
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (LOWER(email));
+
+COMMIT;
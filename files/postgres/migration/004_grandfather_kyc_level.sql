@@ -0,0 +1,7 @@
+-- Trading requires KYC level, account registered before the KYC level existed keeps trading as BASIC level.
+-- Withdrawal still requires FULL level through document review.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kyc_level VARCHAR(32) NOT NULL DEFAULT 'UNVERIFIED';
+
+UPDATE users
+SET kyc_level = 'BASIC'
+WHERE kyc_level = 'UNVERIFIED' AND id <> 0;
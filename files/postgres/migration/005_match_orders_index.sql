@@ -0,0 +1,5 @@
+-- Portfolio reads the trades of the user orders and the price of a pair at the time of each trade.
+CREATE INDEX IF NOT EXISTS orders_user_idx ON orders (user_id);
+CREATE INDEX IF NOT EXISTS match_orders_taker_order_idx ON match_orders (taker_order_id);
+CREATE INDEX IF NOT EXISTS match_orders_maker_order_idx ON match_orders (maker_order_id);
+CREATE INDEX IF NOT EXISTS match_orders_pair_idx ON match_orders (pair_id, id) WHERE deleted_at IS NULL;
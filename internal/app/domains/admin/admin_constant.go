@@ -0,0 +1,31 @@
+package admin
+
+import "errors"
+
+type AuditAction string
+
+const (
+	AuditActionBlockUser          AuditAction = "BLOCK_USER"
+	AuditActionUnblockUser        AuditAction = "UNBLOCK_USER"
+	AuditActionRevokeUserSessions AuditAction = "REVOKE_USER_SESSIONS"
+	AuditActionUnlockUser         AuditAction = "UNLOCK_USER"
+	AuditActionResetUserTOTP      AuditAction = "RESET_USER_TOTP"
+	AuditActionApproveKYC         AuditAction = "APPROVE_KYC"
+	AuditActionRejectKYC          AuditAction = "REJECT_KYC"
+	AuditActionSearchUsers        AuditAction = "SEARCH_USERS"
+	AuditActionViewUserWallets    AuditAction = "VIEW_USER_WALLETS"
+	AuditActionViewUserOrders     AuditAction = "VIEW_USER_ORDERS"
+)
+
+// pairRequestColumns are the pair columns changed by PairRequest, status only changed by halt and resume.
+var pairRequestColumns = []string{
+	"code", "primary_crypto_id", "secondary_crypto_id", "min_quantity", "max_quantity",
+	"quantity_step", "price_tick", "min_notional", "updated_at",
+}
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrCryptoExists = errors.New("crypto symbol already exists")
+	ErrCryptoInUse  = errors.New("crypto still used by pair, delete the pair first")
+	ErrPairExists   = errors.New("pair code already exists")
+)
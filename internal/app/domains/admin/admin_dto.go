@@ -0,0 +1,70 @@
+package admin
+
+import "go-skeleton-code/internal/app/domains/kyc"
+
+type ListRequest struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// setDefault follows the same boundary used by gorm pagination query.
+func (r *ListRequest) setDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	switch {
+	case r.Limit > 1000:
+		r.Limit = 1000
+	case r.Limit <= 0:
+		r.Limit = 10
+	}
+}
+
+type UserSearchRequest struct {
+	ListRequest
+	Query  string `form:"q"` // Matched against email, full name and phone number
+	Role   string `form:"role"`
+	Status *bool  `form:"status"`
+}
+
+type UserActionRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type KYCListRequest struct {
+	ListRequest
+	Status kyc.Status `form:"status"` // Empty returns every submission
+}
+
+type KYCReviewRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type AuditLogRequest struct {
+	ListRequest
+	AdminID int `form:"admin_id"`
+	UserID  int `form:"user_id"`
+}
+
+type CryptoRequest struct {
+	Symbol   string `json:"symbol" validate:"required,max=32"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Decimals int    `json:"decimals" validate:"gte=0,lte=18"`
+	Status   bool   `json:"status"`
+}
+
+type PairRequest struct {
+	Code              string  `json:"code" validate:"required,max=255"`
+	PrimaryCryptoID   int     `json:"primary_crypto_id" validate:"required"`
+	SecondaryCryptoID int     `json:"secondary_crypto_id" validate:"required,nefield=PrimaryCryptoID"`
+	MinQuantity       float64 `json:"min_quantity" validate:"gte=0"`
+	MaxQuantity       float64 `json:"max_quantity" validate:"gte=0"`
+	QuantityStep      float64 `json:"quantity_step" validate:"gte=0"`
+	PriceTick         float64 `json:"price_tick" validate:"gte=0"`
+	MinNotional       float64 `json:"min_notional" validate:"gte=0"`
+}
+
+type HaltPairRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
@@ -0,0 +1,578 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	response "go-skeleton-code/pkg/response/gin"
+)
+
+const (
+	roleAdmin = "admin"
+
+	// Granted per admin in the users permissions column, identity documents only visible to reviewer
+	permissionReviewKYC = "kyc:review"
+)
+
+type httpHandler struct {
+	timeout        time.Duration
+	adminUsecase   Usecase
+	jwtManager     jwt.Manager
+	revocationList jwt.RevocationList
+}
+
+func NewHTTPHandler(adminUsecase Usecase, timeout time.Duration, jwtManager jwt.Manager, revocationList jwt.RevocationList) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
+	return &httpHandler{
+		timeout:        timeout,
+		adminUsecase:   adminUsecase,
+		jwtManager:     jwtManager,
+		revocationList: revocationList,
+	}
+}
+
+func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
+	v1 := g.Group("/v1/admin")
+	v1.Use(middleware.ValidateJwtToken(h.jwtManager, h.revocationList))
+	v1.Use(middleware.ValidateRole(roleAdmin))
+	{
+		v1.GET("/crypto", h.GetCryptoListHandler)
+		v1.POST("/crypto", h.CreateCryptoHandler)
+		v1.PUT("/crypto/:id", h.UpdateCryptoHandler)
+		v1.DELETE("/crypto/:id", h.DeleteCryptoHandler)
+
+		v1.GET("/pair", h.GetPairListHandler)
+		v1.POST("/pair", h.CreatePairHandler)
+		v1.PUT("/pair/:id", h.UpdatePairHandler)
+		v1.DELETE("/pair/:id", h.DeletePairHandler)
+		v1.POST("/pair/:id/halt", h.HaltPairHandler)
+		v1.POST("/pair/:id/resume", h.ResumePairHandler)
+
+		v1.GET("/user", h.SearchUsersHandler)
+		v1.GET("/user/:id/wallet", h.GetUserWalletsHandler)
+		v1.GET("/user/:id/order", h.GetUserOrdersHandler)
+		v1.POST("/user/:id/block", h.BlockUserHandler)
+		v1.POST("/user/:id/unblock", h.UnblockUserHandler)
+		v1.POST("/user/:id/revoke-sessions", h.RevokeUserSessionsHandler)
+		v1.POST("/user/:id/unlock", h.UnlockUserHandler)
+		v1.POST("/user/:id/reset-totp", h.ResetUserTOTPHandler)
+
+		kycReview := v1.Group("/kyc", middleware.RequirePermission(permissionReviewKYC))
+		kycReview.GET("", h.GetKYCSubmissionListHandler)
+		kycReview.GET("/:id/document", h.GetKYCDocumentHandler)
+		kycReview.POST("/:id/approve", h.ApproveKYCSubmissionHandler)
+		kycReview.POST("/:id/reject", h.RejectKYCSubmissionHandler)
+
+		v1.GET("/audit-log", h.GetAuditLogsHandler)
+	}
+}
+
+func (h *httpHandler) GetCryptoListHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ListRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	cryptos, total, err := h.adminUsecase.GetCryptoList(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, cryptos, requestPayload.Page, requestPayload.Limit, total)
+}
+
+func (h *httpHandler) CreateCryptoHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload CryptoRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	crypto, err := h.adminUsecase.CreateCrypto(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, crypto)
+}
+
+func (h *httpHandler) UpdateCryptoHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload CryptoRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	crypto, err := h.adminUsecase.UpdateCrypto(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, crypto)
+}
+
+func (h *httpHandler) DeleteCryptoHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.adminUsecase.DeleteCrypto(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) GetPairListHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ListRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	pairs, total, err := h.adminUsecase.GetPairList(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, pairs, requestPayload.Page, requestPayload.Limit, total)
+}
+
+func (h *httpHandler) CreatePairHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload PairRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	pair, err := h.adminUsecase.CreatePair(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, pair)
+}
+
+func (h *httpHandler) UpdatePairHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload PairRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	pair, err := h.adminUsecase.UpdatePair(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, pair)
+}
+
+func (h *httpHandler) DeletePairHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.adminUsecase.DeletePair(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) HaltPairHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload HaltPairRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	pair, err := h.adminUsecase.HaltPair(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, pair)
+}
+
+func (h *httpHandler) ResumePairHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	pair, err := h.adminUsecase.ResumePair(ctx, id)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, pair)
+}
+
+func (h *httpHandler) RevokeUserSessionsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.adminUsecase.RevokeUserSessions(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) UnlockUserHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.adminUsecase.UnlockUser(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) SearchUsersHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload UserSearchRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	users, total, err := h.adminUsecase.SearchUsers(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, users, requestPayload.Page, requestPayload.Limit, total)
+}
+
+func (h *httpHandler) GetUserWalletsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	wallets, err := h.adminUsecase.GetUserWallets(ctx, id)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, wallets)
+}
+
+func (h *httpHandler) GetUserOrdersHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload ListRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	orders, total, err := h.adminUsecase.GetUserOrders(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, orders, requestPayload.Page, requestPayload.Limit, total)
+}
+
+func (h *httpHandler) BlockUserHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload UserActionRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.adminUsecase.BlockUser(ctx, id, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) UnblockUserHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload UserActionRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.adminUsecase.UnblockUser(ctx, id, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) ResetUserTOTPHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload UserActionRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.adminUsecase.ResetUserTOTP(ctx, id, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) GetKYCSubmissionListHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload KYCListRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	submissions, total, err := h.adminUsecase.GetKYCSubmissionList(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, submissions, requestPayload.Page, requestPayload.Limit, total)
+}
+
+// GetKYCDocumentHandler streams the submitted document file.
+func (h *httpHandler) GetKYCDocumentHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	submission, document, err := h.adminUsecase.GetKYCDocument(ctx, id)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+	defer document.Close()
+
+	c.DataFromReader(http.StatusOK, -1, submission.ContentType, document, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`inline; filename="kyc-%d"`, submission.ID),
+		"Cache-Control":       "no-store",
+	})
+}
+
+func (h *httpHandler) ApproveKYCSubmissionHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload KYCReviewRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	submission, err := h.adminUsecase.ApproveKYCSubmission(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, submission)
+}
+
+func (h *httpHandler) RejectKYCSubmissionHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	var requestPayload UserActionRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	submission, err := h.adminUsecase.RejectKYCSubmission(ctx, id, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, submission)
+}
+
+func (h *httpHandler) GetAuditLogsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload AuditLogRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	auditLogs, total, err := h.adminUsecase.GetAuditLogs(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, auditLogs, requestPayload.Page, requestPayload.Limit, total)
+}
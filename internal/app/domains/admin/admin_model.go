@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go-skeleton-code/internal/app/domains/kyc"
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/internal/app/domains/user"
+)
+
+// AuditLog records action taken by admin on the user.
+type AuditLog struct {
+	ID           int         `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	AdminID      int         `json:"admin_id" gorm:"column:admin_id;type:int"`
+	Action       AuditAction `json:"action" gorm:"column:action;type:varchar;size:32"`
+	TargetUserID int         `json:"target_user_id" gorm:"column:target_user_id;type:int"`
+	Reason       string      `json:"reason" gorm:"column:reason;type:text"`
+	IPAddress    string      `json:"ip_address" gorm:"column:ip_address;type:varchar;size:64"`
+	UserAgent    string      `json:"user_agent" gorm:"column:user_agent;type:text"`
+	CreatedAt    time.Time   `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (AuditLog) TableName() string {
+	return "admin_audit_logs"
+}
+
+type Usecase interface {
+	// Crypto
+	GetCryptoList(ctx context.Context, listReq ListRequest) ([]model.Crypto, int, error)
+	CreateCrypto(ctx context.Context, cryptoReq CryptoRequest) (model.Crypto, error)
+	UpdateCrypto(ctx context.Context, id int, cryptoReq CryptoRequest) (model.Crypto, error)
+	DeleteCrypto(ctx context.Context, id int) error
+
+	// Crypto Pair
+	GetPairList(ctx context.Context, listReq ListRequest) ([]model.Pair, int, error)
+	CreatePair(ctx context.Context, pairReq PairRequest) (model.Pair, error)
+	UpdatePair(ctx context.Context, id int, pairReq PairRequest) (model.Pair, error)
+	DeletePair(ctx context.Context, id int) error
+	HaltPair(ctx context.Context, id int, haltReq HaltPairRequest) (model.Pair, error)
+	ResumePair(ctx context.Context, id int) (model.Pair, error)
+
+	// User
+	SearchUsers(ctx context.Context, searchReq UserSearchRequest) ([]user.User, int, error)
+	GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error)
+	GetUserOrders(ctx context.Context, userID int, listReq ListRequest) ([]model.Order, int, error)
+	BlockUser(ctx context.Context, userID int, actionReq UserActionRequest) error
+	UnblockUser(ctx context.Context, userID int, actionReq UserActionRequest) error
+	RevokeUserSessions(ctx context.Context, userID int) error
+	UnlockUser(ctx context.Context, userID int) error
+	ResetUserTOTP(ctx context.Context, userID int, actionReq UserActionRequest) error
+
+	// KYC review
+	GetKYCSubmissionList(ctx context.Context, listReq KYCListRequest) ([]kyc.Submission, int, error)
+	GetKYCDocument(ctx context.Context, id int) (kyc.Submission, io.ReadCloser, error)
+	ApproveKYCSubmission(ctx context.Context, id int, reviewReq KYCReviewRequest) (kyc.Submission, error)
+	RejectKYCSubmission(ctx context.Context, id int, actionReq UserActionRequest) (kyc.Submission, error)
+
+	// Audit trail
+	GetAuditLogs(ctx context.Context, auditReq AuditLogRequest) ([]AuditLog, int, error)
+}
+
+type Repository interface {
+	// Crypto
+	GetCryptoList(ctx context.Context, page, limit int) ([]model.Crypto, int, error)
+	GetCrypto(ctx context.Context, id int) (model.Crypto, error)
+	SaveCrypto(ctx context.Context, crypto model.Crypto) (model.Crypto, error)
+	IsCryptoUsedByPair(ctx context.Context, cryptoID int) (bool, error)
+
+	// Crypto Pair
+	GetPairList(ctx context.Context, page, limit int) ([]model.Pair, int, error)
+	GetPair(ctx context.Context, id int) (model.Pair, error)
+	SavePair(ctx context.Context, pair model.Pair, columns ...string) (model.Pair, error)
+
+	// User
+	IsUserExist(ctx context.Context, id int) (bool, error)
+	SearchUsers(ctx context.Context, searchReq UserSearchRequest) ([]user.User, int, error)
+	GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error)
+	GetUserOrders(ctx context.Context, userID, page, limit int) ([]model.Order, int, error)
+
+	// Audit trail
+	SaveAuditLog(ctx context.Context, auditLog AuditLog) error
+	GetAuditLogs(ctx context.Context, auditReq AuditLogRequest) ([]AuditLog, int, error)
+}
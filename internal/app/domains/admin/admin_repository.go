@@ -0,0 +1,279 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/internal/app/domains/user"
+	gormpkg "go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/log"
+)
+
+type repository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// NewRepository returns new admin Repository.
+func NewRepository(readDB *gorm.DB, writeDB *gorm.DB) *repository {
+	return &repository{
+		readDB:  readDB,
+		writeDB: writeDB,
+	}
+}
+
+func (r *repository) GetCryptoList(ctx context.Context, page, limit int) ([]model.Crypto, int, error) {
+	defer log.Context(ctx).RecordDuration("get crypto list").Stop()
+
+	var (
+		total   int64
+		cryptos = make([]model.Crypto, 0)
+		query   = r.readDB.WithContext(ctx).Model(&model.Crypto{}).Where("deleted_at IS NULL").Session(&gorm.Session{})
+	)
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(page, limit, "", "")).Find(&cryptos).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return cryptos, int(total), nil
+}
+
+func (r *repository) GetCrypto(ctx context.Context, id int) (model.Crypto, error) {
+	defer log.Context(ctx).RecordDuration("get crypto detail").Stop()
+
+	var crypto model.Crypto
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").First(&crypto, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Crypto{}, err
+	}
+
+	return crypto, nil
+}
+
+func (r *repository) SaveCrypto(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	defer log.Context(ctx).RecordDuration("save crypto to database").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Save(&crypto).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Crypto{}, err
+	}
+
+	return crypto, nil
+}
+
+func (r *repository) GetPairList(ctx context.Context, page, limit int) ([]model.Pair, int, error) {
+	defer log.Context(ctx).RecordDuration("get pair list").Stop()
+
+	var (
+		total int64
+		pairs = make([]model.Pair, 0)
+		query = r.readDB.WithContext(ctx).Model(&model.Pair{}).Where("deleted_at IS NULL").Session(&gorm.Session{})
+	)
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(page, limit, "", "")).Find(&pairs).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return pairs, int(total), nil
+}
+
+func (r *repository) GetPair(ctx context.Context, id int) (model.Pair, error) {
+	defer log.Context(ctx).RecordDuration("get pair detail").Stop()
+
+	var pair model.Pair
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").First(&pair, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Pair{}, err
+	}
+
+	return pair, nil
+}
+
+// SavePair only updates the given columns of existing pair, so status changed by the circuit breaker
+// in the meantime is not overwritten. Every column saved when no column given.
+func (r *repository) SavePair(ctx context.Context, pair model.Pair, columns ...string) (model.Pair, error) {
+	defer log.Context(ctx).RecordDuration("save pair to database").Stop()
+
+	if len(columns) == 0 {
+		if err := r.writeDB.WithContext(ctx).Save(&pair).Error; err != nil {
+			log.Context(ctx).Error(err)
+			return model.Pair{}, err
+		}
+
+		return pair, nil
+	}
+
+	if err := r.writeDB.WithContext(ctx).Model(&pair).Select(columns).Updates(&pair).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Pair{}, err
+	}
+
+	// Returning the latest state including columns not updated
+	var savedPair model.Pair
+	if err := r.writeDB.WithContext(ctx).First(&savedPair, pair.ID).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Pair{}, err
+	}
+
+	return savedPair, nil
+}
+
+// IsCryptoUsedByPair checks pair not deleted yet using the crypto as base or quote asset.
+func (r *repository) IsCryptoUsedByPair(ctx context.Context, cryptoID int) (bool, error) {
+	defer log.Context(ctx).RecordDuration("check crypto used by pair").Stop()
+
+	var total int64
+	if err := r.readDB.WithContext(ctx).
+		Model(&model.Pair{}).
+		Where("(primary_crypto_id = ? OR secondary_crypto_id = ?) AND deleted_at IS NULL", cryptoID, cryptoID).
+		Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *repository) IsUserExist(ctx context.Context, id int) (bool, error) {
+	defer log.Context(ctx).RecordDuration("check user exist").Stop()
+
+	var total int64
+	if err := r.readDB.WithContext(ctx).Table("users").Where("id = ? AND deleted_at IS NULL", id).Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *repository) SearchUsers(ctx context.Context, searchReq UserSearchRequest) ([]user.User, int, error) {
+	defer log.Context(ctx).RecordDuration("search users").Stop()
+
+	var (
+		total int64
+		users = make([]user.User, 0)
+		query = r.readDB.WithContext(ctx).Model(&user.User{})
+	)
+
+	if searchReq.Query != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(searchReq.Query)) + "%"
+		query = query.Where("LOWER(email) LIKE ? OR LOWER(full_name) LIKE ? OR phone_number LIKE ?", pattern, pattern, pattern)
+	}
+	if searchReq.Role != "" {
+		query = query.Where("role = ?", searchReq.Role)
+	}
+	if searchReq.Status != nil {
+		query = query.Where("status = ?", *searchReq.Status)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(searchReq.Page, searchReq.Limit, "", "")).Find(&users).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return users, int(total), nil
+}
+
+// likeEscaper escapes wildcard in the search keyword, backslash is the default LIKE escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *repository) GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error) {
+	defer log.Context(ctx).RecordDuration("get user wallets").Stop()
+
+	wallets := make([]model.Wallet, 0)
+	if err := r.readDB.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&wallets).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+func (r *repository) GetUserOrders(ctx context.Context, userID, page, limit int) ([]model.Order, int, error) {
+	defer log.Context(ctx).RecordDuration("get user orders").Stop()
+
+	var (
+		total  int64
+		orders = make([]model.Order, 0)
+		query  = r.readDB.WithContext(ctx).Model(&model.Order{}).Where("user_id = ? AND deleted_at IS NULL", userID).Session(&gorm.Session{})
+	)
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(page, limit, "", "")).Find(&orders).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return orders, int(total), nil
+}
+
+func (r *repository) SaveAuditLog(ctx context.Context, auditLog AuditLog) error {
+	defer log.Context(ctx).RecordDuration("save admin audit log").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	if err := writeDB.WithContext(ctx).Create(&auditLog).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetAuditLogs(ctx context.Context, auditReq AuditLogRequest) ([]AuditLog, int, error) {
+	defer log.Context(ctx).RecordDuration("get admin audit logs").Stop()
+
+	var (
+		total     int64
+		auditLogs = make([]AuditLog, 0)
+		query     = r.readDB.WithContext(ctx).Model(&AuditLog{})
+	)
+
+	if auditReq.AdminID > 0 {
+		query = query.Where("admin_id = ?", auditReq.AdminID)
+	}
+	if auditReq.UserID > 0 {
+		query = query.Where("target_user_id = ?", auditReq.UserID)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(auditReq.Page, auditReq.Limit, "", "")).Find(&auditLogs).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return auditLogs, int(total), nil
+}
@@ -0,0 +1,486 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/kyc"
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/internal/app/domains/user"
+	"go-skeleton-code/pkg/client"
+	serverError "go-skeleton-code/pkg/error"
+	gormpkg "go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/redis"
+)
+
+type usecase struct {
+	writeDB         *gorm.DB
+	cacheConfig     config.Cache
+	publisher       redis.Publisher
+	validator       *validator.Validate
+	adminRepository Repository
+	orderUsecase    model.Usecase
+	userUsecase     user.Usecase
+	kycUsecase      kyc.Usecase
+}
+
+// NewUsecase returns new admin usecase.
+func NewUsecase(
+	writeDB *gorm.DB,
+	cacheConfig config.Cache,
+	publisher redis.Publisher,
+	validator *validator.Validate,
+	adminRepository Repository,
+	orderUsecase model.Usecase,
+	userUsecase user.Usecase,
+	kycUsecase kyc.Usecase,
+) *usecase {
+	return &usecase{
+		writeDB:         writeDB,
+		cacheConfig:     cacheConfig,
+		publisher:       publisher,
+		validator:       validator,
+		adminRepository: adminRepository,
+		orderUsecase:    orderUsecase,
+		userUsecase:     userUsecase,
+		kycUsecase:      kycUsecase,
+	}
+}
+
+func (u *usecase) GetCryptoList(ctx context.Context, listReq ListRequest) ([]model.Crypto, int, error) {
+	cryptos, total, err := u.adminRepository.GetCryptoList(ctx, listReq.Page, listReq.Limit)
+	if err != nil {
+		return nil, 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return cryptos, total, nil
+}
+
+func (u *usecase) CreateCrypto(ctx context.Context, cryptoReq CryptoRequest) (model.Crypto, error) {
+	if err := u.validator.StructCtx(ctx, cryptoReq); err != nil {
+		return model.Crypto{}, serverError.ErrInvalidRequest(err)
+	}
+
+	newCrypto := model.Crypto{
+		Symbol:   cryptoReq.Symbol,
+		Name:     cryptoReq.Name,
+		Decimals: cryptoReq.Decimals,
+		Status:   cryptoReq.Status,
+	}
+
+	return u.saveCrypto(ctx, newCrypto)
+}
+
+func (u *usecase) UpdateCrypto(ctx context.Context, id int, cryptoReq CryptoRequest) (model.Crypto, error) {
+	if err := u.validator.StructCtx(ctx, cryptoReq); err != nil {
+		return model.Crypto{}, serverError.ErrInvalidRequest(err)
+	}
+
+	crypto, err := u.adminRepository.GetCrypto(ctx, id)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	crypto.Symbol = cryptoReq.Symbol
+	crypto.Name = cryptoReq.Name
+	crypto.Decimals = cryptoReq.Decimals
+	crypto.Status = cryptoReq.Status
+
+	return u.saveCrypto(ctx, crypto)
+}
+
+func (u *usecase) DeleteCrypto(ctx context.Context, id int) error {
+	crypto, err := u.adminRepository.GetCrypto(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	used, err := u.adminRepository.IsCryptoUsedByPair(ctx, crypto.ID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if used {
+		return serverError.ErrInvalidRequest(ErrCryptoInUse)
+	}
+
+	now := time.Now()
+	crypto.Status = false
+	crypto.DeletedAt = &now
+
+	_, err = u.saveCrypto(ctx, crypto)
+	return err
+}
+
+func (u *usecase) GetPairList(ctx context.Context, listReq ListRequest) ([]model.Pair, int, error) {
+	pairs, total, err := u.adminRepository.GetPairList(ctx, listReq.Page, listReq.Limit)
+	if err != nil {
+		return nil, 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return pairs, total, nil
+}
+
+func (u *usecase) CreatePair(ctx context.Context, pairReq PairRequest) (model.Pair, error) {
+	if err := u.validator.StructCtx(ctx, pairReq); err != nil {
+		return model.Pair{}, serverError.ErrInvalidRequest(err)
+	}
+
+	newPair := model.Pair{Status: model.PairStatusTrading}
+	if err := u.applyPairRequest(ctx, &newPair, pairReq); err != nil {
+		return model.Pair{}, err
+	}
+
+	return u.savePair(ctx, newPair)
+}
+
+func (u *usecase) UpdatePair(ctx context.Context, id int, pairReq PairRequest) (model.Pair, error) {
+	if err := u.validator.StructCtx(ctx, pairReq); err != nil {
+		return model.Pair{}, serverError.ErrInvalidRequest(err)
+	}
+
+	pair, err := u.adminRepository.GetPair(ctx, id)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	previousCode := pair.Code
+	if err := u.applyPairRequest(ctx, &pair, pairReq); err != nil {
+		return model.Pair{}, err
+	}
+
+	pair, err = u.savePair(ctx, pair, pairRequestColumns...)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	// Cached lookup by the previous code must be removed as well
+	if previousCode != pair.Code {
+		pairUpdate := model.PairUpdate{PairID: pair.ID, Code: previousCode}
+		if err := u.publisher.Publish(ctx, u.cacheConfig.Channel.PairUpdate, pairUpdate); err != nil {
+			log.Context(ctx).Errorf("failed publishing pair update, %v", err)
+		}
+	}
+
+	return pair, nil
+}
+
+func (u *usecase) DeletePair(ctx context.Context, id int) error {
+	pair, err := u.adminRepository.GetPair(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	pair.Status = model.PairStatusHalted
+	pair.HaltReason = "pair deleted"
+	pair.DeletedAt = &now
+
+	_, err = u.savePair(ctx, pair, "status", "halt_reason", "deleted_at", "updated_at")
+	return err
+}
+
+func (u *usecase) HaltPair(ctx context.Context, id int, haltReq HaltPairRequest) (model.Pair, error) {
+	if err := u.validator.StructCtx(ctx, haltReq); err != nil {
+		return model.Pair{}, serverError.ErrInvalidRequest(err)
+	}
+
+	return u.orderUsecase.HaltPair(ctx, id, haltReq.Reason)
+}
+
+// ResumePair settle every trade queued while the pair halted before trading resumed.
+func (u *usecase) ResumePair(ctx context.Context, id int) (model.Pair, error) {
+	return u.orderUsecase.ResumePair(ctx, id)
+}
+
+// applyPairRequest copy request into pair after making sure both crypto exist.
+func (u *usecase) applyPairRequest(ctx context.Context, pair *model.Pair, pairReq PairRequest) error {
+	for _, cryptoID := range []int{pairReq.PrimaryCryptoID, pairReq.SecondaryCryptoID} {
+		if _, err := u.adminRepository.GetCrypto(ctx, cryptoID); err != nil {
+			return err
+		}
+	}
+
+	pair.Code = pairReq.Code
+	pair.PrimaryCryptoID = pairReq.PrimaryCryptoID
+	pair.SecondaryCryptoID = pairReq.SecondaryCryptoID
+	pair.MinQuantity = pairReq.MinQuantity
+	pair.MaxQuantity = pairReq.MaxQuantity
+	pair.QuantityStep = pairReq.QuantityStep
+	pair.PriceTick = pairReq.PriceTick
+	pair.MinNotional = pairReq.MinNotional
+
+	return nil
+}
+
+// saveCrypto store the crypto and notify every instance that cached crypto data is no longer valid.
+func (u *usecase) saveCrypto(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	crypto, err := u.adminRepository.SaveCrypto(ctx, crypto)
+	if gormpkg.IsUniqueViolation(err) {
+		return model.Crypto{}, serverError.ErrConflict(ErrCryptoExists)
+	}
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	cryptoUpdate := model.CryptoUpdate{CryptoID: crypto.ID}
+	if err := u.publisher.Publish(ctx, u.cacheConfig.Channel.CryptoUpdate, cryptoUpdate); err != nil {
+		// Not returning error, the change already saved and cache will expire by itself
+		log.Context(ctx).Errorf("failed publishing crypto update, %v", err)
+	}
+
+	return crypto, nil
+}
+
+// savePair store the pair and notify every instance that cached pair data is no longer valid.
+func (u *usecase) savePair(ctx context.Context, pair model.Pair, columns ...string) (model.Pair, error) {
+	pair, err := u.adminRepository.SavePair(ctx, pair, columns...)
+	if gormpkg.IsUniqueViolation(err) {
+		return model.Pair{}, serverError.ErrConflict(ErrPairExists)
+	}
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	pairUpdate := model.PairUpdate{PairID: pair.ID, Code: pair.Code}
+	if err := u.publisher.Publish(ctx, u.cacheConfig.Channel.PairUpdate, pairUpdate); err != nil {
+		// Not returning error, the change already saved and cache will expire by itself
+		log.Context(ctx).Errorf("failed publishing pair update, %v", err)
+	}
+
+	return pair, nil
+}
+
+// RevokeUserSessions revokes every access and refresh token of the user issued before now, and every API key.
+func (u *usecase) RevokeUserSessions(ctx context.Context, userID int) error {
+	if err := u.checkUserExist(ctx, userID); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, AuditActionRevokeUserSessions, userID, "", func(ctx context.Context) error {
+		return u.userUsecase.RevokeAccess(ctx, userID)
+	})
+}
+
+func (u *usecase) UnlockUser(ctx context.Context, userID int) error {
+	return u.audit(ctx, AuditActionUnlockUser, userID, "", func(ctx context.Context) error {
+		return u.userUsecase.UnlockAccount(ctx, userID)
+	})
+}
+
+// SearchUsers audit log has no target user, the search filter saved as the reason.
+func (u *usecase) SearchUsers(ctx context.Context, searchReq UserSearchRequest) (users []user.User, total int, err error) {
+	filter := fmt.Sprintf("q=%v role=%v", searchReq.Query, searchReq.Role)
+	if searchReq.Status != nil {
+		filter += fmt.Sprintf(" status=%v", *searchReq.Status)
+	}
+
+	err = u.audit(ctx, AuditActionSearchUsers, 0, filter, func(ctx context.Context) error {
+		users, total, err = u.adminRepository.SearchUsers(ctx, searchReq)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (u *usecase) GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error) {
+	if err := u.checkUserExist(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var wallets []model.Wallet
+	err := u.audit(ctx, AuditActionViewUserWallets, userID, "", func(ctx context.Context) (err error) {
+		wallets, err = u.adminRepository.GetUserWallets(ctx, userID)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+func (u *usecase) GetUserOrders(ctx context.Context, userID int, listReq ListRequest) ([]model.Order, int, error) {
+	if err := u.checkUserExist(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		orders []model.Order
+		total  int
+	)
+	err := u.audit(ctx, AuditActionViewUserOrders, userID, "", func(ctx context.Context) (err error) {
+		orders, total, err = u.adminRepository.GetUserOrders(ctx, userID, listReq.Page, listReq.Limit)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+// BlockUser prevents the user from login and placing order, the user logged out from every device.
+func (u *usecase) BlockUser(ctx context.Context, userID int, actionReq UserActionRequest) error {
+	if err := u.validator.StructCtx(ctx, actionReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	return u.audit(ctx, AuditActionBlockUser, userID, actionReq.Reason, func(ctx context.Context) error {
+		return u.userUsecase.BlockAccount(ctx, userID, actionReq.Reason)
+	})
+}
+
+func (u *usecase) UnblockUser(ctx context.Context, userID int, actionReq UserActionRequest) error {
+	if err := u.validator.StructCtx(ctx, actionReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	return u.audit(ctx, AuditActionUnblockUser, userID, actionReq.Reason, func(ctx context.Context) error {
+		return u.userUsecase.UnblockAccount(ctx, userID, actionReq.Reason)
+	})
+}
+
+func (u *usecase) ResetUserTOTP(ctx context.Context, userID int, actionReq UserActionRequest) error {
+	if err := u.validator.StructCtx(ctx, actionReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	return u.audit(ctx, AuditActionResetUserTOTP, userID, actionReq.Reason, func(ctx context.Context) error {
+		return u.userUsecase.ResetTOTP(ctx, userID)
+	})
+}
+
+func (u *usecase) GetKYCSubmissionList(ctx context.Context, listReq KYCListRequest) ([]kyc.Submission, int, error) {
+	return u.kycUsecase.GetSubmissionList(ctx, listReq.Status, listReq.Page, listReq.Limit)
+}
+
+func (u *usecase) GetKYCDocument(ctx context.Context, id int) (kyc.Submission, io.ReadCloser, error) {
+	return u.kycUsecase.GetDocument(ctx, id)
+}
+
+func (u *usecase) ApproveKYCSubmission(ctx context.Context, id int, reviewReq KYCReviewRequest) (kyc.Submission, error) {
+	if err := u.validator.StructCtx(ctx, reviewReq); err != nil {
+		return kyc.Submission{}, serverError.ErrInvalidRequest(err)
+	}
+
+	// Target user of the audit log is the submission owner
+	submission, err := u.kycUsecase.GetSubmission(ctx, id)
+	if err != nil {
+		return kyc.Submission{}, err
+	}
+
+	err = u.audit(ctx, AuditActionApproveKYC, submission.UserID, reviewReq.Note, func(ctx context.Context) (err error) {
+		submission, err = u.kycUsecase.ApproveSubmission(ctx, id, reviewReq.Note)
+		return err
+	})
+	if err != nil {
+		return kyc.Submission{}, err
+	}
+
+	return submission, nil
+}
+
+// RejectKYCSubmission requires the reason, shown to the user in the submission review note.
+func (u *usecase) RejectKYCSubmission(ctx context.Context, id int, actionReq UserActionRequest) (kyc.Submission, error) {
+	if err := u.validator.StructCtx(ctx, actionReq); err != nil {
+		return kyc.Submission{}, serverError.ErrInvalidRequest(err)
+	}
+
+	// Target user of the audit log is the submission owner
+	submission, err := u.kycUsecase.GetSubmission(ctx, id)
+	if err != nil {
+		return kyc.Submission{}, err
+	}
+
+	err = u.audit(ctx, AuditActionRejectKYC, submission.UserID, actionReq.Reason, func(ctx context.Context) (err error) {
+		submission, err = u.kycUsecase.RejectSubmission(ctx, id, actionReq.Reason)
+		return err
+	})
+	if err != nil {
+		return kyc.Submission{}, err
+	}
+
+	return submission, nil
+}
+
+func (u *usecase) GetAuditLogs(ctx context.Context, auditReq AuditLogRequest) ([]AuditLog, int, error) {
+	auditLogs, total, err := u.adminRepository.GetAuditLogs(ctx, auditReq)
+	if err != nil {
+		return nil, 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return auditLogs, total, nil
+}
+
+func (u *usecase) checkUserExist(ctx context.Context, userID int) error {
+	userExist, err := u.adminRepository.IsUserExist(ctx, userID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if !userExist {
+		return serverError.ErrDataNotFound(ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// audit runs the action in one transaction with the audit log of the admin taking it, the action fails when the
+// audit log can't be saved. Repository joins the transaction from the context, change outside the database applied
+// by the action is kept even if the transaction fails to commit.
+func (u *usecase) audit(ctx context.Context, action AuditAction, targetUserID int, reason string, fn func(ctx context.Context) error) error {
+	ctx, tx, err := gormpkg.InitTransactionToContext(ctx, u.writeDB)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	defer tx.Rollback()
+
+	clientInfo := client.GetFromContext(ctx)
+	auditLog := AuditLog{
+		AdminID:      jwt.GetPayloadFromContext(ctx).UserID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Reason:       reason,
+		IPAddress:    clientInfo.IPAddress,
+		UserAgent:    clientInfo.UserAgent,
+	}
+
+	if err := u.adminRepository.SaveAuditLog(ctx, auditLog); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Context(ctx).Errorf("failed committing audit log %v of user %v by admin %v, %v", action, targetUserID, auditLog.AdminID, err)
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return nil
+}
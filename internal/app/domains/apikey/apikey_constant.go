@@ -0,0 +1,28 @@
+package apikey
+
+import "errors"
+
+// Scopes granted to API key
+const (
+	ScopeRead     = "read"
+	ScopeTrade    = "trade"
+	ScopeWithdraw = "withdraw"
+)
+
+const (
+	keyIDPrefix   = "ak_"
+	signatureKey  = "api-key-signature:%v:%v" // API key and the signature
+	secretLength  = 32
+	keyIDLength   = 16
+	separatorList = ","
+)
+
+var (
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrAPIKeyRevoked     = errors.New("api key revoked")
+	ErrIPNotAllowed      = errors.New("ip address not allowed for the api key")
+	ErrSignatureMismatch = errors.New("signature does not match")
+	ErrRequestExpired    = errors.New("request timestamp outside the replay window")
+	ErrRequestReplayed   = errors.New("request already processed")
+	ErrTooManyAPIKeys    = errors.New("maximum active api key reached")
+)
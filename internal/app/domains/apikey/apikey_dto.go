@@ -0,0 +1,12 @@
+package apikey
+
+type CreateAPIKeyRequest struct {
+	Label       string   `json:"label" validate:"required,max=64"`
+	Scopes      []string `json:"scopes" validate:"required,min=1,dive,oneof=read trade withdraw"`
+	IPAllowlist []string `json:"ip_allowlist" validate:"max=20,dive,ip|cidr"` // Empty allows every address
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey
+	Secret string `json:"secret"` // Only shown once
+}
@@ -0,0 +1,93 @@
+package apikey
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	response "go-skeleton-code/pkg/response/gin"
+)
+
+type httpHandler struct {
+	timeout        time.Duration
+	apiKeyUsecase  Usecase
+	jwtManager     jwt.Manager
+	revocationList jwt.RevocationList
+}
+
+func NewHTTPHandler(apiKeyUsecase Usecase, timeout time.Duration, jwtManager jwt.Manager, revocationList jwt.RevocationList) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
+	return &httpHandler{
+		timeout:        timeout,
+		apiKeyUsecase:  apiKeyUsecase,
+		jwtManager:     jwtManager,
+		revocationList: revocationList,
+	}
+}
+
+// InitRoutes registers API key management, only accessible using login token.
+func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
+	v1 := g.Group("/v1/api-key")
+	v1.Use(middleware.ValidateJwtToken(h.jwtManager, h.revocationList))
+	{
+		v1.POST("", h.CreateAPIKeyHandler)
+		v1.GET("", h.GetAPIKeysHandler)
+		v1.DELETE("/:id", h.RevokeAPIKeyHandler)
+	}
+}
+
+func (h *httpHandler) CreateAPIKeyHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload CreateAPIKeyRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	apiKey, err := h.apiKeyUsecase.CreateAPIKey(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, apiKey)
+}
+
+func (h *httpHandler) GetAPIKeysHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	apiKeys, err := h.apiKeyUsecase.GetAPIKeys(ctx)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, apiKeys)
+}
+
+func (h *httpHandler) RevokeAPIKeyHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.apiKeyUsecase.RevokeAPIKey(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
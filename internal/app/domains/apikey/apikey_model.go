@@ -0,0 +1,91 @@
+package apikey
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/signature"
+)
+
+type APIKey struct {
+	ID          int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID      int        `json:"user_id" gorm:"column:user_id;type:int"`
+	KeyID       string     `json:"key_id" gorm:"column:key_id;type:varchar;size:64"` // Public identifier sent in the API key header
+	Secret      string     `json:"-" gorm:"column:secret;type:text"`                 // Encrypted HMAC secret
+	Label       string     `json:"label" gorm:"column:label;type:varchar;size:64"`
+	Scopes      string     `json:"scopes" gorm:"column:scopes;type:text"`             // Comma separated scope
+	IPAllowlist string     `json:"ip_allowlist" gorm:"column:ip_allowlist;type:text"` // Comma separated IP address or CIDR, empty allows every address
+	LastUsedAt  *time.Time `json:"last_used_at" gorm:"column:last_used_at;type:datetime"`
+	RevokedAt   *time.Time `json:"revoked_at" gorm:"column:revoked_at;type:datetime"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns granted scopes as a list.
+func (a APIKey) ScopeList() []string {
+	return splitList(a.Scopes)
+}
+
+// IsIPAllowed reports whether the request from the IP address accepted.
+func (a APIKey) IsIPAllowed(ipAddress string) bool {
+	allowlist := splitList(a.IPAllowlist)
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range allowlist {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		if allowedIP := net.ParseIP(allowed); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, separatorList) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+type Usecase interface {
+	CreateAPIKey(ctx context.Context, createReq CreateAPIKeyRequest) (CreateAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+
+	// Used by the middleware for signed request
+	Authenticate(ctx context.Context, signedRequest signature.Request) (jwt.Payload, error)
+}
+
+type Repository interface {
+	CreateAPIKey(ctx context.Context, apiKey APIKey) (APIKey, error)
+	CountActiveAPIKeys(ctx context.Context, userID int) (int, error)
+	GetAPIKeys(ctx context.Context, userID int) ([]APIKey, error)
+	GetAPIKeyByKeyID(ctx context.Context, keyID string) (APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id int) (bool, error)
+	UpdateLastUsed(ctx context.Context, id int) error
+	AcquireSignature(ctx context.Context, keyID, signature string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,118 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+
+	"go-skeleton-code/pkg/log"
+)
+
+type repository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+	redis   *redis.Client
+}
+
+// NewRepository returns new API key Repository.
+func NewRepository(readDB *gorm.DB, writeDB *gorm.DB, redis *redis.Client) *repository {
+	return &repository{
+		readDB:  readDB,
+		writeDB: writeDB,
+		redis:   redis,
+	}
+}
+
+func (r *repository) CreateAPIKey(ctx context.Context, apiKey APIKey) (APIKey, error) {
+	defer log.Context(ctx).RecordDuration("create api key").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&apiKey).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return APIKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+func (r *repository) CountActiveAPIKeys(ctx context.Context, userID int) (int, error) {
+	defer log.Context(ctx).RecordDuration("count active api keys").Stop()
+
+	var total int64
+	err := r.readDB.WithContext(ctx).
+		Model(&APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Count(&total).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	return int(total), nil
+}
+
+func (r *repository) GetAPIKeys(ctx context.Context, userID int) ([]APIKey, error) {
+	defer log.Context(ctx).RecordDuration("get api keys").Stop()
+
+	apiKeys := make([]APIKey, 0)
+	if err := r.readDB.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&apiKeys).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (r *repository) GetAPIKeyByKeyID(ctx context.Context, keyID string) (APIKey, error) {
+	defer log.Context(ctx).RecordDuration("get api key by key id").Stop()
+
+	var apiKey APIKey
+	if err := r.readDB.WithContext(ctx).Where("key_id = ?", keyID).First(&apiKey).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return APIKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+// RevokeAPIKey returns false when the key not found or already revoked.
+func (r *repository) RevokeAPIKey(ctx context.Context, userID, id int) (bool, error) {
+	defer log.Context(ctx).RecordDuration("revoke api key").Stop()
+
+	result := r.writeDB.WithContext(ctx).
+		Model(&APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		log.Context(ctx).Error(result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) UpdateLastUsed(ctx context.Context, id int) error {
+	defer log.Context(ctx).RecordDuration("update api key last used").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Model(&APIKey{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// AcquireSignature returns false when the signature already used within the ttl.
+func (r *repository) AcquireSignature(ctx context.Context, keyID, signature string, ttl time.Duration) (bool, error) {
+	defer log.Context(ctx).RecordDuration("acquire api key signature").Stop()
+
+	acquired, err := r.redis.SetNX(ctx, fmt.Sprintf(signatureKey, keyID, signature), time.Now().Unix(), ttl).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return acquired, nil
+}
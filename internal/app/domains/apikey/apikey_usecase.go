@@ -0,0 +1,239 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/kyc"
+	"go-skeleton-code/internal/app/domains/user"
+	"go-skeleton-code/pkg/client"
+	"go-skeleton-code/pkg/encryption"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/signature"
+)
+
+type usecase struct {
+	apiKeyConfig     config.APIKey
+	encryptor        encryption.Encryptor
+	revocationList   jwt.RevocationList
+	validator        *validator.Validate
+	apiKeyRepository Repository
+	userRepository   user.Repository
+	kycUsecase       kyc.Usecase
+}
+
+// NewUsecase returns new API key usecase.
+func NewUsecase(
+	apiKeyConfig config.APIKey,
+	encryptor encryption.Encryptor,
+	revocationList jwt.RevocationList,
+	validator *validator.Validate,
+	apiKeyRepository Repository,
+	userRepository user.Repository,
+	kycUsecase kyc.Usecase,
+) *usecase {
+	return &usecase{
+		apiKeyConfig:     apiKeyConfig,
+		encryptor:        encryptor,
+		revocationList:   revocationList,
+		validator:        validator,
+		apiKeyRepository: apiKeyRepository,
+		userRepository:   userRepository,
+		kycUsecase:       kycUsecase,
+	}
+}
+
+// CreateAPIKey returns the secret once, only the encrypted secret stored.
+func (u *usecase) CreateAPIKey(ctx context.Context, createReq CreateAPIKeyRequest) (CreateAPIKeyResponse, error) {
+	if err := u.validator.StructCtx(ctx, createReq); err != nil {
+		return CreateAPIKeyResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	userID := jwt.GetPayloadFromContext(ctx).UserID
+
+	total, err := u.apiKeyRepository.CountActiveAPIKeys(ctx, userID)
+	if err != nil {
+		return CreateAPIKeyResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if total >= u.apiKeyConfig.MaxKeys {
+		return CreateAPIKeyResponse{}, serverError.ErrInvalidRequest(ErrTooManyAPIKeys)
+	}
+
+	// Withdrawal only granted to user with KYC level allowing withdrawal
+	if slices.Contains(createReq.Scopes, ScopeWithdraw) {
+		userDetail, err := u.userRepository.FindUserByID(ctx, userID)
+		if err != nil {
+			return CreateAPIKeyResponse{}, serverError.ErrGeneralDatabaseError(err)
+		}
+
+		if err := u.kycUsecase.CheckWithdrawal(ctx, userDetail.KYCLevel); err != nil {
+			return CreateAPIKeyResponse{}, err
+		}
+	}
+
+	keyID, err := generateRandomHex(keyIDLength)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return CreateAPIKeyResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	secret, err := generateRandomHex(secretLength)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return CreateAPIKeyResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	encryptedSecret, err := u.encryptor.Encrypt(secret)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return CreateAPIKeyResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	apiKey, err := u.apiKeyRepository.CreateAPIKey(ctx, APIKey{
+		UserID:      userID,
+		KeyID:       keyIDPrefix + keyID,
+		Secret:      encryptedSecret,
+		Label:       createReq.Label,
+		Scopes:      strings.Join(createReq.Scopes, separatorList),
+		IPAllowlist: strings.Join(createReq.IPAllowlist, separatorList),
+	})
+	if err != nil {
+		return CreateAPIKeyResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return CreateAPIKeyResponse{APIKey: apiKey, Secret: secret}, nil
+}
+
+func (u *usecase) GetAPIKeys(ctx context.Context) ([]APIKey, error) {
+	apiKeys, err := u.apiKeyRepository.GetAPIKeys(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return nil, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return apiKeys, nil
+}
+
+func (u *usecase) RevokeAPIKey(ctx context.Context, id int) error {
+	revoked, err := u.apiKeyRepository.RevokeAPIKey(ctx, jwt.GetPayloadFromContext(ctx).UserID, id)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !revoked {
+		return serverError.ErrDataNotFound(ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// Authenticate verifies the signed request, the same signature only accepted once within the replay window.
+func (u *usecase) Authenticate(ctx context.Context, signedRequest signature.Request) (jwt.Payload, error) {
+	// Reject request signed too long ago or too far in the future
+	requestTime := time.UnixMilli(signedRequest.Timestamp)
+	if elapsed := time.Since(requestTime); elapsed > u.apiKeyConfig.ReplayWindow || elapsed < -u.apiKeyConfig.ReplayWindow {
+		return jwt.Payload{}, serverError.ErrInvalidSignature(ErrRequestExpired)
+	}
+
+	apiKey, err := u.apiKeyRepository.GetAPIKeyByKeyID(ctx, signedRequest.APIKey)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return jwt.Payload{}, serverError.ErrInvalidAPIKey(ErrAPIKeyNotFound)
+	}
+	if err != nil {
+		return jwt.Payload{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if apiKey.RevokedAt != nil {
+		return jwt.Payload{}, serverError.ErrInvalidAPIKey(ErrAPIKeyRevoked)
+	}
+
+	if !apiKey.IsIPAllowed(client.GetFromContext(ctx).IPAddress) {
+		return jwt.Payload{}, serverError.ErrInvalidAPIKey(ErrIPNotAllowed)
+	}
+
+	secret, err := u.encryptor.Decrypt(apiKey.Secret)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return jwt.Payload{}, serverError.ErrGeneralError(err)
+	}
+
+	if !signature.Verify(secret, signedRequest) {
+		return jwt.Payload{}, serverError.ErrInvalidSignature(ErrSignatureMismatch)
+	}
+
+	// Kept for the whole window in both direction, so the request can not be replayed while the timestamp accepted.
+	// Keyed on the MAC bytes, the same signature in different hex letter case is the same request.
+	mac, err := hex.DecodeString(signedRequest.Signature)
+	if err != nil {
+		return jwt.Payload{}, serverError.ErrInvalidSignature(ErrSignatureMismatch)
+	}
+
+	firstUse, err := u.apiKeyRepository.AcquireSignature(ctx, apiKey.KeyID, hex.EncodeToString(mac), 2*u.apiKeyConfig.ReplayWindow)
+	if err != nil {
+		return jwt.Payload{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if !firstUse {
+		return jwt.Payload{}, serverError.ErrInvalidSignature(ErrRequestReplayed)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return jwt.Payload{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if !userDetail.Status {
+		return jwt.Payload{}, serverError.ErrUserBlocked(user.ErrUserInactive)
+	}
+
+	// API key treated as token issued when the key created, so revoking every token of the user, such as after
+	// password change or account blocked, also rejects the API key. The key itself revoked in the database at the
+	// same time, the token revocation expires after the longest token lifetime while the key never expires.
+	payload := jwt.Payload{
+		UserID:     userDetail.ID,
+		Email:      userDetail.Email,
+		IssuedAt:   apiKey.CreatedAt.Unix(),
+		IssuedAtMs: apiKey.CreatedAt.UnixMilli(),
+		Role:       userDetail.Role,
+		APIKeyID:   apiKey.KeyID,
+		Scopes:     apiKey.ScopeList(),
+	}
+
+	// KYC level may be lowered after the key created, withdrawal only granted while the current level allows it
+	if slices.Contains(payload.Scopes, ScopeWithdraw) && u.kycUsecase.CheckWithdrawal(ctx, userDetail.KYCLevel) != nil {
+		payload.Scopes = slices.DeleteFunc(payload.Scopes, func(scope string) bool { return scope == ScopeWithdraw })
+	}
+
+	revoked, err := u.revocationList.IsRevoked(ctx, payload)
+	if err != nil {
+		return jwt.Payload{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if revoked {
+		return jwt.Payload{}, serverError.ErrInvalidAPIKey(ErrAPIKeyRevoked)
+	}
+
+	// Not returning error, the request already authenticated
+	if err := u.apiKeyRepository.UpdateLastUsed(ctx, apiKey.ID); err != nil {
+		log.Context(ctx).Errorf("failed updating last used of api key %v, %v", apiKey.KeyID, err)
+	}
+
+	// Permissions never granted to API key, admin endpoints only accessible using login token
+	return payload, nil
+}
+
+func generateRandomHex(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
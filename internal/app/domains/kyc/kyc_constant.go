@@ -0,0 +1,54 @@
+package kyc
+
+import "errors"
+
+type (
+	Status       string
+	DocumentType string
+)
+
+const (
+	StatusPending  Status = "PENDING"
+	StatusApproved Status = "APPROVED"
+	StatusRejected Status = "REJECTED"
+)
+
+const (
+	DocumentTypeIDCard         DocumentType = "ID_CARD"
+	DocumentTypePassport       DocumentType = "PASSPORT"
+	DocumentTypeDrivingLicense DocumentType = "DRIVING_LICENSE"
+	DocumentTypeProofOfAddress DocumentType = "PROOF_OF_ADDRESS"
+)
+
+const (
+	documentKey            = "kyc/%v/%v%v" // User id, random name and the extension
+	documentNameLength     = 16
+	contentTypeSniffLength = 512
+	maxFormOverhead        = 1 << 20 // Multipart form fields and headers around the document, bytes
+)
+
+func (t DocumentType) IsValid() bool {
+	switch t {
+	case DocumentTypeIDCard, DocumentTypePassport, DocumentTypeDrivingLicense, DocumentTypeProofOfAddress:
+		return true
+	}
+	return false
+}
+
+// documentExtensions of the accepted document content type
+var documentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+var (
+	ErrSubmissionNotFound  = errors.New("kyc submission not found")
+	ErrSubmissionPending   = errors.New("previous kyc submission still waiting for review")
+	ErrSubmissionReviewed  = errors.New("kyc submission already reviewed")
+	ErrLevelAlreadyGranted = errors.New("kyc level already granted")
+	ErrDocumentTooLarge    = errors.New("document exceeds maximum size")
+	ErrUnsupportedDocument = errors.New("document must be jpeg, png or pdf")
+	ErrOrderLimitExceeded  = errors.New("order exceeds limit of every kyc level")
+	ErrWithdrawalDisabled  = errors.New("withdrawal not allowed for every kyc level")
+)
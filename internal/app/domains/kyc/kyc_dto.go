@@ -0,0 +1,13 @@
+package kyc
+
+import (
+	"mime/multipart"
+
+	"go-skeleton-code/internal/app/domains/user"
+)
+
+type SubmitRequest struct {
+	Level        user.KYCLevel         `form:"level" json:"level" validate:"required,enum"`
+	DocumentType DocumentType          `form:"document_type" json:"document_type" validate:"required,enum"`
+	Document     *multipart.FileHeader `form:"document" json:"-" validate:"required"`
+}
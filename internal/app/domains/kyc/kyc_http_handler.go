@@ -0,0 +1,86 @@
+package kyc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	response "go-skeleton-code/pkg/response/gin"
+)
+
+type httpHandler struct {
+	timeout         time.Duration
+	maxDocumentSize int64
+	kycUsecase      Usecase
+	jwtManager      jwt.Manager
+	revocationList  jwt.RevocationList
+}
+
+func NewHTTPHandler(kycUsecase Usecase, timeout time.Duration, maxDocumentSize int64, jwtManager jwt.Manager, revocationList jwt.RevocationList) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
+	return &httpHandler{
+		timeout:         timeout,
+		maxDocumentSize: maxDocumentSize,
+		kycUsecase:      kycUsecase,
+		jwtManager:      jwtManager,
+		revocationList:  revocationList,
+	}
+}
+
+// InitRoutes registers KYC submission of the logged in user, the review is part of admin routes.
+func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
+	v1 := g.Group("/v1/kyc")
+	v1.Use(middleware.ValidateJwtToken(h.jwtManager, h.revocationList))
+	{
+		v1.POST("/submission", h.SubmitDocumentHandler)
+		v1.GET("/submission", h.GetSubmissionsHandler)
+	}
+}
+
+// SubmitDocumentHandler accepts multipart form with level, document_type and document file.
+func (h *httpHandler) SubmitDocumentHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	// Oversized body rejected while reading, before the document spooled into memory or disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxDocumentSize+maxFormOverhead)
+
+	var requestPayload SubmitRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = serverError.ErrInvalidRequest(ErrDocumentTooLarge)
+		}
+
+		response.Failed(c, err)
+		return
+	}
+
+	submission, err := h.kycUsecase.SubmitDocument(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, submission)
+}
+
+func (h *httpHandler) GetSubmissionsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	submissions, err := h.kycUsecase.GetSubmissions(ctx)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, submissions)
+}
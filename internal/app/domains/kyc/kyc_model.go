@@ -0,0 +1,59 @@
+package kyc
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go-skeleton-code/internal/app/domains/user"
+)
+
+// Submission is the document submitted for upgrading the user KYC level, reviewed by admin.
+type Submission struct {
+	ID           int           `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID       int           `json:"user_id" gorm:"column:user_id;type:int"`
+	Level        user.KYCLevel `json:"level" gorm:"column:level;type:varchar;size:32"` // Requested level
+	DocumentType DocumentType  `json:"document_type" gorm:"column:document_type;type:varchar;size:32"`
+	DocumentKey  string        `json:"-" gorm:"column:document_key;type:varchar;size:255"` // Location in the storage
+	ContentType  string        `json:"content_type" gorm:"column:content_type;type:varchar;size:64"`
+	Status       Status        `json:"status" gorm:"column:status;type:varchar;size:32"`
+	ReviewerID   int           `json:"reviewer_id" gorm:"column:reviewer_id;type:int"`
+	ReviewNote   string        `json:"review_note" gorm:"column:review_note;type:text"`
+	ReviewedAt   *time.Time    `json:"reviewed_at" gorm:"column:reviewed_at;type:datetime"`
+	CreatedAt    time.Time     `json:"created_at" gorm:"column:created_at;type:datetime"`
+	UpdatedAt    time.Time     `json:"updated_at" gorm:"column:updated_at;type:datetime"`
+}
+
+func (Submission) TableName() string {
+	return "kyc_submissions"
+}
+
+type Usecase interface {
+	// Submission of the logged in user
+	SubmitDocument(ctx context.Context, submitReq SubmitRequest) (Submission, error)
+	GetSubmissions(ctx context.Context) ([]Submission, error)
+
+	// Used by admin
+	GetSubmissionList(ctx context.Context, status Status, page, limit int) ([]Submission, int, error)
+	GetSubmission(ctx context.Context, id int) (Submission, error)
+	GetDocument(ctx context.Context, id int) (Submission, io.ReadCloser, error)
+	ApproveSubmission(ctx context.Context, id int, note string) (Submission, error)
+	RejectSubmission(ctx context.Context, id int, note string) (Submission, error)
+
+	// Tier limit, the returned error tells the required level
+	CheckOrderLimit(ctx context.Context, level user.KYCLevel, cryptoID int, notional float64) error
+	CheckWithdrawal(ctx context.Context, level user.KYCLevel) error
+}
+
+type Repository interface {
+	CreateSubmission(ctx context.Context, submission Submission) (Submission, error)
+	GetSubmission(ctx context.Context, id int) (Submission, error)
+	GetUserSubmissions(ctx context.Context, userID int) ([]Submission, error)
+	GetSubmissionList(ctx context.Context, status Status, page, limit int) ([]Submission, int, error)
+	HasPendingSubmission(ctx context.Context, userID int) (bool, error)
+	ApproveSubmission(ctx context.Context, submission Submission, userLevel user.KYCLevel) error
+	RejectSubmission(ctx context.Context, submission Submission) error
+
+	// Market price
+	GetConversionRate(ctx context.Context, cryptoID int, symbol string) (float64, error)
+}
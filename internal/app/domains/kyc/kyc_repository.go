@@ -0,0 +1,197 @@
+package kyc
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-skeleton-code/internal/app/domains/user"
+	gormpkg "go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/log"
+)
+
+type repository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// NewRepository returns new KYC Repository.
+func NewRepository(readDB *gorm.DB, writeDB *gorm.DB) *repository {
+	return &repository{
+		readDB:  readDB,
+		writeDB: writeDB,
+	}
+}
+
+func (r *repository) CreateSubmission(ctx context.Context, submission Submission) (Submission, error) {
+	defer log.Context(ctx).RecordDuration("create kyc submission").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&submission).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return Submission{}, err
+	}
+
+	return submission, nil
+}
+
+func (r *repository) GetSubmission(ctx context.Context, id int) (Submission, error) {
+	defer log.Context(ctx).RecordDuration("get kyc submission").Stop()
+
+	var submission Submission
+	if err := r.readDB.WithContext(ctx).First(&submission, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return Submission{}, err
+	}
+
+	return submission, nil
+}
+
+func (r *repository) GetUserSubmissions(ctx context.Context, userID int) ([]Submission, error) {
+	defer log.Context(ctx).RecordDuration("get user kyc submissions").Stop()
+
+	submissions := make([]Submission, 0)
+	if err := r.readDB.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&submissions).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+func (r *repository) GetSubmissionList(ctx context.Context, status Status, page, limit int) ([]Submission, int, error) {
+	defer log.Context(ctx).RecordDuration("get kyc submission list").Stop()
+
+	var (
+		total       int64
+		submissions = make([]Submission, 0)
+		query       = r.readDB.WithContext(ctx).Model(&Submission{})
+	)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	// Oldest submission reviewed first
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(page, limit, "id", "ASC")).Find(&submissions).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return submissions, int(total), nil
+}
+
+func (r *repository) HasPendingSubmission(ctx context.Context, userID int) (bool, error) {
+	defer log.Context(ctx).RecordDuration("check pending kyc submission").Stop()
+
+	var total int64
+	if err := r.readDB.WithContext(ctx).Model(&Submission{}).Where("user_id = ? AND status = ?", userID, StatusPending).Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+// ApproveSubmission marks the submission approved and updates the user level in the same transaction.
+func (r *repository) ApproveSubmission(ctx context.Context, submission Submission, userLevel user.KYCLevel) error {
+	defer log.Context(ctx).RecordDuration("approve kyc submission").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	err := writeDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.reviewSubmission(tx, submission); err != nil {
+			return err
+		}
+
+		return tx.Model(&user.User{}).Where("id = ?", submission.UserID).Update("kyc_level", userLevel).Error
+	})
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) RejectSubmission(ctx context.Context, submission Submission) error {
+	defer log.Context(ctx).RecordDuration("reject kyc submission").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	if err := r.reviewSubmission(writeDB.WithContext(ctx), submission); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// reviewSubmission stores the review result, only pending submission updated so it can't be reviewed twice.
+func (r *repository) reviewSubmission(db *gorm.DB, submission Submission) error {
+	result := db.Model(&Submission{}).
+		Where("id = ? AND status = ?", submission.ID, StatusPending).
+		Updates(map[string]any{
+			"status":      submission.Status,
+			"reviewer_id": submission.ReviewerID,
+			"review_note": submission.ReviewNote,
+			"reviewed_at": submission.ReviewedAt,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrSubmissionReviewed
+	}
+
+	return nil
+}
+
+// GetConversionRate returns the price of the crypto in the crypto with the symbol, using the latest trade of the pair
+// between both crypto. Returns gorm.ErrRecordNotFound when there is no such market.
+func (r *repository) GetConversionRate(ctx context.Context, cryptoID int, symbol string) (float64, error) {
+	defer log.Context(ctx).RecordDuration("get conversion rate").Stop()
+
+	rawQuery := `SELECT CASE WHEN c.id = ? THEN 1
+			WHEN p.primary_crypto_id = ? THEN m.price
+			ELSE 1 / m.price END AS rate
+		FROM cryptos c
+		LEFT JOIN pairs p ON p.deleted_at IS NULL AND (
+			(p.primary_crypto_id = ? AND p.secondary_crypto_id = c.id) OR
+			(p.primary_crypto_id = c.id AND p.secondary_crypto_id = ?))
+		LEFT JOIN LATERAL (
+			SELECT price FROM match_orders
+			WHERE pair_id = p.id AND deleted_at IS NULL AND price > 0
+			ORDER BY id DESC
+			LIMIT 1
+		) m ON true
+		WHERE c.symbol = ? AND c.deleted_at IS NULL AND (c.id = ? OR m.price IS NOT NULL)
+		LIMIT 1`
+
+	var rates []float64
+	err := r.readDB.WithContext(ctx).Raw(rawQuery, cryptoID, cryptoID, cryptoID, cryptoID, symbol, cryptoID).Scan(&rates).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	if len(rates) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return rates[0], nil
+}
@@ -0,0 +1,303 @@
+package kyc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/user"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/storage"
+)
+
+type usecase struct {
+	kycConfig      config.KYC
+	tiers          map[user.KYCLevel]config.KYCTier
+	storage        storage.Storage
+	validator      *validator.Validate
+	kycRepository  Repository
+	userRepository user.Repository
+}
+
+// NewUsecase returns new KYC usecase.
+func NewUsecase(
+	kycConfig config.KYC,
+	storage storage.Storage,
+	validator *validator.Validate,
+	kycRepository Repository,
+	userRepository user.Repository,
+) *usecase {
+	return &usecase{
+		kycConfig: kycConfig,
+		tiers: map[user.KYCLevel]config.KYCTier{
+			user.KYCLevelUnverified: kycConfig.Tiers.Unverified,
+			user.KYCLevelBasic:      kycConfig.Tiers.Basic,
+			user.KYCLevelFull:       kycConfig.Tiers.Full,
+		},
+		storage:        storage,
+		validator:      validator,
+		kycRepository:  kycRepository,
+		userRepository: userRepository,
+	}
+}
+
+// SubmitDocument stores the document and waits for admin review, only one submission reviewed at a time.
+func (u *usecase) SubmitDocument(ctx context.Context, submitReq SubmitRequest) (Submission, error) {
+	if err := u.validator.StructCtx(ctx, submitReq); err != nil {
+		return Submission{}, serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return Submission{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.KYCLevel.Rank() >= submitReq.Level.Rank() {
+		return Submission{}, serverError.ErrInvalidRequest(ErrLevelAlreadyGranted)
+	}
+
+	hasPending, err := u.kycRepository.HasPendingSubmission(ctx, userDetail.ID)
+	if err != nil {
+		return Submission{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if hasPending {
+		return Submission{}, serverError.ErrConflict(ErrSubmissionPending)
+	}
+
+	if submitReq.Document.Size > u.kycConfig.MaxDocumentSize {
+		return Submission{}, serverError.ErrInvalidRequest(ErrDocumentTooLarge)
+	}
+
+	document, err := submitReq.Document.Open()
+	if err != nil {
+		return Submission{}, serverError.ErrInvalidRequest(err)
+	}
+	defer document.Close()
+
+	// Detected from the content, the client provided content type can't be trusted
+	head := make([]byte, contentTypeSniffLength)
+	n, err := io.ReadFull(document, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return Submission{}, serverError.ErrInvalidRequest(err)
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	extension, ok := documentExtensions[contentType]
+	if !ok {
+		return Submission{}, serverError.ErrInvalidRequest(ErrUnsupportedDocument)
+	}
+
+	documentName, err := generateRandomHex(documentNameLength)
+	if err != nil {
+		return Submission{}, serverError.ErrGeneralError(err)
+	}
+
+	documentKey := fmt.Sprintf(documentKey, userDetail.ID, documentName, extension)
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), document), u.kycConfig.MaxDocumentSize)
+	if err := u.storage.Save(ctx, documentKey, content); err != nil {
+		log.Context(ctx).Error(err)
+		return Submission{}, serverError.ErrGeneralError(err)
+	}
+
+	submission, err := u.kycRepository.CreateSubmission(ctx, Submission{
+		UserID:       userDetail.ID,
+		Level:        submitReq.Level,
+		DocumentType: submitReq.DocumentType,
+		DocumentKey:  documentKey,
+		ContentType:  contentType,
+		Status:       StatusPending,
+	})
+	if err != nil {
+		if err := u.storage.Delete(ctx, documentKey); err != nil {
+			log.Context(ctx).Errorf("failed deleting kyc document %v, %v", documentKey, err)
+		}
+		return Submission{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return submission, nil
+}
+
+func (u *usecase) GetSubmissions(ctx context.Context) ([]Submission, error) {
+	submissions, err := u.kycRepository.GetUserSubmissions(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return nil, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return submissions, nil
+}
+
+func (u *usecase) GetSubmissionList(ctx context.Context, status Status, page, limit int) ([]Submission, int, error) {
+	submissions, total, err := u.kycRepository.GetSubmissionList(ctx, status, page, limit)
+	if err != nil {
+		return nil, 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return submissions, total, nil
+}
+
+// GetDocument returns the submitted document, the caller must close the reader.
+func (u *usecase) GetSubmission(ctx context.Context, id int) (Submission, error) {
+	return u.getSubmission(ctx, id)
+}
+
+func (u *usecase) GetDocument(ctx context.Context, id int) (Submission, io.ReadCloser, error) {
+	submission, err := u.getSubmission(ctx, id)
+	if err != nil {
+		return Submission{}, nil, err
+	}
+
+	document, err := u.storage.Open(ctx, submission.DocumentKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return Submission{}, nil, serverError.ErrDataNotFound(err)
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return Submission{}, nil, serverError.ErrGeneralError(err)
+	}
+
+	return submission, document, nil
+}
+
+// ApproveSubmission grants the requested level, level never lowered when the user already has higher level.
+func (u *usecase) ApproveSubmission(ctx context.Context, id int, note string) (Submission, error) {
+	submission, err := u.getSubmission(ctx, id)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, submission.UserID)
+	if err != nil {
+		return Submission{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	userLevel := userDetail.KYCLevel
+	if submission.Level.Rank() > userLevel.Rank() {
+		userLevel = submission.Level
+	}
+
+	submission = reviewed(ctx, submission, StatusApproved, note)
+	if err := u.kycRepository.ApproveSubmission(ctx, submission, userLevel); err != nil {
+		return Submission{}, reviewError(err)
+	}
+
+	return submission, nil
+}
+
+func (u *usecase) RejectSubmission(ctx context.Context, id int, note string) (Submission, error) {
+	submission, err := u.getSubmission(ctx, id)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	submission = reviewed(ctx, submission, StatusRejected, note)
+	if err := u.kycRepository.RejectSubmission(ctx, submission); err != nil {
+		return Submission{}, reviewError(err)
+	}
+
+	return submission, nil
+}
+
+// CheckOrderLimit requires the lowest level allowed to place order with the notional, given in the crypto
+// the order priced in. Order with unknown notional, e.g. market order or crypto without market price, only
+// allowed for level without notional limit.
+func (u *usecase) CheckOrderLimit(ctx context.Context, level user.KYCLevel, cryptoID int, notional float64) error {
+	notional, err := u.convertNotional(ctx, cryptoID, notional)
+	if err != nil {
+		return err
+	}
+
+	return u.checkLevel(level, ErrOrderLimitExceeded, func(tier config.KYCTier) bool {
+		return tier.Trading && (tier.MaxOrderNotional == 0 || (notional > 0 && notional <= tier.MaxOrderNotional))
+	})
+}
+
+func (u *usecase) CheckWithdrawal(ctx context.Context, level user.KYCLevel) error {
+	return u.checkLevel(level, ErrWithdrawalDisabled, func(tier config.KYCTier) bool {
+		return tier.Withdrawal
+	})
+}
+
+// convertNotional converts the notional into the notional currency using the latest price, 0 when the rate is unknown.
+func (u *usecase) convertNotional(ctx context.Context, cryptoID int, notional float64) (float64, error) {
+	if notional <= 0 {
+		return 0, nil
+	}
+
+	rate, err := u.kycRepository.GetConversionRate(ctx, cryptoID, u.kycConfig.NotionalCurrency)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return notional * rate, nil
+}
+
+// checkLevel finds the lowest level allowed, errNotAllowed returned when none of the level allowed.
+func (u *usecase) checkLevel(level user.KYCLevel, errNotAllowed error, allowed func(tier config.KYCTier) bool) error {
+	for _, requiredLevel := range user.KYCLevels {
+		if !allowed(u.tiers[requiredLevel]) {
+			continue
+		}
+
+		if level.Rank() >= requiredLevel.Rank() {
+			return nil
+		}
+
+		return serverError.ErrKYCLevelRequired(string(requiredLevel))
+	}
+
+	return serverError.ErrInvalidRequest(errNotAllowed)
+}
+
+func (u *usecase) getSubmission(ctx context.Context, id int) (Submission, error) {
+	submission, err := u.kycRepository.GetSubmission(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Submission{}, serverError.ErrDataNotFound(ErrSubmissionNotFound)
+	}
+	if err != nil {
+		return Submission{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return submission, nil
+}
+
+func reviewed(ctx context.Context, submission Submission, status Status, note string) Submission {
+	now := time.Now()
+	submission.Status = status
+	submission.ReviewerID = jwt.GetPayloadFromContext(ctx).UserID
+	submission.ReviewNote = note
+	submission.ReviewedAt = &now
+
+	return submission
+}
+
+func reviewError(err error) error {
+	if errors.Is(err, ErrSubmissionReviewed) {
+		return serverError.ErrConflict(err)
+	}
+
+	return serverError.ErrGeneralDatabaseError(err)
+}
+
+func generateRandomHex(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
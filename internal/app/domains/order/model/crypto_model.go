@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Crypto is a tradable asset, referenced by pairs and wallets.
+type Crypto struct {
+	ID        int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	Symbol    string     `json:"symbol" gorm:"column:symbol;type:varchar;size:32"`
+	Name      string     `json:"name" gorm:"column:name;type:varchar;size:255"`
+	Decimals  int        `json:"decimals" gorm:"column:decimals;type:int"` // Number of decimal places supported by the asset
+	Status    bool       `json:"status" gorm:"column:status;type:tinyint"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"column:updated_at;type:datetime"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"column:deleted_at;type:datetime"`
+}
+
+func (Crypto) TableName() string {
+	return "cryptos"
+}
+
+// CryptoUpdate is published every time crypto data changed, so cached copy can be invalidated.
+type CryptoUpdate struct {
+	CryptoID int `json:"crypto_id"`
+}
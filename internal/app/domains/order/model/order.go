@@ -5,12 +5,20 @@ import "context"
 type Usecase interface {
 	ProcessOrder(ctx context.Context, orderReq OrderRequest) (Order, error)
 	MatchOrder(ctx context.Context, tradeReq TradeRequest) error
+	GetOrderEvents(ctx context.Context, orderID int) ([]OrderEvent, error)
+	GetUserOrderEvents(ctx context.Context, eventReq OrderEventRequest) ([]OrderEvent, error)
+	HaltPair(ctx context.Context, pairID int, reason string) (Pair, error)
+	ResumePair(ctx context.Context, pairID int) (Pair, error)
 }
 
 type Repository interface {
+	// Crypto
+	GetCryptoDetail(ctx context.Context, id int) (Crypto, error)
+
 	// Crypto Pair
 	GetPairDetail(ctx context.Context, code string) (Pair, error)
 	GetPairDetailByID(ctx context.Context, id int) (Pair, error)
+	UpdatePairStatus(ctx context.Context, id int, status PairStatus, reason string) (Pair, error)
 
 	// User Order
 	SaveOrder(ctx context.Context, order Order) (Order, error)
@@ -18,8 +26,23 @@ type Repository interface {
 
 	// Matching Order
 	SaveMatchOrder(ctx context.Context, matchOrder MatchOrder) error
+	SavePendingTrade(ctx context.Context, pendingTrade PendingTrade) error
+	GetPendingTrades(ctx context.Context, pairID int, limit int) ([]PendingTrade, error)
+	MarkPendingTradeSettled(ctx context.Context, id int) error
+	MarkPendingTradeFailed(ctx context.Context, id int, reason string) error
+
+	// Order Event
+	SaveOrderEvent(ctx context.Context, event OrderEvent) (OrderEvent, error)
+	GetOrderEvents(ctx context.Context, orderID int) ([]OrderEvent, error)
+	GetUserOrderEvents(ctx context.Context, userID int, fromSequence int64, limit int) ([]OrderEvent, error)
 
 	// Wallet
 	GetUserWallet(ctx context.Context, userID, cryptoID int) (Wallet, error)
 	UpdateUserWallet(ctx context.Context, userID, cryptoID int, amount float64) error
 }
+
+type CircuitBreaker interface {
+	// Check record the trade price, tripped when price moved beyond the limit
+	Check(ctx context.Context, pairID int, price float64) (reason string, tripped bool, err error)
+	Reset(ctx context.Context, pairID int) error
+}
@@ -3,11 +3,16 @@ package model
 import "encoding/json"
 
 type OrderRequest struct {
-	PairCode string  `json:"pair_code"`
-	Quantity float64 `json:"quantity"`
-	Price    float64 `json:"price"`
-	Side     Side    `json:"side"` // BUY / SELL
-	Type     Type    `json:"type"` // MARKET / LIMIT
+	PairCode string  `json:"pair_code" validate:"required"`
+	Quantity float64 `json:"quantity" validate:"gt=0"`
+	Price    float64 `json:"price" validate:"gte=0,required_unless=Type MARKET"`
+	Side     Side    `json:"side" validate:"required,enum"` // BUY / SELL
+	Type     Type    `json:"type" validate:"required,enum"` // MARKET / LIMIT / STOP_LOSS / TAKE_PROFIT
+}
+
+type OrderEventRequest struct {
+	FromSequence int64 `form:"from_sequence"` // Return events with user sequence greater than this value
+	Limit        int   `form:"limit"`
 }
 
 type TradeRequest struct {
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type EventType string
+
+const (
+	OrderEventCreated         EventType = "CREATED"
+	OrderEventPartiallyFilled EventType = "PARTIALLY_FILLED"
+	OrderEventFilled          EventType = "FILLED"
+	OrderEventRejected        EventType = "REJECTED"  // Order refused on placement, the order saved with failed status
+	OrderEventCancelled       EventType = "CANCELLED" // Reserved, order cancellation not supported yet
+	OrderEventExpired         EventType = "EXPIRED"   // Reserved, order expiry not supported yet
+)
+
+const (
+	// Scope of the sequence counter stored in table order_event_sequences
+	SequenceScopeOrder = "ORDER"
+	SequenceScopeUser  = "USER"
+)
+
+// OrderEvent is an append-only record of a single change to an order.
+type OrderEvent struct {
+	ID              int       `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	OrderID         int       `json:"order_id" gorm:"column:order_id;type:int"`
+	UserID          int       `json:"user_id" gorm:"column:user_id;type:int"`
+	OrderSequence   int64     `json:"order_sequence" gorm:"column:order_sequence;type:bigint"` // Sequence number per order
+	UserSequence    int64     `json:"user_sequence" gorm:"column:user_sequence;type:bigint"`   // Sequence number per user
+	Type            EventType `json:"type" gorm:"column:type;type:text"`
+	Status          Status    `json:"status" gorm:"column:status;type:text"`                     // Order status after the event
+	Quantity        float64   `json:"quantity" gorm:"column:quantity;type:double"`               // Quantity affected by the event
+	Price           float64   `json:"price" gorm:"column:price;type:double"`                     // Price applied by the event
+	FilledQuantity  float64   `json:"filled_quantity" gorm:"column:filled_quantity;type:double"` // Order filled quantity after the event
+	TransactionTime int64     `json:"transaction_time" gorm:"column:transaction_time;type:bigint"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (OrderEvent) TableName() string {
+	return "order_events"
+}
+
+// NewOrderEvent creates event from the latest order state.
+func NewOrderEvent(order Order, eventType EventType, quantity, price float64, transactionTime int64) OrderEvent {
+	return OrderEvent{
+		OrderID:         order.ID,
+		UserID:          order.UserID,
+		Type:            eventType,
+		Status:          order.Status,
+		Quantity:        quantity,
+		Price:           price,
+		FilledQuantity:  order.FilledQuantity,
+		TransactionTime: transactionTime,
+	}
+}
+
+// FillEventType returns event type for an order that just received a trade.
+func (o Order) FillEventType() EventType {
+	if o.Status == OrderStatusComplete {
+		return OrderEventFilled
+	}
+	return OrderEventPartiallyFilled
+}
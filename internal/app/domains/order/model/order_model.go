@@ -30,6 +30,18 @@ const (
 	OrderStatusPartial  Status = "PARTIAL"
 )
 
+func (s Side) IsValid() bool {
+	return s == OrderSideBuy || s == OrderSideSell
+}
+
+func (t Type) IsValid() bool {
+	switch t {
+	case OrderTypeMarket, OrderTypeLimit, OrderTypeStopLoss, OrderTypeTakeProfit:
+		return true
+	}
+	return false
+}
+
 var (
 	ErrInsufficientBalance = errors.New("Insufficient balance")
 )
@@ -1,12 +1,38 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type PairStatus string
+
+const (
+	PairStatusTrading PairStatus = "TRADING"
+	PairStatusHalted  PairStatus = "HALTED"
+)
+
+var (
+	ErrQuantityOutOfRange = errors.New("quantity outside the allowed range of the pair")
+	ErrInvalidLotSize     = errors.New("quantity is not a multiple of the pair lot size")
+	ErrInvalidPriceTick   = errors.New("price is not a multiple of the pair price tick")
+	ErrNotionalTooSmall   = errors.New("order value below the pair minimum notional")
+)
 
 type Pair struct {
 	ID                int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
 	Code              string     `json:"code" gorm:"column:code;type:varchar;size:255"`
-	PrimaryCryptoID   int        `json:"primary_crypto_id" gorm:"column:primary_crypto_id;type:int"`
-	SecondaryCryptoID int        `json:"secondary_crypto_id" gorm:"column:secondary_crypto_id;type:int"`
+	PrimaryCryptoID   int        `json:"primary_crypto_id" gorm:"column:primary_crypto_id;type:int"`     // Base asset
+	SecondaryCryptoID int        `json:"secondary_crypto_id" gorm:"column:secondary_crypto_id;type:int"` // Quote asset
+	Status            PairStatus `json:"status" gorm:"column:status;type:text"`
+	HaltReason        string     `json:"halt_reason" gorm:"column:halt_reason;type:text"`
+	MinQuantity       float64    `json:"min_quantity" gorm:"column:min_quantity;type:double"`
+	MaxQuantity       float64    `json:"max_quantity" gorm:"column:max_quantity;type:double"`
+	QuantityStep      float64    `json:"quantity_step" gorm:"column:quantity_step;type:double"` // Lot size
+	PriceTick         float64    `json:"price_tick" gorm:"column:price_tick;type:double"`       // Minimum price movement
+	MinNotional       float64    `json:"min_notional" gorm:"column:min_notional;type:double"`   // Minimum price * quantity
 	CreatedAt         time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
 	UpdatedAt         time.Time  `json:"updated_at" gorm:"column:updated_at;type:datetime"`
 	DeletedAt         *time.Time `json:"deleted_at" gorm:"column:deleted_at;type:datetime"`
@@ -15,3 +41,49 @@ type Pair struct {
 func (Pair) TableName() string {
 	return "pairs"
 }
+
+func (p Pair) IsHalted() bool {
+	return p.Status == PairStatusHalted
+}
+
+// ValidateOrder checks the order against the trading rules of the pair, zero rule means not limited.
+// Price related rules skipped for market order since the price decided by the matching engine.
+func (p Pair) ValidateOrder(orderReq OrderRequest) error {
+	if orderReq.Quantity < p.MinQuantity || (p.MaxQuantity > 0 && orderReq.Quantity > p.MaxQuantity) {
+		return fmt.Errorf("%w, min %v max %v", ErrQuantityOutOfRange, p.MinQuantity, p.MaxQuantity)
+	}
+
+	if !isMultipleOf(orderReq.Quantity, p.QuantityStep) {
+		return fmt.Errorf("%w %v", ErrInvalidLotSize, p.QuantityStep)
+	}
+
+	if orderReq.Type == OrderTypeMarket {
+		return nil
+	}
+
+	if !isMultipleOf(orderReq.Price, p.PriceTick) {
+		return fmt.Errorf("%w %v", ErrInvalidPriceTick, p.PriceTick)
+	}
+
+	if orderReq.Price*orderReq.Quantity < p.MinNotional {
+		return fmt.Errorf("%w %v", ErrNotionalTooSmall, p.MinNotional)
+	}
+
+	return nil
+}
+
+// isMultipleOf tolerates floating point error, e.g. 0.3 is a multiple of 0.1.
+func isMultipleOf(value, step float64) bool {
+	if step <= 0 {
+		return true
+	}
+
+	ratio := value / step
+	return math.Abs(ratio-math.Round(ratio)) < 1e-9
+}
+
+// PairUpdate is published every time pair data changed, so cached copy can be invalidated.
+type PairUpdate struct {
+	PairID int    `json:"pair_id"`
+	Code   string `json:"code"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// PendingTrade is a trade received while the pair halted, settled after trading resumed.
+type PendingTrade struct {
+	ID           int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	PairID       int        `json:"pair_id" gorm:"column:pair_id;type:int"`
+	TakerOrderID int        `json:"taker_order_id" gorm:"column:taker_order_id;type:int"`
+	MakerOrderID int        `json:"maker_order_id" gorm:"column:maker_order_id;type:int"`
+	Quantity     float64    `json:"quantity" gorm:"column:quantity;type:double"`
+	Price        float64    `json:"price" gorm:"column:price;type:double"`
+	Side         Side       `json:"side" gorm:"column:side;type:text"`
+	TradeTime    int64      `json:"trade_time" gorm:"column:trade_time;type:bigint"`
+	SettledAt    *time.Time `json:"settled_at" gorm:"column:settled_at;type:datetime"`
+	FailedAt     *time.Time `json:"failed_at" gorm:"column:failed_at;type:datetime"` // Skipped after settlement failed, needs manual review
+	FailReason   string     `json:"fail_reason" gorm:"column:fail_reason;type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (PendingTrade) TableName() string {
+	return "pending_trades"
+}
+
+func NewPendingTrade(tradeReq TradeRequest) PendingTrade {
+	return PendingTrade{
+		PairID:       tradeReq.PairID,
+		TakerOrderID: tradeReq.TakerOrderID,
+		MakerOrderID: tradeReq.MakerOrderID,
+		Quantity:     tradeReq.Quantity,
+		Price:        tradeReq.Price,
+		Side:         tradeReq.Side,
+		TradeTime:    tradeReq.TradeTime,
+	}
+}
+
+func (p PendingTrade) TradeRequest() TradeRequest {
+	return TradeRequest{
+		PairID:       p.PairID,
+		TakerOrderID: p.TakerOrderID,
+		MakerOrderID: p.MakerOrderID,
+		Quantity:     p.Quantity,
+		Price:        p.Price,
+		Side:         p.Side,
+		TradeTime:    p.TradeTime,
+	}
+}
@@ -0,0 +1,178 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/pkg/cache"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	cacheHit  = "cache hit"
+	cacheMiss = "cache miss"
+
+	pairDetailKey   = "pair-detail:%v"
+	pairCodeKey     = "pair-code:%v"
+	cryptoDetailKey = "crypto-detail:%v"
+)
+
+// cachedRepository is a read-through cache for pair and crypto detail, in memory LRU
+// as the first level and redis as the second level. Every other method goes directly
+// to the underlying repository.
+type cachedRepository struct {
+	model.Repository
+	redis       *redis.Client
+	cacheConfig config.Cache
+	pairs       *cache.LRU[int, model.Pair]
+	pairCodes   *cache.LRU[string, int] // Pair code to pair id
+	cryptos     *cache.LRU[int, model.Crypto]
+}
+
+// NewCachedRepository returns order Repository with cached pair and crypto detail.
+func NewCachedRepository(repository model.Repository, redis *redis.Client, cacheConfig config.Cache) *cachedRepository {
+	return &cachedRepository{
+		Repository:  repository,
+		redis:       redis,
+		cacheConfig: cacheConfig,
+		pairs:       cache.NewLRU[int, model.Pair](cacheConfig.Local.Size, cacheConfig.Local.TTL),
+		pairCodes:   cache.NewLRU[string, int](cacheConfig.Local.Size, cacheConfig.Local.TTL),
+		cryptos:     cache.NewLRU[int, model.Crypto](cacheConfig.Local.Size, cacheConfig.Local.TTL),
+	}
+}
+
+func (r *cachedRepository) GetCryptoDetail(ctx context.Context, id int) (model.Crypto, error) {
+	return readThrough(ctx, r, "crypto detail", r.cryptos, id, fmt.Sprintf(cryptoDetailKey, id), func() (model.Crypto, error) {
+		return r.Repository.GetCryptoDetail(ctx, id)
+	})
+}
+
+func (r *cachedRepository) GetPairDetailByID(ctx context.Context, id int) (model.Pair, error) {
+	return readThrough(ctx, r, "pair detail", r.pairs, id, fmt.Sprintf(pairDetailKey, id), func() (model.Pair, error) {
+		return r.Repository.GetPairDetailByID(ctx, id)
+	})
+}
+
+func (r *cachedRepository) GetPairDetail(ctx context.Context, code string) (model.Pair, error) {
+	pairID, err := readThrough(ctx, r, "pair code", r.pairCodes, code, fmt.Sprintf(pairCodeKey, code), func() (int, error) {
+		pair, err := r.Repository.GetPairDetail(ctx, code)
+		if err != nil {
+			return 0, err
+		}
+
+		r.setPair(ctx, pair) // Avoid second database query for the pair detail
+		return pair.ID, nil
+	})
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	pair, err := r.GetPairDetailByID(ctx, pairID)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	// Code already moved to other pair, the cached code is outdated
+	if pair.Code != code {
+		r.pairCodes.Delete(code)
+		r.redis.Del(ctx, fmt.Sprintf(pairCodeKey, code))
+		return r.Repository.GetPairDetail(ctx, code)
+	}
+
+	return pair, nil
+}
+
+func (r *cachedRepository) UpdatePairStatus(ctx context.Context, id int, status model.PairStatus, reason string) (model.Pair, error) {
+	pair, err := r.Repository.UpdatePairStatus(ctx, id, status, reason)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	// Other instance notified through pubsub, this instance must see the change immediately
+	r.InvalidatePair(ctx, model.PairUpdate{PairID: pair.ID, Code: pair.Code})
+	return pair, nil
+}
+
+// InvalidatePair remove pair from every cache level.
+func (r *cachedRepository) InvalidatePair(ctx context.Context, pairUpdate model.PairUpdate) {
+	r.pairs.Delete(pairUpdate.PairID)
+	r.pairCodes.Delete(pairUpdate.Code)
+
+	redisKeys := []string{fmt.Sprintf(pairDetailKey, pairUpdate.PairID), fmt.Sprintf(pairCodeKey, pairUpdate.Code)}
+	if err := r.redis.Del(ctx, redisKeys...).Err(); err != nil {
+		log.Context(ctx).Error(err)
+	}
+}
+
+// InvalidateCrypto remove crypto from every cache level.
+func (r *cachedRepository) InvalidateCrypto(ctx context.Context, cryptoUpdate model.CryptoUpdate) {
+	r.cryptos.Delete(cryptoUpdate.CryptoID)
+
+	if err := r.redis.Del(ctx, fmt.Sprintf(cryptoDetailKey, cryptoUpdate.CryptoID)).Err(); err != nil {
+		log.Context(ctx).Error(err)
+	}
+}
+
+func (r *cachedRepository) setPair(ctx context.Context, pair model.Pair) {
+	r.pairs.Set(pair.ID, pair)
+	r.setRemote(ctx, fmt.Sprintf(pairDetailKey, pair.ID), pair)
+}
+
+func (r *cachedRepository) setRemote(ctx context.Context, redisKey string, value any) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return
+	}
+
+	if err := r.redis.Set(ctx, redisKey, valueJSON, r.cacheConfig.TTL).Err(); err != nil {
+		log.Context(ctx).Error(err)
+	}
+}
+
+// readThrough looks up the value in memory, then in redis, and finally from the loader.
+// Every level found on the way back is filled with the loaded value.
+func readThrough[K comparable, V any](
+	ctx context.Context,
+	r *cachedRepository,
+	name string,
+	local *cache.LRU[K, V],
+	localKey K,
+	redisKey string,
+	loader func() (V, error),
+) (V, error) {
+	// First level, in memory
+	timeRecord := log.Context(ctx).RecordDuration(fmt.Sprintf("get %s from local cache", name))
+	if value, found := local.Get(localKey); found {
+		timeRecord.StopWithResult(cacheHit)
+		return value, nil
+	}
+	timeRecord.StopWithResult(cacheMiss)
+
+	// Second level, redis
+	var value V
+	timeRecord = log.Context(ctx).RecordDuration(fmt.Sprintf("get %s from redis cache", name))
+	if valueJSON, err := r.redis.Get(ctx, redisKey).Bytes(); err == nil && json.Unmarshal(valueJSON, &value) == nil {
+		timeRecord.StopWithResult(cacheHit)
+		local.Set(localKey, value)
+		return value, nil
+	} else if err != nil && err != redis.Nil {
+		log.Context(ctx).Error(err) // Redis unavailable, continue to database
+	}
+	timeRecord.StopWithResult(cacheMiss)
+
+	value, err := loader()
+	if err != nil {
+		return value, err
+	}
+
+	local.Set(localKey, value)
+	r.setRemote(ctx, redisKey, value)
+
+	return value, nil
+}
@@ -0,0 +1,88 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	circuitBreakerKey = "circuit-breaker:%v"
+)
+
+// circuitBreaker keep recent trade prices per pair inside redis sorted set, scored by the time
+// the trade received. Price movement measured against the oldest price inside the window.
+type circuitBreaker struct {
+	redis  *redis.Client
+	config config.CircuitBreaker
+}
+
+// NewCircuitBreaker returns new order CircuitBreaker.
+func NewCircuitBreaker(redis *redis.Client, config config.CircuitBreaker) *circuitBreaker {
+	return &circuitBreaker{
+		redis:  redis,
+		config: config,
+	}
+}
+
+func (c *circuitBreaker) Check(ctx context.Context, pairID int, price float64) (string, bool, error) {
+	if !c.config.Enabled || price <= 0 {
+		return "", false, nil
+	}
+
+	defer log.Context(ctx).RecordDuration("check circuit breaker").Stop()
+
+	var (
+		now         = time.Now()
+		key         = fmt.Sprintf(circuitBreakerKey, pairID)
+		windowStart = now.Add(-c.config.Window).UnixNano()
+		member      = fmt.Sprintf("%v:%v", price, now.UnixNano()) // Unique member for the same price
+	)
+
+	pipeline := c.redis.TxPipeline()
+	pipeline.ZAdd(ctx, key, &redis.Z{Score: float64(now.UnixNano()), Member: member})
+	pipeline.ZRemRangeByScore(ctx, key, "-inf", fmt.Sprintf("(%d", windowStart))
+	oldest := pipeline.ZRange(ctx, key, 0, 0)
+	pipeline.Expire(ctx, key, c.config.Window)
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		log.Context(ctx).Error(err)
+		return "", false, err
+	}
+
+	if len(oldest.Val()) == 0 {
+		return "", false, nil
+	}
+
+	referencePrice, err := strconv.ParseFloat(strings.Split(oldest.Val()[0], ":")[0], 64)
+	if err != nil || referencePrice <= 0 {
+		log.Context(ctx).Error(err)
+		return "", false, err
+	}
+
+	movement := math.Abs(price-referencePrice) / referencePrice * 100
+	if movement <= c.config.Threshold {
+		return "", false, nil
+	}
+
+	reason := fmt.Sprintf("circuit breaker, price moved %.2f%% within %v", movement, c.config.Window)
+	return reason, true, nil
+}
+
+// Reset clear the price history, so resumed pair is not halted again by the same movement.
+func (c *circuitBreaker) Reset(ctx context.Context, pairID int) error {
+	if err := c.redis.Del(ctx, fmt.Sprintf(circuitBreakerKey, pairID)).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -2,37 +2,46 @@ package order
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
-	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/apikey"
 	"go-skeleton-code/internal/app/domains/order/model"
 	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
 	response "go-skeleton-code/pkg/response/gin"
 )
 
 type httpHandler struct {
 	timeout        time.Duration
 	orderUsecase   model.Usecase
-	securityConfig config.Security
+	jwtManager     jwt.Manager
+	revocationList jwt.RevocationList
+	authenticator  middleware.APIKeyAuthenticator
 }
 
-func NewHTTPHandler(orderUsecase model.Usecase, timeout time.Duration, securityConfig config.Security) interface {
+func NewHTTPHandler(orderUsecase model.Usecase, timeout time.Duration, jwtManager jwt.Manager, revocationList jwt.RevocationList, authenticator middleware.APIKeyAuthenticator) interface {
 	InitRoutes(g *gin.RouterGroup)
 } {
 	return &httpHandler{
 		timeout:        timeout,
 		orderUsecase:   orderUsecase,
-		securityConfig: securityConfig,
+		jwtManager:     jwtManager,
+		revocationList: revocationList,
+		authenticator:  authenticator,
 	}
 }
 
 func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
 	v1 := g.Group("/v1/order")
-	v1.Use(middleware.ValidateJwtToken([]byte(h.securityConfig.Jwt.Key)))
+	v1.Use(middleware.ValidateJwtTokenOrAPIKey(h.jwtManager, h.revocationList, h.authenticator))
 	{
-		v1.POST("", h.OrderHandler)
+		v1.POST("", middleware.RequireScope(apikey.ScopeTrade), h.OrderHandler)
+		v1.GET("/events", middleware.RequireScope(apikey.ScopeRead), h.UserOrderEventsHandler)
+		v1.GET("/:id/events", middleware.RequireScope(apikey.ScopeRead), h.OrderEventsHandler)
 	}
 }
 
@@ -54,3 +63,41 @@ func (h *httpHandler) OrderHandler(c *gin.Context) {
 
 	response.Success(c, orderResult)
 }
+
+func (h *httpHandler) OrderEventsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	events, err := h.orderUsecase.GetOrderEvents(ctx, orderID)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, events)
+}
+
+func (h *httpHandler) UserOrderEventsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload model.OrderEventRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	events, err := h.orderUsecase.GetUserOrderEvents(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, events)
+}
@@ -0,0 +1,78 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/pkg/log"
+)
+
+type cacheInvalidator interface {
+	InvalidatePair(ctx context.Context, pairUpdate model.PairUpdate)
+	InvalidateCrypto(ctx context.Context, cryptoUpdate model.CryptoUpdate)
+}
+
+type pubSubHandler struct {
+	subscriber  *redis.PubSub
+	invalidator cacheInvalidator
+	cacheConfig config.Cache
+	timeout     time.Duration
+}
+
+func NewPubSubHandler(subscriber *redis.PubSub, invalidator cacheInvalidator, cacheConfig config.Cache, timeout time.Duration) *pubSubHandler {
+	return &pubSubHandler{
+		subscriber:  subscriber,
+		invalidator: invalidator,
+		cacheConfig: cacheConfig,
+		timeout:     timeout,
+	}
+}
+
+func (h *pubSubHandler) StartSubscriber() {
+	go func() {
+		// Channel closed when the subscriber closed
+		for message := range h.subscriber.Channel() {
+			func() {
+				ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+				defer func() { log.Context(ctx).Save(); cancel() }()
+
+				ctx = log.NewRequest().SaveToContext(ctx)
+				log.Context(ctx).Method = "SUBSCRIBER"
+				log.Context(ctx).URL = message.Channel
+
+				if err := h.InvalidateCacheHandler(ctx, message.Channel, []byte(message.Payload)); err != nil {
+					log.Context(ctx).Error(err)
+				}
+			}()
+		}
+	}()
+}
+
+func (h *pubSubHandler) InvalidateCacheHandler(ctx context.Context, channel string, msg []byte) error {
+	switch channel {
+	case h.cacheConfig.Channel.PairUpdate:
+		var payload model.PairUpdate
+		if err := json.Unmarshal(msg, &payload); err != nil {
+			return err
+		}
+
+		log.Context(ctx).ReqBody = payload
+		h.invalidator.InvalidatePair(ctx, payload)
+
+	case h.cacheConfig.Channel.CryptoUpdate:
+		var payload model.CryptoUpdate
+		if err := json.Unmarshal(msg, &payload); err != nil {
+			return err
+		}
+
+		log.Context(ctx).ReqBody = payload
+		h.invalidator.InvalidateCrypto(ctx, payload)
+	}
+
+	return nil
+}
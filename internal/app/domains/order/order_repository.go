@@ -6,6 +6,7 @@ import (
 	"go-skeleton-code/pkg/log"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"go-skeleton-code/internal/app/domains/order/model"
 	gormpkg "go-skeleton-code/pkg/gorm"
@@ -68,11 +69,92 @@ func (r *repository) SaveMatchOrder(ctx context.Context, matchOrder model.MatchO
 	return nil
 }
 
+func (r *repository) SaveOrderEvent(ctx context.Context, event model.OrderEvent) (model.OrderEvent, error) {
+	defer log.Context(ctx).RecordDuration("save order event to database").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	var err error
+	if event.OrderSequence, err = r.nextSequence(ctx, writeDB, model.SequenceScopeOrder, event.OrderID); err != nil {
+		return model.OrderEvent{}, err
+	}
+
+	if event.UserSequence, err = r.nextSequence(ctx, writeDB, model.SequenceScopeUser, event.UserID); err != nil {
+		return model.OrderEvent{}, err
+	}
+
+	if err = writeDB.WithContext(ctx).Create(&event).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.OrderEvent{}, err
+	}
+
+	return event, nil
+}
+
+// nextSequence increments the counter for given scope. The row lock held by the
+// upsert makes sure concurrent transactions never receive the same sequence.
+func (r *repository) nextSequence(ctx context.Context, db *gorm.DB, scope string, scopeID int) (int64, error) {
+	var sequence int64
+
+	rawQuery := `INSERT INTO order_event_sequences (scope, scope_id, value) VALUES (?, ?, 1)
+		ON CONFLICT (scope, scope_id) DO UPDATE SET value = order_event_sequences.value + 1
+		RETURNING value`
+	if err := db.WithContext(ctx).Raw(rawQuery, scope, scopeID).Scan(&sequence).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (r *repository) GetOrderEvents(ctx context.Context, orderID int) ([]model.OrderEvent, error) {
+	defer log.Context(ctx).RecordDuration("get order events").Stop()
+
+	events := make([]model.OrderEvent, 0)
+	if err := r.readDB.WithContext(ctx).Where("order_id = ?", orderID).Order("order_sequence ASC").Find(&events).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *repository) GetUserOrderEvents(ctx context.Context, userID int, fromSequence int64, limit int) ([]model.OrderEvent, error) {
+	defer log.Context(ctx).RecordDuration("get user order events").Stop()
+
+	events := make([]model.OrderEvent, 0)
+	if err := r.readDB.WithContext(ctx).
+		Where("user_id = ? AND user_sequence > ?", userID, fromSequence).
+		Order("user_sequence ASC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *repository) GetCryptoDetail(ctx context.Context, id int) (model.Crypto, error) {
+	defer log.Context(ctx).RecordDuration("get crypto detail").Stop()
+
+	var crypto model.Crypto
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").First(&crypto, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return model.Crypto{}, err
+	}
+
+	return crypto, nil
+}
+
 func (r *repository) GetPairDetail(ctx context.Context, code string) (model.Pair, error) {
 	defer log.Context(ctx).RecordDuration("get pair detail").Stop()
 
 	var pair model.Pair
-	if err := r.readDB.WithContext(ctx).Where("code = ?", code).First(&pair).Error; err != nil {
+	if err := r.readDB.WithContext(ctx).Where("code = ? AND deleted_at IS NULL", code).First(&pair).Error; err != nil {
 		log.Context(ctx).Error(err)
 		return model.Pair{}, err
 	}
@@ -84,7 +166,7 @@ func (r *repository) GetPairDetailByID(ctx context.Context, id int) (model.Pair,
 	defer log.Context(ctx).RecordDuration("get pair detail").Stop()
 
 	var pair model.Pair
-	if err := r.readDB.WithContext(ctx).First(&pair, id).Error; err != nil {
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").First(&pair, id).Error; err != nil {
 		log.Context(ctx).Error(err)
 		return model.Pair{}, err
 	}
@@ -92,6 +174,84 @@ func (r *repository) GetPairDetailByID(ctx context.Context, id int) (model.Pair,
 	return pair, nil
 }
 
+func (r *repository) UpdatePairStatus(ctx context.Context, id int, status model.PairStatus, reason string) (model.Pair, error) {
+	defer log.Context(ctx).RecordDuration("update pair status").Stop()
+
+	var pair model.Pair
+	result := r.writeDB.WithContext(ctx).
+		Model(&pair).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]any{"status": status, "halt_reason": reason})
+	if result.Error != nil {
+		log.Context(ctx).Error(result.Error)
+		return model.Pair{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return model.Pair{}, gorm.ErrRecordNotFound
+	}
+
+	return pair, nil
+}
+
+func (r *repository) SavePendingTrade(ctx context.Context, pendingTrade model.PendingTrade) error {
+	defer log.Context(ctx).RecordDuration("save pending trade to database").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&pendingTrade).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetPendingTrades read from write database, settled and failed flag must always be up to date
+func (r *repository) GetPendingTrades(ctx context.Context, pairID int, limit int) ([]model.PendingTrade, error) {
+	defer log.Context(ctx).RecordDuration("get pending trades").Stop()
+
+	pendingTrades := make([]model.PendingTrade, 0)
+	if err := r.writeDB.WithContext(ctx).
+		Where("pair_id = ? AND settled_at IS NULL AND failed_at IS NULL", pairID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&pendingTrades).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return pendingTrades, nil
+}
+
+func (r *repository) MarkPendingTradeFailed(ctx context.Context, id int, reason string) error {
+	defer log.Context(ctx).RecordDuration("mark pending trade failed").Stop()
+
+	rawQuery := `UPDATE pending_trades SET failed_at = now(), fail_reason = ? WHERE id = ?`
+	if err := r.writeDB.WithContext(ctx).Exec(rawQuery, reason, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) MarkPendingTradeSettled(ctx context.Context, id int) error {
+	defer log.Context(ctx).RecordDuration("mark pending trade settled").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	rawQuery := `UPDATE pending_trades SET settled_at = now() WHERE id = ?`
+	if err := writeDB.WithContext(ctx).Exec(rawQuery, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *repository) GetUserWallet(ctx context.Context, userID, cryptoID int) (model.Wallet, error) {
 	defer log.Context(ctx).RecordDuration("get user wallet").Stop()
 
@@ -2,46 +2,71 @@ package order
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/cast"
 	"gorm.io/gorm"
 
+	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/kyc"
 	"go-skeleton-code/internal/app/domains/order/model"
 	"go-skeleton-code/internal/app/domains/user"
 	serverError "go-skeleton-code/pkg/error"
 	gormpkg "go-skeleton-code/pkg/gorm"
 	"go-skeleton-code/pkg/jwt"
 	"go-skeleton-code/pkg/kafka"
+	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/redis"
+)
+
+const (
+	pendingTradeBatchSize = 100
 )
 
 type usecase struct {
 	writeDB         *gorm.DB
+	cacheConfig     config.Cache
 	kafkaProducer   kafka.Producer
+	publisher       redis.Publisher
+	circuitBreaker  model.CircuitBreaker
 	validator       *validator.Validate
 	orderRepository model.Repository
 	userRepository  user.Repository
+	kycUsecase      kyc.Usecase
 }
 
 // NewUsecase returns new order usecase.
 func NewUsecase(
 	writeDB *gorm.DB,
+	cacheConfig config.Cache,
 	kafkaProducer kafka.Producer,
+	publisher redis.Publisher,
+	circuitBreaker model.CircuitBreaker,
 	validator *validator.Validate,
 	orderRepository model.Repository,
 	userRepository user.Repository,
+	kycUsecase kyc.Usecase,
 ) *usecase {
 	return &usecase{
 		writeDB:         writeDB,
+		cacheConfig:     cacheConfig,
 		kafkaProducer:   kafkaProducer,
+		publisher:       publisher,
+		circuitBreaker:  circuitBreaker,
 		validator:       validator,
 		orderRepository: orderRepository,
 		userRepository:  userRepository,
+		kycUsecase:      kycUsecase,
 	}
 }
 
 func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest) (model.Order, error) {
+	if err := u.validator.StructCtx(ctx, orderReq); err != nil {
+		return model.Order{}, serverError.ErrInvalidRequest(err)
+	}
+
 	tokenPayload := jwt.GetPayloadFromContext(ctx)
 
 	// Check user detail
@@ -52,7 +77,11 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 
 	// Check account status
 	if !userDetail.Status {
-		return model.Order{}, serverError.ErrUserBlocked(nil) // User already deactivated
+		return model.Order{}, serverError.ErrUserBlocked(user.ErrUserInactive) // User already deactivated
+	}
+
+	if userDetail.EmailVerifiedAt == nil {
+		return model.Order{}, serverError.ErrEmailNotVerified(user.ErrEmailNotVerified)
 	}
 
 	// Check crypto pair detail
@@ -61,6 +90,22 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 		return model.Order{}, serverError.ErrGeneralDatabaseError(err)
 	}
 
+	// Check trading status
+	if cryptoPairDetail.IsHalted() {
+		err := serverError.ErrTradingHalted(fmt.Errorf("pair %v halted, %v", cryptoPairDetail.Code, cryptoPairDetail.HaltReason))
+		return model.Order{}, u.rejectOrder(ctx, userDetail.ID, cryptoPairDetail.ID, orderReq, err)
+	}
+
+	// Check trading rules of the pair
+	if err := cryptoPairDetail.ValidateOrder(orderReq); err != nil {
+		return model.Order{}, u.rejectOrder(ctx, userDetail.ID, cryptoPairDetail.ID, orderReq, serverError.ErrInvalidRequest(err))
+	}
+
+	// Check trading limit of the user KYC level, the order priced in secondary crypto
+	if err := u.kycUsecase.CheckOrderLimit(ctx, userDetail.KYCLevel, cryptoPairDetail.SecondaryCryptoID, orderReq.Price*orderReq.Quantity); err != nil {
+		return model.Order{}, u.rejectOrder(ctx, userDetail.ID, cryptoPairDetail.ID, orderReq, err)
+	}
+
 	targetCryptoID := cryptoPairDetail.PrimaryCryptoID
 	if orderReq.Side == model.OrderSideBuy {
 		// When buying, check if user have enough secondary balance for buying primary crypto
@@ -69,6 +114,13 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 
 	// TODO:Lock all balance activity for this specific user
 
+	ctx, tx, err := gormpkg.InitTransactionToContext(ctx, u.writeDB)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	defer tx.Rollback()
+
 	userWallet, err := u.orderRepository.GetUserWallet(ctx, userDetail.ID, targetCryptoID)
 	if err != nil {
 		return model.Order{}, serverError.ErrGeneralDatabaseError(err)
@@ -76,7 +128,8 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 
 	// Validate user balance
 	if !userWallet.IsEnoughBalance(orderReq) {
-		return model.Order{}, model.ErrInsufficientBalance
+		tx.Rollback()
+		return model.Order{}, u.rejectOrder(ctx, userDetail.ID, cryptoPairDetail.ID, orderReq, model.ErrInsufficientBalance)
 	}
 
 	// Deduct user wallet balance
@@ -113,6 +166,16 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 		return model.Order{}, err
 	}
 
+	// Record order lifecycle
+	createdEvent := model.NewOrderEvent(order, model.OrderEventCreated, order.Quantity, order.Price, order.TransactionTime)
+	if _, err := u.orderRepository.SaveOrderEvent(ctx, createdEvent); err != nil {
+		return model.Order{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return model.Order{}, err
+	}
+
 	// Publish to matching engine
 	if err := u.kafkaProducer.Send(ctx, cryptoPairDetail.Code, cast.ToString(order.ID), order); err != nil {
 		return model.Order{}, err
@@ -121,6 +184,48 @@ func (u *usecase) ProcessOrder(ctx context.Context, orderReq model.OrderRequest)
 	return order, nil
 }
 
+// rejectOrder saves the refused order with failed status together with its rejected event, then returns the
+// cause. Failure saving the rejection only logged, the client still receives the cause.
+func (u *usecase) rejectOrder(ctx context.Context, userID, pairID int, orderReq model.OrderRequest, cause error) error {
+	// New transaction, replacing the one already in the context
+	ctx, tx, err := gormpkg.InitTransactionToContext(ctx, u.writeDB)
+	if err != nil {
+		log.Context(ctx).Errorf("failed recording rejected order, %v", err)
+		return cause
+	}
+
+	defer tx.Rollback()
+
+	rejectedOrder := model.Order{
+		UserID:          userID,
+		PairID:          pairID,
+		Quantity:        orderReq.Quantity,
+		Price:           orderReq.Price,
+		Type:            orderReq.Type,
+		Side:            orderReq.Side,
+		Status:          model.OrderStatusFailed,
+		TransactionTime: time.Now().Unix(),
+	}
+
+	rejectedOrder, err = u.orderRepository.SaveOrder(ctx, rejectedOrder)
+	if err != nil {
+		log.Context(ctx).Errorf("failed recording rejected order, %v", err)
+		return cause
+	}
+
+	rejectedEvent := model.NewOrderEvent(rejectedOrder, model.OrderEventRejected, rejectedOrder.Quantity, rejectedOrder.Price, rejectedOrder.TransactionTime)
+	if _, err := u.orderRepository.SaveOrderEvent(ctx, rejectedEvent); err != nil {
+		log.Context(ctx).Errorf("failed recording rejected order, %v", err)
+		return cause
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Context(ctx).Errorf("failed recording rejected order, %v", err)
+	}
+
+	return cause
+}
+
 func (u *usecase) MatchOrder(ctx context.Context, tradeReq model.TradeRequest) error {
 	// Check crypto pair detail
 	cryptoPairDetail, err := u.orderRepository.GetPairDetailByID(ctx, tradeReq.PairID)
@@ -128,6 +233,32 @@ func (u *usecase) MatchOrder(ctx context.Context, tradeReq model.TradeRequest) e
 		return err
 	}
 
+	// Trading halted, keep the trade until trading resumed
+	if cryptoPairDetail.IsHalted() {
+		log.Context(ctx).Warnf("pair %v halted, trade queued", cryptoPairDetail.Code)
+		return u.orderRepository.SavePendingTrade(ctx, model.NewPendingTrade(tradeReq))
+	}
+
+	if err := u.settleTrade(ctx, cryptoPairDetail, tradeReq, nil); err != nil {
+		return err
+	}
+
+	// Halt the pair when price moving too fast
+	reason, tripped, err := u.circuitBreaker.Check(ctx, cryptoPairDetail.ID, tradeReq.Price)
+	if err != nil || !tripped {
+		return nil // Trade already settled, circuit breaker failure must not fail the trade
+	}
+
+	if _, err := u.HaltPair(ctx, cryptoPairDetail.ID, reason); err != nil {
+		log.Context(ctx).Errorf("failed halting pair %v, %v", cryptoPairDetail.Code, err)
+	}
+
+	return nil
+}
+
+// settleTrade update both orders and wallets for a single trade. When the trade
+// coming from pending trade, it will be marked as settled in the same transaction.
+func (u *usecase) settleTrade(ctx context.Context, cryptoPairDetail model.Pair, tradeReq model.TradeRequest, pendingTrade *model.PendingTrade) error {
 	// Get order detail from maker and taker
 	takerOrder, err := u.orderRepository.GetOrder(ctx, tradeReq.TakerOrderID)
 	if err != nil {
@@ -194,6 +325,14 @@ func (u *usecase) MatchOrder(ctx context.Context, tradeReq model.TradeRequest) e
 		return err
 	}
 
+	// Record order lifecycle
+	for _, order := range []model.Order{takerOrder, makerOrder} {
+		fillEvent := model.NewOrderEvent(order, order.FillEventType(), tradeReq.Quantity, tradeReq.Price, tradeReq.TradeTime)
+		if _, err := u.orderRepository.SaveOrderEvent(ctx, fillEvent); err != nil {
+			return err
+		}
+	}
+
 	// Save to table match order
 	matchOrder := model.MatchOrder{
 		PairID:          tradeReq.PairID,
@@ -208,5 +347,126 @@ func (u *usecase) MatchOrder(ctx context.Context, tradeReq model.TradeRequest) e
 		return err
 	}
 
+	if pendingTrade != nil {
+		if err = u.orderRepository.MarkPendingTradeSettled(ctx, pendingTrade.ID); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit().Error
 }
+
+func (u *usecase) HaltPair(ctx context.Context, pairID int, reason string) (model.Pair, error) {
+	pair, err := u.orderRepository.UpdatePairStatus(ctx, pairID, model.PairStatusHalted, reason)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	log.Context(ctx).Warnf("pair %v halted, %v", pair.Code, reason)
+	u.publishPairUpdate(ctx, pair)
+
+	return pair, nil
+}
+
+func (u *usecase) ResumePair(ctx context.Context, pairID int) (model.Pair, error) {
+	pair, err := u.orderRepository.GetPairDetailByID(ctx, pairID)
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	// Settle queued trades in order before accepting new trades
+	if err := u.settlePendingTrades(ctx, pair); err != nil {
+		return model.Pair{}, err
+	}
+
+	pair, err = u.orderRepository.UpdatePairStatus(ctx, pairID, model.PairStatusTrading, "")
+	if err != nil {
+		return model.Pair{}, err
+	}
+
+	u.publishPairUpdate(ctx, pair)
+
+	// Trades received by other instance before the status change reached them
+	if err := u.settlePendingTrades(ctx, pair); err != nil {
+		return model.Pair{}, err
+	}
+
+	if err := u.circuitBreaker.Reset(ctx, pair.ID); err != nil {
+		log.Context(ctx).Errorf("failed reset circuit breaker, %v", err)
+	}
+
+	return pair, nil
+}
+
+func (u *usecase) settlePendingTrades(ctx context.Context, pair model.Pair) error {
+	for {
+		pendingTrades, err := u.orderRepository.GetPendingTrades(ctx, pair.ID, pendingTradeBatchSize)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+
+		if len(pendingTrades) == 0 {
+			return nil
+		}
+
+		for i := range pendingTrades {
+			err := u.settleTrade(ctx, pair, pendingTrades[i].TradeRequest(), &pendingTrades[i])
+			if err == nil {
+				continue
+			}
+
+			// Failed trade skipped so the rest still settled, left for manual review
+			log.Context(ctx).Errorf("failed settling pending trade %v, %v", pendingTrades[i].ID, err)
+			if err := u.orderRepository.MarkPendingTradeFailed(ctx, pendingTrades[i].ID, err.Error()); err != nil {
+				return serverError.ErrGeneralDatabaseError(err)
+			}
+		}
+	}
+}
+
+func (u *usecase) publishPairUpdate(ctx context.Context, pair model.Pair) {
+	pairUpdate := model.PairUpdate{PairID: pair.ID, Code: pair.Code}
+	if err := u.publisher.Publish(ctx, u.cacheConfig.Channel.PairUpdate, pairUpdate); err != nil {
+		// Not returning error, the change already saved and cache will expire by itself
+		log.Context(ctx).Errorf("failed publishing pair update, %v", err)
+	}
+}
+
+func (u *usecase) GetOrderEvents(ctx context.Context, orderID int) ([]model.OrderEvent, error) {
+	tokenPayload := jwt.GetPayloadFromContext(ctx)
+
+	order, err := u.orderRepository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the owner allowed to see the order history
+	if order.UserID != tokenPayload.UserID {
+		return nil, serverError.ErrDataNotFound(nil)
+	}
+
+	events, err := u.orderRepository.GetOrderEvents(ctx, order.ID)
+	if err != nil {
+		return nil, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return events, nil
+}
+
+func (u *usecase) GetUserOrderEvents(ctx context.Context, eventReq model.OrderEventRequest) ([]model.OrderEvent, error) {
+	tokenPayload := jwt.GetPayloadFromContext(ctx)
+
+	switch {
+	case eventReq.Limit > 1000:
+		eventReq.Limit = 1000
+	case eventReq.Limit <= 0:
+		eventReq.Limit = 100
+	}
+
+	events, err := u.orderRepository.GetUserOrderEvents(ctx, tokenPayload.UserID, eventReq.FromSequence, eventReq.Limit)
+	if err != nil {
+		return nil, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return events, nil
+}
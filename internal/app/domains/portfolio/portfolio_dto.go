@@ -0,0 +1,5 @@
+package portfolio
+
+type PortfolioRequest struct {
+	Quote string `form:"quote"` // Quote currency symbol, e.g. USDT
+}
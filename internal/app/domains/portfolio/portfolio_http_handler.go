@@ -0,0 +1,60 @@
+package portfolio
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-skeleton-code/internal/app/domains/apikey"
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	"go-skeleton-code/pkg/jwt"
+	response "go-skeleton-code/pkg/response/gin"
+)
+
+type httpHandler struct {
+	timeout          time.Duration
+	portfolioUsecase Usecase
+	jwtManager       jwt.Manager
+	revocationList   jwt.RevocationList
+	authenticator    middleware.APIKeyAuthenticator
+}
+
+func NewHTTPHandler(portfolioUsecase Usecase, timeout time.Duration, jwtManager jwt.Manager, revocationList jwt.RevocationList, authenticator middleware.APIKeyAuthenticator) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
+	return &httpHandler{
+		timeout:          timeout,
+		portfolioUsecase: portfolioUsecase,
+		jwtManager:       jwtManager,
+		revocationList:   revocationList,
+		authenticator:    authenticator,
+	}
+}
+
+func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
+	v1 := g.Group("/v1/portfolio")
+	v1.Use(middleware.ValidateJwtTokenOrAPIKey(h.jwtManager, h.revocationList, h.authenticator))
+	{
+		v1.GET("", middleware.RequireScope(apikey.ScopeRead), h.PortfolioHandler)
+	}
+}
+
+func (h *httpHandler) PortfolioHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload PortfolioRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	portfolio, err := h.portfolioUsecase.GetPortfolio(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, portfolio)
+}
@@ -0,0 +1,62 @@
+package portfolio
+
+import (
+	"context"
+
+	"go-skeleton-code/internal/app/domains/order/model"
+)
+
+type Portfolio struct {
+	Quote         string  `json:"quote"`
+	TotalValue    float64 `json:"total_value"`
+	RealizedPnL   float64 `json:"realized_pnl"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+	Assets        []Asset `json:"assets"`
+}
+
+type Asset struct {
+	CryptoID       int     `json:"crypto_id"`
+	Symbol         string  `json:"symbol"`
+	Quantity       float64 `json:"quantity"`        // Wallet balance
+	Price          float64 `json:"price"`           // Latest price in quote currency
+	PriceAvailable bool    `json:"price_available"` // False when there is no market to convert the asset
+	Value          float64 `json:"value"`
+	AverageCost    float64 `json:"average_cost"` // Average buying price in quote currency
+	RealizedPnL    float64 `json:"realized_pnl"`
+	UnrealizedPnL  float64 `json:"unrealized_pnl"`
+}
+
+// Fill is a trade from the point of view of the user order.
+type Fill struct {
+	MatchOrderID    int        `gorm:"column:id"`
+	PairID          int        `gorm:"column:pair_id"`
+	Side            model.Side `gorm:"column:side"`
+	Quantity        float64    `gorm:"column:quantity"`
+	Price           float64    `gorm:"column:price"`
+	TransactionTime int64      `gorm:"column:transaction_time"`
+}
+
+// LastPrice is the most recent trade price of a pair.
+type LastPrice struct {
+	PairID int     `gorm:"column:pair_id"`
+	Price  float64 `gorm:"column:price"`
+}
+
+// FillPrice is the most recent trade price of a pair at the time of a user fill.
+type FillPrice struct {
+	MatchOrderID int `gorm:"column:match_order_id"`
+	LastPrice
+}
+
+type Usecase interface {
+	GetPortfolio(ctx context.Context, portfolioReq PortfolioRequest) (Portfolio, error)
+}
+
+type Repository interface {
+	GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error)
+	GetUserFills(ctx context.Context, userID int) ([]Fill, error)
+	GetCryptos(ctx context.Context) ([]model.Crypto, error)
+	GetPairs(ctx context.Context) ([]model.Pair, error)
+	GetLastPrices(ctx context.Context) ([]LastPrice, error)
+	GetFillPrices(ctx context.Context, userID, quoteID int) ([]FillPrice, error)
+}
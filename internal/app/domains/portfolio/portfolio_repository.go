@@ -0,0 +1,149 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+
+	"go-skeleton-code/internal/app/domains/order/model"
+	"go-skeleton-code/pkg/log"
+)
+
+type repository struct {
+	readDB *gorm.DB
+}
+
+// NewRepository returns new portfolio Repository.
+func NewRepository(readDB *gorm.DB) *repository {
+	return &repository{
+		readDB: readDB,
+	}
+}
+
+func (r *repository) GetUserWallets(ctx context.Context, userID int) ([]model.Wallet, error) {
+	defer log.Context(ctx).RecordDuration("get user wallets").Stop()
+
+	wallets := make([]model.Wallet, 0)
+	if err := r.readDB.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&wallets).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+// GetUserFills returns every trade of the user orders, ordered from the oldest trade. Taker and maker side
+// queried separately so both use the index of the order column.
+func (r *repository) GetUserFills(ctx context.Context, userID int) ([]Fill, error) {
+	defer log.Context(ctx).RecordDuration("get user fills").Stop()
+
+	rawQuery := `SELECT m.id, m.pair_id, o.side, m.quantity, m.price, m.transaction_time
+		FROM orders o
+		JOIN match_orders m ON m.taker_order_id = o.id
+		WHERE o.user_id = ? AND m.deleted_at IS NULL
+		UNION ALL
+		SELECT m.id, m.pair_id, o.side, m.quantity, m.price, m.transaction_time
+		FROM orders o
+		JOIN match_orders m ON m.maker_order_id = o.id
+		WHERE o.user_id = ? AND m.deleted_at IS NULL
+		ORDER BY id ASC`
+
+	fills := make([]Fill, 0)
+	if err := r.readDB.WithContext(ctx).Raw(rawQuery, userID, userID).Scan(&fills).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return fills, nil
+}
+
+func (r *repository) GetCryptos(ctx context.Context) ([]model.Crypto, error) {
+	defer log.Context(ctx).RecordDuration("get cryptos").Stop()
+
+	cryptos := make([]model.Crypto, 0)
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").Find(&cryptos).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return cryptos, nil
+}
+
+func (r *repository) GetPairs(ctx context.Context) ([]model.Pair, error) {
+	defer log.Context(ctx).RecordDuration("get pairs").Stop()
+
+	pairs := make([]model.Pair, 0)
+	if err := r.readDB.WithContext(ctx).Where("deleted_at IS NULL").Find(&pairs).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func (r *repository) GetLastPrices(ctx context.Context) ([]LastPrice, error) {
+	defer log.Context(ctx).RecordDuration("get last prices").Stop()
+
+	rawQuery := `SELECT p.id AS pair_id, lp.price
+		FROM pairs p
+		CROSS JOIN LATERAL (
+			SELECT price
+			FROM match_orders
+			WHERE pair_id = p.id AND deleted_at IS NULL
+			ORDER BY id DESC
+			LIMIT 1
+		) lp
+		WHERE p.deleted_at IS NULL`
+
+	lastPrices := make([]LastPrice, 0)
+	if err := r.readDB.WithContext(ctx).Raw(rawQuery).Scan(&lastPrices).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return lastPrices, nil
+}
+
+// GetFillPrices returns the price at the time of every trade of the user orders, used for converting the trade
+// into quote currency with the rate when the trade happened. Only pairs of the trade secondary crypto or the quote
+// crypto returned, enough for direct conversion or through one intermediate crypto, each price found using the
+// pair and id index.
+func (r *repository) GetFillPrices(ctx context.Context, userID, quoteID int) ([]FillPrice, error) {
+	defer log.Context(ctx).RecordDuration("get fill prices").Stop()
+
+	rawQuery := `WITH fills AS (
+			SELECT m.id, pr.secondary_crypto_id
+			FROM orders o
+			JOIN match_orders m ON m.taker_order_id = o.id
+			JOIN pairs pr ON pr.id = m.pair_id
+			WHERE o.user_id = @user AND m.deleted_at IS NULL
+			UNION
+			SELECT m.id, pr.secondary_crypto_id
+			FROM orders o
+			JOIN match_orders m ON m.maker_order_id = o.id
+			JOIN pairs pr ON pr.id = m.pair_id
+			WHERE o.user_id = @user AND m.deleted_at IS NULL
+		)
+		SELECT f.id AS match_order_id, p.id AS pair_id, lp.price
+		FROM fills f
+		JOIN pairs p ON p.deleted_at IS NULL
+			AND (p.primary_crypto_id IN (f.secondary_crypto_id, @quote) OR p.secondary_crypto_id IN (f.secondary_crypto_id, @quote))
+		CROSS JOIN LATERAL (
+			SELECT price
+			FROM match_orders
+			WHERE pair_id = p.id AND id <= f.id AND deleted_at IS NULL
+			ORDER BY id DESC
+			LIMIT 1
+		) lp
+		WHERE f.secondary_crypto_id <> @quote`
+
+	fillPrices := make([]FillPrice, 0)
+	err := r.readDB.WithContext(ctx).Raw(rawQuery, sql.Named("user", userID), sql.Named("quote", quoteID)).Scan(&fillPrices).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return fillPrices, nil
+}
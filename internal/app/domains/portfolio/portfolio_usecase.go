@@ -0,0 +1,276 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"go-skeleton-code/internal/app/domains/order/model"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+)
+
+const (
+	defaultQuote = "USDT"
+)
+
+type usecase struct {
+	portfolioRepository Repository
+}
+
+// NewUsecase returns new portfolio usecase.
+func NewUsecase(portfolioRepository Repository) *usecase {
+	return &usecase{
+		portfolioRepository: portfolioRepository,
+	}
+}
+
+// GetPortfolio value every user wallet in the quote currency. Profit and loss calculated with
+// average cost method on both assets of every trade, valued in quote currency with the market price
+// when the trade happened. Unrealized profit and loss uses the latest market price.
+func (u *usecase) GetPortfolio(ctx context.Context, portfolioReq PortfolioRequest) (Portfolio, error) {
+	tokenPayload := jwt.GetPayloadFromContext(ctx)
+
+	if portfolioReq.Quote == "" {
+		portfolioReq.Quote = defaultQuote
+	}
+
+	cryptos, err := u.portfolioRepository.GetCryptos(ctx)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	quote, found := findCryptoBySymbol(cryptos, portfolioReq.Quote)
+	if !found {
+		return Portfolio{}, serverError.ErrDataNotFound(fmt.Errorf("quote currency %v not found", portfolioReq.Quote))
+	}
+
+	pairs, err := u.portfolioRepository.GetPairs(ctx)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	lastPrices, err := u.portfolioRepository.GetLastPrices(ctx)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	wallets, err := u.portfolioRepository.GetUserWallets(ctx, tokenPayload.UserID)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	fills, err := u.portfolioRepository.GetUserFills(ctx, tokenPayload.UserID)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	fillPrices, err := u.portfolioRepository.GetFillPrices(ctx, tokenPayload.UserID, quote.ID)
+	if err != nil {
+		return Portfolio{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	var (
+		currentMarket = newMarket(pairs, lastPrices)
+		positions     = calculatePositions(fills, pairs, newFillMarkets(pairs, fillPrices), quote.ID)
+		symbols       = make(map[int]string, len(cryptos))
+		portfolio     = Portfolio{Quote: quote.Symbol, Assets: make([]Asset, 0, len(wallets))}
+	)
+
+	for _, crypto := range cryptos {
+		symbols[crypto.ID] = crypto.Symbol
+	}
+
+	for _, wallet := range wallets {
+		position := positions[wallet.CryptoID]
+		asset := Asset{
+			CryptoID:    wallet.CryptoID,
+			Symbol:      symbols[wallet.CryptoID],
+			Quantity:    wallet.Quantity,
+			AverageCost: position.averageCost(),
+			RealizedPnL: position.realized,
+		}
+
+		asset.Price, asset.PriceAvailable = currentMarket.price(wallet.CryptoID, quote.ID)
+		if asset.PriceAvailable {
+			asset.Value = wallet.Quantity * asset.Price
+			asset.UnrealizedPnL = (asset.Price - asset.AverageCost) * math.Min(position.quantity, wallet.Quantity)
+		}
+
+		portfolio.TotalValue += asset.Value
+		portfolio.RealizedPnL += asset.RealizedPnL
+		portfolio.UnrealizedPnL += asset.UnrealizedPnL
+		portfolio.Assets = append(portfolio.Assets, asset)
+	}
+
+	return portfolio, nil
+}
+
+func findCryptoBySymbol(cryptos []model.Crypto, symbol string) (model.Crypto, bool) {
+	for _, crypto := range cryptos {
+		if strings.EqualFold(crypto.Symbol, symbol) {
+			return crypto, true
+		}
+	}
+
+	return model.Crypto{}, false
+}
+
+// position is the amount of an asset received through trades with its total cost in quote currency
+type position struct {
+	quantity float64
+	cost     float64
+	realized float64
+}
+
+func (p position) averageCost() float64 {
+	if p.quantity <= 0 {
+		return 0
+	}
+	return p.cost / p.quantity
+}
+
+func (p *position) acquire(quantity, cost float64) {
+	p.quantity += quantity
+	p.cost += cost
+}
+
+// dispose realizes the profit of the traded amount, asset received outside trading has no cost.
+func (p *position) dispose(quantity, proceeds float64) {
+	if quantity <= 0 {
+		return
+	}
+
+	averageCost := p.averageCost()
+	soldQuantity := math.Min(quantity, p.quantity)
+	p.realized += (proceeds/quantity - averageCost) * soldQuantity
+	p.cost -= averageCost * soldQuantity
+	p.quantity -= soldQuantity
+}
+
+// calculatePositions applies every fill to the base and the counter asset of the pair. Trade valued in
+// quote currency using the market when the fill happened, quote currency itself has no position.
+func calculatePositions(fills []Fill, pairs []model.Pair, fillMarkets map[int]market, quoteID int) map[int]position {
+	var (
+		positions = make(map[int]position)
+		pairByID  = make(map[int]model.Pair, len(pairs))
+	)
+
+	for _, pair := range pairs {
+		pairByID[pair.ID] = pair
+	}
+
+	for _, fill := range fills {
+		pair, found := pairByID[fill.PairID]
+		if !found {
+			continue
+		}
+
+		// Trade price is in secondary crypto, convert it to quote currency
+		conversionRate, found := fillMarkets[fill.MatchOrderID].price(pair.SecondaryCryptoID, quoteID)
+		if !found {
+			continue
+		}
+
+		var (
+			counterQuantity = fill.Quantity * fill.Price
+			tradeValue      = counterQuantity * conversionRate
+			base            = positions[pair.PrimaryCryptoID]
+			counter         = positions[pair.SecondaryCryptoID]
+		)
+
+		switch fill.Side {
+		case model.OrderSideBuy:
+			base.acquire(fill.Quantity, tradeValue)
+			counter.dispose(counterQuantity, tradeValue)
+
+		case model.OrderSideSell:
+			base.dispose(fill.Quantity, tradeValue)
+			counter.acquire(counterQuantity, tradeValue)
+		}
+
+		positions[pair.PrimaryCryptoID] = base
+		positions[pair.SecondaryCryptoID] = counter
+	}
+
+	delete(positions, quoteID)
+	return positions
+}
+
+// newFillMarkets returns the market at the time of every fill, keyed by the match order ID.
+func newFillMarkets(pairs []model.Pair, fillPrices []FillPrice) map[int]market {
+	pricesByFill := make(map[int][]LastPrice)
+	for _, fillPrice := range fillPrices {
+		pricesByFill[fillPrice.MatchOrderID] = append(pricesByFill[fillPrice.MatchOrderID], fillPrice.LastPrice)
+	}
+
+	fillMarkets := make(map[int]market, len(pricesByFill))
+	for matchOrderID, prices := range pricesByFill {
+		fillMarkets[matchOrderID] = newMarket(pairs, prices)
+	}
+
+	return fillMarkets
+}
+
+type rate struct {
+	to    int
+	price float64
+}
+
+// market convert one crypto to another crypto using the pair price at a point in time,
+// directly or through one intermediate crypto.
+type market struct {
+	rates map[int][]rate
+}
+
+func newMarket(pairs []model.Pair, lastPrices []LastPrice) market {
+	var (
+		prices        = make(map[int]float64, len(lastPrices))
+		currentMarket = market{rates: make(map[int][]rate)}
+	)
+
+	for _, lastPrice := range lastPrices {
+		prices[lastPrice.PairID] = lastPrice.Price
+	}
+
+	for _, pair := range pairs {
+		price := prices[pair.ID]
+		if price <= 0 {
+			continue
+		}
+
+		currentMarket.rates[pair.PrimaryCryptoID] = append(currentMarket.rates[pair.PrimaryCryptoID], rate{to: pair.SecondaryCryptoID, price: price})
+		currentMarket.rates[pair.SecondaryCryptoID] = append(currentMarket.rates[pair.SecondaryCryptoID], rate{to: pair.PrimaryCryptoID, price: 1 / price})
+	}
+
+	return currentMarket
+}
+
+func (m market) price(from, to int) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	if price, found := m.directPrice(from, to); found {
+		return price, true
+	}
+
+	for _, intermediate := range m.rates[from] {
+		if price, found := m.directPrice(intermediate.to, to); found {
+			return intermediate.price * price, true
+		}
+	}
+
+	return 0, false
+}
+
+func (m market) directPrice(from, to int) (float64, bool) {
+	for _, r := range m.rates[from] {
+		if r.to == to {
+			return r.price, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,78 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"go-skeleton-code/internal/app/domains/user"
+	"sync"
+)
+
+type FakeAuthEventWriter struct {
+	WriteStub        func(context.Context, user.AuthEvent)
+	writeMutex       sync.RWMutex
+	writeArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.AuthEvent
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthEventWriter) Write(arg1 context.Context, arg2 user.AuthEvent) {
+	fake.writeMutex.Lock()
+	fake.writeArgsForCall = append(fake.writeArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.AuthEvent
+	}{arg1, arg2})
+	stub := fake.WriteStub
+	fake.recordInvocation("Write", []interface{}{arg1, arg2})
+	fake.writeMutex.Unlock()
+	if stub != nil {
+		fake.WriteStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeAuthEventWriter) WriteCallCount() int {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	return len(fake.writeArgsForCall)
+}
+
+func (fake *FakeAuthEventWriter) WriteCalls(stub func(context.Context, user.AuthEvent)) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = stub
+}
+
+func (fake *FakeAuthEventWriter) WriteArgsForCall(i int) (context.Context, user.AuthEvent) {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	argsForCall := fake.writeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthEventWriter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuthEventWriter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ user.AuthEventWriter = new(FakeAuthEventWriter)
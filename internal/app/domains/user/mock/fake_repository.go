@@ -5,52 +5,3617 @@ import (
 	"context"
 	"go-skeleton-code/internal/app/domains/user"
 	"sync"
+	"time"
 )
 
 type FakeRepository struct {
+	AcquireRateLimitStub        func(context.Context, string, string, time.Duration) (bool, error)
+	acquireRateLimitMutex       sync.RWMutex
+	acquireRateLimitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	acquireRateLimitReturns struct {
+		result1 bool
+		result2 error
+	}
+	acquireRateLimitReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ClearRateLimitStub        func(context.Context, string, string) error
+	clearRateLimitMutex       sync.RWMutex
+	clearRateLimitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	clearRateLimitReturns struct {
+		result1 error
+	}
+	clearRateLimitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClearRateLimitsStub        func(context.Context, string, string) error
+	clearRateLimitsMutex       sync.RWMutex
+	clearRateLimitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	clearRateLimitsReturns struct {
+		result1 error
+	}
+	clearRateLimitsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeactivateUserStub        func(context.Context, int) error
+	deactivateUserMutex       sync.RWMutex
+	deactivateUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	deactivateUserReturns struct {
+		result1 error
+	}
+	deactivateUserReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeletePhoneOTPStub        func(context.Context, user.TokenPurpose, string) (bool, error)
+	deletePhoneOTPMutex       sync.RWMutex
+	deletePhoneOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}
+	deletePhoneOTPReturns struct {
+		result1 bool
+		result2 error
+	}
+	deletePhoneOTPReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	FindIdentityStub        func(context.Context, string, string) (user.UserIdentity, error)
+	findIdentityMutex       sync.RWMutex
+	findIdentityArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	findIdentityReturns struct {
+		result1 user.UserIdentity
+		result2 error
+	}
+	findIdentityReturnsOnCall map[int]struct {
+		result1 user.UserIdentity
+		result2 error
+	}
 	FindUserByEmailStub        func(context.Context, string) (user.User, error)
 	findUserByEmailMutex       sync.RWMutex
 	findUserByEmailArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 	}
-	findUserByEmailReturns struct {
-		result1 user.User
-		result2 error
+	findUserByEmailReturns struct {
+		result1 user.User
+		result2 error
+	}
+	findUserByEmailReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	FindUserByIDStub        func(context.Context, int) (user.User, error)
+	findUserByIDMutex       sync.RWMutex
+	findUserByIDArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	findUserByIDReturns struct {
+		result1 user.User
+		result2 error
+	}
+	findUserByIDReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	FindUserByVerifiedPhoneStub        func(context.Context, string) (user.User, error)
+	findUserByVerifiedPhoneMutex       sync.RWMutex
+	findUserByVerifiedPhoneArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	findUserByVerifiedPhoneReturns struct {
+		result1 user.User
+		result2 error
+	}
+	findUserByVerifiedPhoneReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	GetActiveSessionsStub        func(context.Context, int, time.Time) ([]user.Session, error)
+	getActiveSessionsMutex       sync.RWMutex
+	getActiveSessionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 time.Time
+	}
+	getActiveSessionsReturns struct {
+		result1 []user.Session
+		result2 error
+	}
+	getActiveSessionsReturnsOnCall map[int]struct {
+		result1 []user.Session
+		result2 error
+	}
+	GetAuthEventsStub        func(context.Context, int, int, int) ([]user.AuthEvent, int, error)
+	getAuthEventsMutex       sync.RWMutex
+	getAuthEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+		arg4 int
+	}
+	getAuthEventsReturns struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}
+	getAuthEventsReturnsOnCall map[int]struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}
+	GetPhoneOTPStub        func(context.Context, user.TokenPurpose, string) (user.PhoneOTP, error)
+	getPhoneOTPMutex       sync.RWMutex
+	getPhoneOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}
+	getPhoneOTPReturns struct {
+		result1 user.PhoneOTP
+		result2 error
+	}
+	getPhoneOTPReturnsOnCall map[int]struct {
+		result1 user.PhoneOTP
+		result2 error
+	}
+	GetRateLimitStub        func(context.Context, string, string) (time.Duration, error)
+	getRateLimitMutex       sync.RWMutex
+	getRateLimitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getRateLimitReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	getRateLimitReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
+	GetRefreshTokenStub        func(context.Context, string) (user.RefreshToken, error)
+	getRefreshTokenMutex       sync.RWMutex
+	getRefreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getRefreshTokenReturns struct {
+		result1 user.RefreshToken
+		result2 error
+	}
+	getRefreshTokenReturnsOnCall map[int]struct {
+		result1 user.RefreshToken
+		result2 error
+	}
+	GetSessionStub        func(context.Context, int, int) (user.Session, error)
+	getSessionMutex       sync.RWMutex
+	getSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	getSessionReturns struct {
+		result1 user.Session
+		result2 error
+	}
+	getSessionReturnsOnCall map[int]struct {
+		result1 user.Session
+		result2 error
+	}
+	GetSingleUseTokenStub        func(context.Context, user.TokenPurpose, string) (int, error)
+	getSingleUseTokenMutex       sync.RWMutex
+	getSingleUseTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}
+	getSingleUseTokenReturns struct {
+		result1 int
+		result2 error
+	}
+	getSingleUseTokenReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	IncrementCounterStub        func(context.Context, string, string, time.Duration) (int64, error)
+	incrementCounterMutex       sync.RWMutex
+	incrementCounterArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	incrementCounterReturns struct {
+		result1 int64
+		result2 error
+	}
+	incrementCounterReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	IsTokenFamilyActiveStub        func(context.Context, string) (bool, error)
+	isTokenFamilyActiveMutex       sync.RWMutex
+	isTokenFamilyActiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	isTokenFamilyActiveReturns struct {
+		result1 bool
+		result2 error
+	}
+	isTokenFamilyActiveReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RegisterIdentityUserStub        func(context.Context, user.User, user.UserIdentity) (user.User, error)
+	registerIdentityUserMutex       sync.RWMutex
+	registerIdentityUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.User
+		arg3 user.UserIdentity
+	}
+	registerIdentityUserReturns struct {
+		result1 user.User
+		result2 error
+	}
+	registerIdentityUserReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	RegisterNewUserStub        func(context.Context, user.User) (user.User, error)
+	registerNewUserMutex       sync.RWMutex
+	registerNewUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.User
+	}
+	registerNewUserReturns struct {
+		result1 user.User
+		result2 error
+	}
+	registerNewUserReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	RehashPasswordStub        func(context.Context, int, string, string) error
+	rehashPasswordMutex       sync.RWMutex
+	rehashPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 string
+	}
+	rehashPasswordReturns struct {
+		result1 error
+	}
+	rehashPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplaceRecoveryCodesStub        func(context.Context, int, []user.RecoveryCode) error
+	replaceRecoveryCodesMutex       sync.RWMutex
+	replaceRecoveryCodesArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 []user.RecoveryCode
+	}
+	replaceRecoveryCodesReturns struct {
+		result1 error
+	}
+	replaceRecoveryCodesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResetCounterStub        func(context.Context, string, string) error
+	resetCounterMutex       sync.RWMutex
+	resetCounterArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	resetCounterReturns struct {
+		result1 error
+	}
+	resetCounterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResetCountersStub        func(context.Context, string, string) error
+	resetCountersMutex       sync.RWMutex
+	resetCountersArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	resetCountersReturns struct {
+		result1 error
+	}
+	resetCountersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeAPIKeysStub        func(context.Context, int) error
+	revokeAPIKeysMutex       sync.RWMutex
+	revokeAPIKeysArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	revokeAPIKeysReturns struct {
+		result1 error
+	}
+	revokeAPIKeysReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeSessionStub        func(context.Context, string) error
+	revokeSessionMutex       sync.RWMutex
+	revokeSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	revokeSessionReturns struct {
+		result1 error
+	}
+	revokeSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeSingleUseTokensStub        func(context.Context, user.TokenPurpose, int) error
+	revokeSingleUseTokensMutex       sync.RWMutex
+	revokeSingleUseTokensArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 int
+	}
+	revokeSingleUseTokensReturns struct {
+		result1 error
+	}
+	revokeSingleUseTokensReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeTokenFamilyStub        func(context.Context, string) error
+	revokeTokenFamilyMutex       sync.RWMutex
+	revokeTokenFamilyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	revokeTokenFamilyReturns struct {
+		result1 error
+	}
+	revokeTokenFamilyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveAuthEventStub        func(context.Context, user.AuthEvent) error
+	saveAuthEventMutex       sync.RWMutex
+	saveAuthEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.AuthEvent
+	}
+	saveAuthEventReturns struct {
+		result1 error
+	}
+	saveAuthEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveEmailChangeStub        func(context.Context, string, user.EmailChange, time.Duration) error
+	saveEmailChangeMutex       sync.RWMutex
+	saveEmailChangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.EmailChange
+		arg4 time.Duration
+	}
+	saveEmailChangeReturns struct {
+		result1 error
+	}
+	saveEmailChangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveIdentityStub        func(context.Context, user.UserIdentity) error
+	saveIdentityMutex       sync.RWMutex
+	saveIdentityArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.UserIdentity
+	}
+	saveIdentityReturns struct {
+		result1 error
+	}
+	saveIdentityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveOIDCStateStub        func(context.Context, string, user.OIDCState, time.Duration) error
+	saveOIDCStateMutex       sync.RWMutex
+	saveOIDCStateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.OIDCState
+		arg4 time.Duration
+	}
+	saveOIDCStateReturns struct {
+		result1 error
+	}
+	saveOIDCStateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SavePhoneOTPStub        func(context.Context, user.TokenPurpose, string, user.PhoneOTP, time.Duration) error
+	savePhoneOTPMutex       sync.RWMutex
+	savePhoneOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+		arg4 user.PhoneOTP
+		arg5 time.Duration
+	}
+	savePhoneOTPReturns struct {
+		result1 error
+	}
+	savePhoneOTPReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveRefreshTokenStub        func(context.Context, string, user.RefreshToken, time.Duration) error
+	saveRefreshTokenMutex       sync.RWMutex
+	saveRefreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.RefreshToken
+		arg4 time.Duration
+	}
+	saveRefreshTokenReturns struct {
+		result1 error
+	}
+	saveRefreshTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveSessionStub        func(context.Context, user.Session) error
+	saveSessionMutex       sync.RWMutex
+	saveSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.Session
+	}
+	saveSessionReturns struct {
+		result1 error
+	}
+	saveSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveSingleUseTokenStub        func(context.Context, user.TokenPurpose, string, int, time.Duration) error
+	saveSingleUseTokenMutex       sync.RWMutex
+	saveSingleUseTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+		arg4 int
+		arg5 time.Duration
+	}
+	saveSingleUseTokenReturns struct {
+		result1 error
+	}
+	saveSingleUseTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveTokenFamilyStub        func(context.Context, string, int, time.Duration) error
+	saveTokenFamilyMutex       sync.RWMutex
+	saveTokenFamilyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 time.Duration
+	}
+	saveTokenFamilyReturns struct {
+		result1 error
+	}
+	saveTokenFamilyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateEmailStub        func(context.Context, int, string) error
+	updateEmailMutex       sync.RWMutex
+	updateEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	updateEmailReturns struct {
+		result1 error
+	}
+	updateEmailReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateEmailVerifiedStub        func(context.Context, int) error
+	updateEmailVerifiedMutex       sync.RWMutex
+	updateEmailVerifiedArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	updateEmailVerifiedReturns struct {
+		result1 error
+	}
+	updateEmailVerifiedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePasswordStub        func(context.Context, int, string) error
+	updatePasswordMutex       sync.RWMutex
+	updatePasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	updatePasswordReturns struct {
+		result1 error
+	}
+	updatePasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePhoneVerifiedStub        func(context.Context, int, string) error
+	updatePhoneVerifiedMutex       sync.RWMutex
+	updatePhoneVerifiedArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	updatePhoneVerifiedReturns struct {
+		result1 error
+	}
+	updatePhoneVerifiedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateProfileStub        func(context.Context, int, string, string, *time.Time) error
+	updateProfileMutex       sync.RWMutex
+	updateProfileArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 string
+		arg5 *time.Time
+	}
+	updateProfileReturns struct {
+		result1 error
+	}
+	updateProfileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateSessionLastSeenStub        func(context.Context, string, string, string) error
+	updateSessionLastSeenMutex       sync.RWMutex
+	updateSessionLastSeenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateSessionLastSeenReturns struct {
+		result1 error
+	}
+	updateSessionLastSeenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateStatusStub        func(context.Context, int, bool) error
+	updateStatusMutex       sync.RWMutex
+	updateStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 bool
+	}
+	updateStatusReturns struct {
+		result1 error
+	}
+	updateStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateTOTPStub        func(context.Context, int, string, *time.Time) error
+	updateTOTPMutex       sync.RWMutex
+	updateTOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 *time.Time
+	}
+	updateTOTPReturns struct {
+		result1 error
+	}
+	updateTOTPReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UseEmailChangeStub        func(context.Context, string) (user.EmailChange, error)
+	useEmailChangeMutex       sync.RWMutex
+	useEmailChangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	useEmailChangeReturns struct {
+		result1 user.EmailChange
+		result2 error
+	}
+	useEmailChangeReturnsOnCall map[int]struct {
+		result1 user.EmailChange
+		result2 error
+	}
+	UseOIDCStateStub        func(context.Context, string) (user.OIDCState, error)
+	useOIDCStateMutex       sync.RWMutex
+	useOIDCStateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	useOIDCStateReturns struct {
+		result1 user.OIDCState
+		result2 error
+	}
+	useOIDCStateReturnsOnCall map[int]struct {
+		result1 user.OIDCState
+		result2 error
+	}
+	UseRecoveryCodeStub        func(context.Context, int, string) (bool, error)
+	useRecoveryCodeMutex       sync.RWMutex
+	useRecoveryCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	useRecoveryCodeReturns struct {
+		result1 bool
+		result2 error
+	}
+	useRecoveryCodeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UseRefreshTokenStub        func(context.Context, string, time.Duration) (bool, error)
+	useRefreshTokenMutex       sync.RWMutex
+	useRefreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	useRefreshTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	useRefreshTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UseSingleUseTokenStub        func(context.Context, user.TokenPurpose, string) (int, error)
+	useSingleUseTokenMutex       sync.RWMutex
+	useSingleUseTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}
+	useSingleUseTokenReturns struct {
+		result1 int
+		result2 error
+	}
+	useSingleUseTokenReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) AcquireRateLimit(arg1 context.Context, arg2 string, arg3 string, arg4 time.Duration) (bool, error) {
+	fake.acquireRateLimitMutex.Lock()
+	ret, specificReturn := fake.acquireRateLimitReturnsOnCall[len(fake.acquireRateLimitArgsForCall)]
+	fake.acquireRateLimitArgsForCall = append(fake.acquireRateLimitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AcquireRateLimitStub
+	fakeReturns := fake.acquireRateLimitReturns
+	fake.recordInvocation("AcquireRateLimit", []interface{}{arg1, arg2, arg3, arg4})
+	fake.acquireRateLimitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) AcquireRateLimitCallCount() int {
+	fake.acquireRateLimitMutex.RLock()
+	defer fake.acquireRateLimitMutex.RUnlock()
+	return len(fake.acquireRateLimitArgsForCall)
+}
+
+func (fake *FakeRepository) AcquireRateLimitCalls(stub func(context.Context, string, string, time.Duration) (bool, error)) {
+	fake.acquireRateLimitMutex.Lock()
+	defer fake.acquireRateLimitMutex.Unlock()
+	fake.AcquireRateLimitStub = stub
+}
+
+func (fake *FakeRepository) AcquireRateLimitArgsForCall(i int) (context.Context, string, string, time.Duration) {
+	fake.acquireRateLimitMutex.RLock()
+	defer fake.acquireRateLimitMutex.RUnlock()
+	argsForCall := fake.acquireRateLimitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) AcquireRateLimitReturns(result1 bool, result2 error) {
+	fake.acquireRateLimitMutex.Lock()
+	defer fake.acquireRateLimitMutex.Unlock()
+	fake.AcquireRateLimitStub = nil
+	fake.acquireRateLimitReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) AcquireRateLimitReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.acquireRateLimitMutex.Lock()
+	defer fake.acquireRateLimitMutex.Unlock()
+	fake.AcquireRateLimitStub = nil
+	if fake.acquireRateLimitReturnsOnCall == nil {
+		fake.acquireRateLimitReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.acquireRateLimitReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ClearRateLimit(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.clearRateLimitMutex.Lock()
+	ret, specificReturn := fake.clearRateLimitReturnsOnCall[len(fake.clearRateLimitArgsForCall)]
+	fake.clearRateLimitArgsForCall = append(fake.clearRateLimitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ClearRateLimitStub
+	fakeReturns := fake.clearRateLimitReturns
+	fake.recordInvocation("ClearRateLimit", []interface{}{arg1, arg2, arg3})
+	fake.clearRateLimitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ClearRateLimitCallCount() int {
+	fake.clearRateLimitMutex.RLock()
+	defer fake.clearRateLimitMutex.RUnlock()
+	return len(fake.clearRateLimitArgsForCall)
+}
+
+func (fake *FakeRepository) ClearRateLimitCalls(stub func(context.Context, string, string) error) {
+	fake.clearRateLimitMutex.Lock()
+	defer fake.clearRateLimitMutex.Unlock()
+	fake.ClearRateLimitStub = stub
+}
+
+func (fake *FakeRepository) ClearRateLimitArgsForCall(i int) (context.Context, string, string) {
+	fake.clearRateLimitMutex.RLock()
+	defer fake.clearRateLimitMutex.RUnlock()
+	argsForCall := fake.clearRateLimitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ClearRateLimitReturns(result1 error) {
+	fake.clearRateLimitMutex.Lock()
+	defer fake.clearRateLimitMutex.Unlock()
+	fake.ClearRateLimitStub = nil
+	fake.clearRateLimitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ClearRateLimitReturnsOnCall(i int, result1 error) {
+	fake.clearRateLimitMutex.Lock()
+	defer fake.clearRateLimitMutex.Unlock()
+	fake.ClearRateLimitStub = nil
+	if fake.clearRateLimitReturnsOnCall == nil {
+		fake.clearRateLimitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearRateLimitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ClearRateLimits(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.clearRateLimitsMutex.Lock()
+	ret, specificReturn := fake.clearRateLimitsReturnsOnCall[len(fake.clearRateLimitsArgsForCall)]
+	fake.clearRateLimitsArgsForCall = append(fake.clearRateLimitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ClearRateLimitsStub
+	fakeReturns := fake.clearRateLimitsReturns
+	fake.recordInvocation("ClearRateLimits", []interface{}{arg1, arg2, arg3})
+	fake.clearRateLimitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ClearRateLimitsCallCount() int {
+	fake.clearRateLimitsMutex.RLock()
+	defer fake.clearRateLimitsMutex.RUnlock()
+	return len(fake.clearRateLimitsArgsForCall)
+}
+
+func (fake *FakeRepository) ClearRateLimitsCalls(stub func(context.Context, string, string) error) {
+	fake.clearRateLimitsMutex.Lock()
+	defer fake.clearRateLimitsMutex.Unlock()
+	fake.ClearRateLimitsStub = stub
+}
+
+func (fake *FakeRepository) ClearRateLimitsArgsForCall(i int) (context.Context, string, string) {
+	fake.clearRateLimitsMutex.RLock()
+	defer fake.clearRateLimitsMutex.RUnlock()
+	argsForCall := fake.clearRateLimitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ClearRateLimitsReturns(result1 error) {
+	fake.clearRateLimitsMutex.Lock()
+	defer fake.clearRateLimitsMutex.Unlock()
+	fake.ClearRateLimitsStub = nil
+	fake.clearRateLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ClearRateLimitsReturnsOnCall(i int, result1 error) {
+	fake.clearRateLimitsMutex.Lock()
+	defer fake.clearRateLimitsMutex.Unlock()
+	fake.ClearRateLimitsStub = nil
+	if fake.clearRateLimitsReturnsOnCall == nil {
+		fake.clearRateLimitsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearRateLimitsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeactivateUser(arg1 context.Context, arg2 int) error {
+	fake.deactivateUserMutex.Lock()
+	ret, specificReturn := fake.deactivateUserReturnsOnCall[len(fake.deactivateUserArgsForCall)]
+	fake.deactivateUserArgsForCall = append(fake.deactivateUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.DeactivateUserStub
+	fakeReturns := fake.deactivateUserReturns
+	fake.recordInvocation("DeactivateUser", []interface{}{arg1, arg2})
+	fake.deactivateUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) DeactivateUserCallCount() int {
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	return len(fake.deactivateUserArgsForCall)
+}
+
+func (fake *FakeRepository) DeactivateUserCalls(stub func(context.Context, int) error) {
+	fake.deactivateUserMutex.Lock()
+	defer fake.deactivateUserMutex.Unlock()
+	fake.DeactivateUserStub = stub
+}
+
+func (fake *FakeRepository) DeactivateUserArgsForCall(i int) (context.Context, int) {
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	argsForCall := fake.deactivateUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) DeactivateUserReturns(result1 error) {
+	fake.deactivateUserMutex.Lock()
+	defer fake.deactivateUserMutex.Unlock()
+	fake.DeactivateUserStub = nil
+	fake.deactivateUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeactivateUserReturnsOnCall(i int, result1 error) {
+	fake.deactivateUserMutex.Lock()
+	defer fake.deactivateUserMutex.Unlock()
+	fake.DeactivateUserStub = nil
+	if fake.deactivateUserReturnsOnCall == nil {
+		fake.deactivateUserReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deactivateUserReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeletePhoneOTP(arg1 context.Context, arg2 user.TokenPurpose, arg3 string) (bool, error) {
+	fake.deletePhoneOTPMutex.Lock()
+	ret, specificReturn := fake.deletePhoneOTPReturnsOnCall[len(fake.deletePhoneOTPArgsForCall)]
+	fake.deletePhoneOTPArgsForCall = append(fake.deletePhoneOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeletePhoneOTPStub
+	fakeReturns := fake.deletePhoneOTPReturns
+	fake.recordInvocation("DeletePhoneOTP", []interface{}{arg1, arg2, arg3})
+	fake.deletePhoneOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) DeletePhoneOTPCallCount() int {
+	fake.deletePhoneOTPMutex.RLock()
+	defer fake.deletePhoneOTPMutex.RUnlock()
+	return len(fake.deletePhoneOTPArgsForCall)
+}
+
+func (fake *FakeRepository) DeletePhoneOTPCalls(stub func(context.Context, user.TokenPurpose, string) (bool, error)) {
+	fake.deletePhoneOTPMutex.Lock()
+	defer fake.deletePhoneOTPMutex.Unlock()
+	fake.DeletePhoneOTPStub = stub
+}
+
+func (fake *FakeRepository) DeletePhoneOTPArgsForCall(i int) (context.Context, user.TokenPurpose, string) {
+	fake.deletePhoneOTPMutex.RLock()
+	defer fake.deletePhoneOTPMutex.RUnlock()
+	argsForCall := fake.deletePhoneOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) DeletePhoneOTPReturns(result1 bool, result2 error) {
+	fake.deletePhoneOTPMutex.Lock()
+	defer fake.deletePhoneOTPMutex.Unlock()
+	fake.DeletePhoneOTPStub = nil
+	fake.deletePhoneOTPReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) DeletePhoneOTPReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deletePhoneOTPMutex.Lock()
+	defer fake.deletePhoneOTPMutex.Unlock()
+	fake.DeletePhoneOTPStub = nil
+	if fake.deletePhoneOTPReturnsOnCall == nil {
+		fake.deletePhoneOTPReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deletePhoneOTPReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindIdentity(arg1 context.Context, arg2 string, arg3 string) (user.UserIdentity, error) {
+	fake.findIdentityMutex.Lock()
+	ret, specificReturn := fake.findIdentityReturnsOnCall[len(fake.findIdentityArgsForCall)]
+	fake.findIdentityArgsForCall = append(fake.findIdentityArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FindIdentityStub
+	fakeReturns := fake.findIdentityReturns
+	fake.recordInvocation("FindIdentity", []interface{}{arg1, arg2, arg3})
+	fake.findIdentityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) FindIdentityCallCount() int {
+	fake.findIdentityMutex.RLock()
+	defer fake.findIdentityMutex.RUnlock()
+	return len(fake.findIdentityArgsForCall)
+}
+
+func (fake *FakeRepository) FindIdentityCalls(stub func(context.Context, string, string) (user.UserIdentity, error)) {
+	fake.findIdentityMutex.Lock()
+	defer fake.findIdentityMutex.Unlock()
+	fake.FindIdentityStub = stub
+}
+
+func (fake *FakeRepository) FindIdentityArgsForCall(i int) (context.Context, string, string) {
+	fake.findIdentityMutex.RLock()
+	defer fake.findIdentityMutex.RUnlock()
+	argsForCall := fake.findIdentityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) FindIdentityReturns(result1 user.UserIdentity, result2 error) {
+	fake.findIdentityMutex.Lock()
+	defer fake.findIdentityMutex.Unlock()
+	fake.FindIdentityStub = nil
+	fake.findIdentityReturns = struct {
+		result1 user.UserIdentity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindIdentityReturnsOnCall(i int, result1 user.UserIdentity, result2 error) {
+	fake.findIdentityMutex.Lock()
+	defer fake.findIdentityMutex.Unlock()
+	fake.FindIdentityStub = nil
+	if fake.findIdentityReturnsOnCall == nil {
+		fake.findIdentityReturnsOnCall = make(map[int]struct {
+			result1 user.UserIdentity
+			result2 error
+		})
+	}
+	fake.findIdentityReturnsOnCall[i] = struct {
+		result1 user.UserIdentity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByEmail(arg1 context.Context, arg2 string) (user.User, error) {
+	fake.findUserByEmailMutex.Lock()
+	ret, specificReturn := fake.findUserByEmailReturnsOnCall[len(fake.findUserByEmailArgsForCall)]
+	fake.findUserByEmailArgsForCall = append(fake.findUserByEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindUserByEmailStub
+	fakeReturns := fake.findUserByEmailReturns
+	fake.recordInvocation("FindUserByEmail", []interface{}{arg1, arg2})
+	fake.findUserByEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) FindUserByEmailCallCount() int {
+	fake.findUserByEmailMutex.RLock()
+	defer fake.findUserByEmailMutex.RUnlock()
+	return len(fake.findUserByEmailArgsForCall)
+}
+
+func (fake *FakeRepository) FindUserByEmailCalls(stub func(context.Context, string) (user.User, error)) {
+	fake.findUserByEmailMutex.Lock()
+	defer fake.findUserByEmailMutex.Unlock()
+	fake.FindUserByEmailStub = stub
+}
+
+func (fake *FakeRepository) FindUserByEmailArgsForCall(i int) (context.Context, string) {
+	fake.findUserByEmailMutex.RLock()
+	defer fake.findUserByEmailMutex.RUnlock()
+	argsForCall := fake.findUserByEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) FindUserByEmailReturns(result1 user.User, result2 error) {
+	fake.findUserByEmailMutex.Lock()
+	defer fake.findUserByEmailMutex.Unlock()
+	fake.FindUserByEmailStub = nil
+	fake.findUserByEmailReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByEmailReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.findUserByEmailMutex.Lock()
+	defer fake.findUserByEmailMutex.Unlock()
+	fake.FindUserByEmailStub = nil
+	if fake.findUserByEmailReturnsOnCall == nil {
+		fake.findUserByEmailReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.findUserByEmailReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByID(arg1 context.Context, arg2 int) (user.User, error) {
+	fake.findUserByIDMutex.Lock()
+	ret, specificReturn := fake.findUserByIDReturnsOnCall[len(fake.findUserByIDArgsForCall)]
+	fake.findUserByIDArgsForCall = append(fake.findUserByIDArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.FindUserByIDStub
+	fakeReturns := fake.findUserByIDReturns
+	fake.recordInvocation("FindUserByID", []interface{}{arg1, arg2})
+	fake.findUserByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) FindUserByIDCallCount() int {
+	fake.findUserByIDMutex.RLock()
+	defer fake.findUserByIDMutex.RUnlock()
+	return len(fake.findUserByIDArgsForCall)
+}
+
+func (fake *FakeRepository) FindUserByIDCalls(stub func(context.Context, int) (user.User, error)) {
+	fake.findUserByIDMutex.Lock()
+	defer fake.findUserByIDMutex.Unlock()
+	fake.FindUserByIDStub = stub
+}
+
+func (fake *FakeRepository) FindUserByIDArgsForCall(i int) (context.Context, int) {
+	fake.findUserByIDMutex.RLock()
+	defer fake.findUserByIDMutex.RUnlock()
+	argsForCall := fake.findUserByIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) FindUserByIDReturns(result1 user.User, result2 error) {
+	fake.findUserByIDMutex.Lock()
+	defer fake.findUserByIDMutex.Unlock()
+	fake.FindUserByIDStub = nil
+	fake.findUserByIDReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByIDReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.findUserByIDMutex.Lock()
+	defer fake.findUserByIDMutex.Unlock()
+	fake.FindUserByIDStub = nil
+	if fake.findUserByIDReturnsOnCall == nil {
+		fake.findUserByIDReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.findUserByIDReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhone(arg1 context.Context, arg2 string) (user.User, error) {
+	fake.findUserByVerifiedPhoneMutex.Lock()
+	ret, specificReturn := fake.findUserByVerifiedPhoneReturnsOnCall[len(fake.findUserByVerifiedPhoneArgsForCall)]
+	fake.findUserByVerifiedPhoneArgsForCall = append(fake.findUserByVerifiedPhoneArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindUserByVerifiedPhoneStub
+	fakeReturns := fake.findUserByVerifiedPhoneReturns
+	fake.recordInvocation("FindUserByVerifiedPhone", []interface{}{arg1, arg2})
+	fake.findUserByVerifiedPhoneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhoneCallCount() int {
+	fake.findUserByVerifiedPhoneMutex.RLock()
+	defer fake.findUserByVerifiedPhoneMutex.RUnlock()
+	return len(fake.findUserByVerifiedPhoneArgsForCall)
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhoneCalls(stub func(context.Context, string) (user.User, error)) {
+	fake.findUserByVerifiedPhoneMutex.Lock()
+	defer fake.findUserByVerifiedPhoneMutex.Unlock()
+	fake.FindUserByVerifiedPhoneStub = stub
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhoneArgsForCall(i int) (context.Context, string) {
+	fake.findUserByVerifiedPhoneMutex.RLock()
+	defer fake.findUserByVerifiedPhoneMutex.RUnlock()
+	argsForCall := fake.findUserByVerifiedPhoneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhoneReturns(result1 user.User, result2 error) {
+	fake.findUserByVerifiedPhoneMutex.Lock()
+	defer fake.findUserByVerifiedPhoneMutex.Unlock()
+	fake.FindUserByVerifiedPhoneStub = nil
+	fake.findUserByVerifiedPhoneReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindUserByVerifiedPhoneReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.findUserByVerifiedPhoneMutex.Lock()
+	defer fake.findUserByVerifiedPhoneMutex.Unlock()
+	fake.FindUserByVerifiedPhoneStub = nil
+	if fake.findUserByVerifiedPhoneReturnsOnCall == nil {
+		fake.findUserByVerifiedPhoneReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.findUserByVerifiedPhoneReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetActiveSessions(arg1 context.Context, arg2 int, arg3 time.Time) ([]user.Session, error) {
+	fake.getActiveSessionsMutex.Lock()
+	ret, specificReturn := fake.getActiveSessionsReturnsOnCall[len(fake.getActiveSessionsArgsForCall)]
+	fake.getActiveSessionsArgsForCall = append(fake.getActiveSessionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.GetActiveSessionsStub
+	fakeReturns := fake.getActiveSessionsReturns
+	fake.recordInvocation("GetActiveSessions", []interface{}{arg1, arg2, arg3})
+	fake.getActiveSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetActiveSessionsCallCount() int {
+	fake.getActiveSessionsMutex.RLock()
+	defer fake.getActiveSessionsMutex.RUnlock()
+	return len(fake.getActiveSessionsArgsForCall)
+}
+
+func (fake *FakeRepository) GetActiveSessionsCalls(stub func(context.Context, int, time.Time) ([]user.Session, error)) {
+	fake.getActiveSessionsMutex.Lock()
+	defer fake.getActiveSessionsMutex.Unlock()
+	fake.GetActiveSessionsStub = stub
+}
+
+func (fake *FakeRepository) GetActiveSessionsArgsForCall(i int) (context.Context, int, time.Time) {
+	fake.getActiveSessionsMutex.RLock()
+	defer fake.getActiveSessionsMutex.RUnlock()
+	argsForCall := fake.getActiveSessionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetActiveSessionsReturns(result1 []user.Session, result2 error) {
+	fake.getActiveSessionsMutex.Lock()
+	defer fake.getActiveSessionsMutex.Unlock()
+	fake.GetActiveSessionsStub = nil
+	fake.getActiveSessionsReturns = struct {
+		result1 []user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetActiveSessionsReturnsOnCall(i int, result1 []user.Session, result2 error) {
+	fake.getActiveSessionsMutex.Lock()
+	defer fake.getActiveSessionsMutex.Unlock()
+	fake.GetActiveSessionsStub = nil
+	if fake.getActiveSessionsReturnsOnCall == nil {
+		fake.getActiveSessionsReturnsOnCall = make(map[int]struct {
+			result1 []user.Session
+			result2 error
+		})
+	}
+	fake.getActiveSessionsReturnsOnCall[i] = struct {
+		result1 []user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetAuthEvents(arg1 context.Context, arg2 int, arg3 int, arg4 int) ([]user.AuthEvent, int, error) {
+	fake.getAuthEventsMutex.Lock()
+	ret, specificReturn := fake.getAuthEventsReturnsOnCall[len(fake.getAuthEventsArgsForCall)]
+	fake.getAuthEventsArgsForCall = append(fake.getAuthEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetAuthEventsStub
+	fakeReturns := fake.getAuthEventsReturns
+	fake.recordInvocation("GetAuthEvents", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getAuthEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeRepository) GetAuthEventsCallCount() int {
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	return len(fake.getAuthEventsArgsForCall)
+}
+
+func (fake *FakeRepository) GetAuthEventsCalls(stub func(context.Context, int, int, int) ([]user.AuthEvent, int, error)) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = stub
+}
+
+func (fake *FakeRepository) GetAuthEventsArgsForCall(i int) (context.Context, int, int, int) {
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	argsForCall := fake.getAuthEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) GetAuthEventsReturns(result1 []user.AuthEvent, result2 int, result3 error) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = nil
+	fake.getAuthEventsReturns = struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeRepository) GetAuthEventsReturnsOnCall(i int, result1 []user.AuthEvent, result2 int, result3 error) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = nil
+	if fake.getAuthEventsReturnsOnCall == nil {
+		fake.getAuthEventsReturnsOnCall = make(map[int]struct {
+			result1 []user.AuthEvent
+			result2 int
+			result3 error
+		})
+	}
+	fake.getAuthEventsReturnsOnCall[i] = struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeRepository) GetPhoneOTP(arg1 context.Context, arg2 user.TokenPurpose, arg3 string) (user.PhoneOTP, error) {
+	fake.getPhoneOTPMutex.Lock()
+	ret, specificReturn := fake.getPhoneOTPReturnsOnCall[len(fake.getPhoneOTPArgsForCall)]
+	fake.getPhoneOTPArgsForCall = append(fake.getPhoneOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetPhoneOTPStub
+	fakeReturns := fake.getPhoneOTPReturns
+	fake.recordInvocation("GetPhoneOTP", []interface{}{arg1, arg2, arg3})
+	fake.getPhoneOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetPhoneOTPCallCount() int {
+	fake.getPhoneOTPMutex.RLock()
+	defer fake.getPhoneOTPMutex.RUnlock()
+	return len(fake.getPhoneOTPArgsForCall)
+}
+
+func (fake *FakeRepository) GetPhoneOTPCalls(stub func(context.Context, user.TokenPurpose, string) (user.PhoneOTP, error)) {
+	fake.getPhoneOTPMutex.Lock()
+	defer fake.getPhoneOTPMutex.Unlock()
+	fake.GetPhoneOTPStub = stub
+}
+
+func (fake *FakeRepository) GetPhoneOTPArgsForCall(i int) (context.Context, user.TokenPurpose, string) {
+	fake.getPhoneOTPMutex.RLock()
+	defer fake.getPhoneOTPMutex.RUnlock()
+	argsForCall := fake.getPhoneOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetPhoneOTPReturns(result1 user.PhoneOTP, result2 error) {
+	fake.getPhoneOTPMutex.Lock()
+	defer fake.getPhoneOTPMutex.Unlock()
+	fake.GetPhoneOTPStub = nil
+	fake.getPhoneOTPReturns = struct {
+		result1 user.PhoneOTP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetPhoneOTPReturnsOnCall(i int, result1 user.PhoneOTP, result2 error) {
+	fake.getPhoneOTPMutex.Lock()
+	defer fake.getPhoneOTPMutex.Unlock()
+	fake.GetPhoneOTPStub = nil
+	if fake.getPhoneOTPReturnsOnCall == nil {
+		fake.getPhoneOTPReturnsOnCall = make(map[int]struct {
+			result1 user.PhoneOTP
+			result2 error
+		})
+	}
+	fake.getPhoneOTPReturnsOnCall[i] = struct {
+		result1 user.PhoneOTP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRateLimit(arg1 context.Context, arg2 string, arg3 string) (time.Duration, error) {
+	fake.getRateLimitMutex.Lock()
+	ret, specificReturn := fake.getRateLimitReturnsOnCall[len(fake.getRateLimitArgsForCall)]
+	fake.getRateLimitArgsForCall = append(fake.getRateLimitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetRateLimitStub
+	fakeReturns := fake.getRateLimitReturns
+	fake.recordInvocation("GetRateLimit", []interface{}{arg1, arg2, arg3})
+	fake.getRateLimitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetRateLimitCallCount() int {
+	fake.getRateLimitMutex.RLock()
+	defer fake.getRateLimitMutex.RUnlock()
+	return len(fake.getRateLimitArgsForCall)
+}
+
+func (fake *FakeRepository) GetRateLimitCalls(stub func(context.Context, string, string) (time.Duration, error)) {
+	fake.getRateLimitMutex.Lock()
+	defer fake.getRateLimitMutex.Unlock()
+	fake.GetRateLimitStub = stub
+}
+
+func (fake *FakeRepository) GetRateLimitArgsForCall(i int) (context.Context, string, string) {
+	fake.getRateLimitMutex.RLock()
+	defer fake.getRateLimitMutex.RUnlock()
+	argsForCall := fake.getRateLimitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetRateLimitReturns(result1 time.Duration, result2 error) {
+	fake.getRateLimitMutex.Lock()
+	defer fake.getRateLimitMutex.Unlock()
+	fake.GetRateLimitStub = nil
+	fake.getRateLimitReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRateLimitReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.getRateLimitMutex.Lock()
+	defer fake.getRateLimitMutex.Unlock()
+	fake.GetRateLimitStub = nil
+	if fake.getRateLimitReturnsOnCall == nil {
+		fake.getRateLimitReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.getRateLimitReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRefreshToken(arg1 context.Context, arg2 string) (user.RefreshToken, error) {
+	fake.getRefreshTokenMutex.Lock()
+	ret, specificReturn := fake.getRefreshTokenReturnsOnCall[len(fake.getRefreshTokenArgsForCall)]
+	fake.getRefreshTokenArgsForCall = append(fake.getRefreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetRefreshTokenStub
+	fakeReturns := fake.getRefreshTokenReturns
+	fake.recordInvocation("GetRefreshToken", []interface{}{arg1, arg2})
+	fake.getRefreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetRefreshTokenCallCount() int {
+	fake.getRefreshTokenMutex.RLock()
+	defer fake.getRefreshTokenMutex.RUnlock()
+	return len(fake.getRefreshTokenArgsForCall)
+}
+
+func (fake *FakeRepository) GetRefreshTokenCalls(stub func(context.Context, string) (user.RefreshToken, error)) {
+	fake.getRefreshTokenMutex.Lock()
+	defer fake.getRefreshTokenMutex.Unlock()
+	fake.GetRefreshTokenStub = stub
+}
+
+func (fake *FakeRepository) GetRefreshTokenArgsForCall(i int) (context.Context, string) {
+	fake.getRefreshTokenMutex.RLock()
+	defer fake.getRefreshTokenMutex.RUnlock()
+	argsForCall := fake.getRefreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetRefreshTokenReturns(result1 user.RefreshToken, result2 error) {
+	fake.getRefreshTokenMutex.Lock()
+	defer fake.getRefreshTokenMutex.Unlock()
+	fake.GetRefreshTokenStub = nil
+	fake.getRefreshTokenReturns = struct {
+		result1 user.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRefreshTokenReturnsOnCall(i int, result1 user.RefreshToken, result2 error) {
+	fake.getRefreshTokenMutex.Lock()
+	defer fake.getRefreshTokenMutex.Unlock()
+	fake.GetRefreshTokenStub = nil
+	if fake.getRefreshTokenReturnsOnCall == nil {
+		fake.getRefreshTokenReturnsOnCall = make(map[int]struct {
+			result1 user.RefreshToken
+			result2 error
+		})
+	}
+	fake.getRefreshTokenReturnsOnCall[i] = struct {
+		result1 user.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSession(arg1 context.Context, arg2 int, arg3 int) (user.Session, error) {
+	fake.getSessionMutex.Lock()
+	ret, specificReturn := fake.getSessionReturnsOnCall[len(fake.getSessionArgsForCall)]
+	fake.getSessionArgsForCall = append(fake.getSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetSessionStub
+	fakeReturns := fake.getSessionReturns
+	fake.recordInvocation("GetSession", []interface{}{arg1, arg2, arg3})
+	fake.getSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSessionCallCount() int {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	return len(fake.getSessionArgsForCall)
+}
+
+func (fake *FakeRepository) GetSessionCalls(stub func(context.Context, int, int) (user.Session, error)) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = stub
+}
+
+func (fake *FakeRepository) GetSessionArgsForCall(i int) (context.Context, int, int) {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	argsForCall := fake.getSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetSessionReturns(result1 user.Session, result2 error) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = nil
+	fake.getSessionReturns = struct {
+		result1 user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSessionReturnsOnCall(i int, result1 user.Session, result2 error) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = nil
+	if fake.getSessionReturnsOnCall == nil {
+		fake.getSessionReturnsOnCall = make(map[int]struct {
+			result1 user.Session
+			result2 error
+		})
+	}
+	fake.getSessionReturnsOnCall[i] = struct {
+		result1 user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSingleUseToken(arg1 context.Context, arg2 user.TokenPurpose, arg3 string) (int, error) {
+	fake.getSingleUseTokenMutex.Lock()
+	ret, specificReturn := fake.getSingleUseTokenReturnsOnCall[len(fake.getSingleUseTokenArgsForCall)]
+	fake.getSingleUseTokenArgsForCall = append(fake.getSingleUseTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSingleUseTokenStub
+	fakeReturns := fake.getSingleUseTokenReturns
+	fake.recordInvocation("GetSingleUseToken", []interface{}{arg1, arg2, arg3})
+	fake.getSingleUseTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSingleUseTokenCallCount() int {
+	fake.getSingleUseTokenMutex.RLock()
+	defer fake.getSingleUseTokenMutex.RUnlock()
+	return len(fake.getSingleUseTokenArgsForCall)
+}
+
+func (fake *FakeRepository) GetSingleUseTokenCalls(stub func(context.Context, user.TokenPurpose, string) (int, error)) {
+	fake.getSingleUseTokenMutex.Lock()
+	defer fake.getSingleUseTokenMutex.Unlock()
+	fake.GetSingleUseTokenStub = stub
+}
+
+func (fake *FakeRepository) GetSingleUseTokenArgsForCall(i int) (context.Context, user.TokenPurpose, string) {
+	fake.getSingleUseTokenMutex.RLock()
+	defer fake.getSingleUseTokenMutex.RUnlock()
+	argsForCall := fake.getSingleUseTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetSingleUseTokenReturns(result1 int, result2 error) {
+	fake.getSingleUseTokenMutex.Lock()
+	defer fake.getSingleUseTokenMutex.Unlock()
+	fake.GetSingleUseTokenStub = nil
+	fake.getSingleUseTokenReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSingleUseTokenReturnsOnCall(i int, result1 int, result2 error) {
+	fake.getSingleUseTokenMutex.Lock()
+	defer fake.getSingleUseTokenMutex.Unlock()
+	fake.GetSingleUseTokenStub = nil
+	if fake.getSingleUseTokenReturnsOnCall == nil {
+		fake.getSingleUseTokenReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.getSingleUseTokenReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) IncrementCounter(arg1 context.Context, arg2 string, arg3 string, arg4 time.Duration) (int64, error) {
+	fake.incrementCounterMutex.Lock()
+	ret, specificReturn := fake.incrementCounterReturnsOnCall[len(fake.incrementCounterArgsForCall)]
+	fake.incrementCounterArgsForCall = append(fake.incrementCounterArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.IncrementCounterStub
+	fakeReturns := fake.incrementCounterReturns
+	fake.recordInvocation("IncrementCounter", []interface{}{arg1, arg2, arg3, arg4})
+	fake.incrementCounterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) IncrementCounterCallCount() int {
+	fake.incrementCounterMutex.RLock()
+	defer fake.incrementCounterMutex.RUnlock()
+	return len(fake.incrementCounterArgsForCall)
+}
+
+func (fake *FakeRepository) IncrementCounterCalls(stub func(context.Context, string, string, time.Duration) (int64, error)) {
+	fake.incrementCounterMutex.Lock()
+	defer fake.incrementCounterMutex.Unlock()
+	fake.IncrementCounterStub = stub
+}
+
+func (fake *FakeRepository) IncrementCounterArgsForCall(i int) (context.Context, string, string, time.Duration) {
+	fake.incrementCounterMutex.RLock()
+	defer fake.incrementCounterMutex.RUnlock()
+	argsForCall := fake.incrementCounterArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) IncrementCounterReturns(result1 int64, result2 error) {
+	fake.incrementCounterMutex.Lock()
+	defer fake.incrementCounterMutex.Unlock()
+	fake.IncrementCounterStub = nil
+	fake.incrementCounterReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) IncrementCounterReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.incrementCounterMutex.Lock()
+	defer fake.incrementCounterMutex.Unlock()
+	fake.IncrementCounterStub = nil
+	if fake.incrementCounterReturnsOnCall == nil {
+		fake.incrementCounterReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.incrementCounterReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) IsTokenFamilyActive(arg1 context.Context, arg2 string) (bool, error) {
+	fake.isTokenFamilyActiveMutex.Lock()
+	ret, specificReturn := fake.isTokenFamilyActiveReturnsOnCall[len(fake.isTokenFamilyActiveArgsForCall)]
+	fake.isTokenFamilyActiveArgsForCall = append(fake.isTokenFamilyActiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IsTokenFamilyActiveStub
+	fakeReturns := fake.isTokenFamilyActiveReturns
+	fake.recordInvocation("IsTokenFamilyActive", []interface{}{arg1, arg2})
+	fake.isTokenFamilyActiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) IsTokenFamilyActiveCallCount() int {
+	fake.isTokenFamilyActiveMutex.RLock()
+	defer fake.isTokenFamilyActiveMutex.RUnlock()
+	return len(fake.isTokenFamilyActiveArgsForCall)
+}
+
+func (fake *FakeRepository) IsTokenFamilyActiveCalls(stub func(context.Context, string) (bool, error)) {
+	fake.isTokenFamilyActiveMutex.Lock()
+	defer fake.isTokenFamilyActiveMutex.Unlock()
+	fake.IsTokenFamilyActiveStub = stub
+}
+
+func (fake *FakeRepository) IsTokenFamilyActiveArgsForCall(i int) (context.Context, string) {
+	fake.isTokenFamilyActiveMutex.RLock()
+	defer fake.isTokenFamilyActiveMutex.RUnlock()
+	argsForCall := fake.isTokenFamilyActiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) IsTokenFamilyActiveReturns(result1 bool, result2 error) {
+	fake.isTokenFamilyActiveMutex.Lock()
+	defer fake.isTokenFamilyActiveMutex.Unlock()
+	fake.IsTokenFamilyActiveStub = nil
+	fake.isTokenFamilyActiveReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) IsTokenFamilyActiveReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isTokenFamilyActiveMutex.Lock()
+	defer fake.isTokenFamilyActiveMutex.Unlock()
+	fake.IsTokenFamilyActiveStub = nil
+	if fake.isTokenFamilyActiveReturnsOnCall == nil {
+		fake.isTokenFamilyActiveReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isTokenFamilyActiveReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) RegisterIdentityUser(arg1 context.Context, arg2 user.User, arg3 user.UserIdentity) (user.User, error) {
+	fake.registerIdentityUserMutex.Lock()
+	ret, specificReturn := fake.registerIdentityUserReturnsOnCall[len(fake.registerIdentityUserArgsForCall)]
+	fake.registerIdentityUserArgsForCall = append(fake.registerIdentityUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.User
+		arg3 user.UserIdentity
+	}{arg1, arg2, arg3})
+	stub := fake.RegisterIdentityUserStub
+	fakeReturns := fake.registerIdentityUserReturns
+	fake.recordInvocation("RegisterIdentityUser", []interface{}{arg1, arg2, arg3})
+	fake.registerIdentityUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) RegisterIdentityUserCallCount() int {
+	fake.registerIdentityUserMutex.RLock()
+	defer fake.registerIdentityUserMutex.RUnlock()
+	return len(fake.registerIdentityUserArgsForCall)
+}
+
+func (fake *FakeRepository) RegisterIdentityUserCalls(stub func(context.Context, user.User, user.UserIdentity) (user.User, error)) {
+	fake.registerIdentityUserMutex.Lock()
+	defer fake.registerIdentityUserMutex.Unlock()
+	fake.RegisterIdentityUserStub = stub
+}
+
+func (fake *FakeRepository) RegisterIdentityUserArgsForCall(i int) (context.Context, user.User, user.UserIdentity) {
+	fake.registerIdentityUserMutex.RLock()
+	defer fake.registerIdentityUserMutex.RUnlock()
+	argsForCall := fake.registerIdentityUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) RegisterIdentityUserReturns(result1 user.User, result2 error) {
+	fake.registerIdentityUserMutex.Lock()
+	defer fake.registerIdentityUserMutex.Unlock()
+	fake.RegisterIdentityUserStub = nil
+	fake.registerIdentityUserReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) RegisterIdentityUserReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.registerIdentityUserMutex.Lock()
+	defer fake.registerIdentityUserMutex.Unlock()
+	fake.RegisterIdentityUserStub = nil
+	if fake.registerIdentityUserReturnsOnCall == nil {
+		fake.registerIdentityUserReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.registerIdentityUserReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) RegisterNewUser(arg1 context.Context, arg2 user.User) (user.User, error) {
+	fake.registerNewUserMutex.Lock()
+	ret, specificReturn := fake.registerNewUserReturnsOnCall[len(fake.registerNewUserArgsForCall)]
+	fake.registerNewUserArgsForCall = append(fake.registerNewUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.User
+	}{arg1, arg2})
+	stub := fake.RegisterNewUserStub
+	fakeReturns := fake.registerNewUserReturns
+	fake.recordInvocation("RegisterNewUser", []interface{}{arg1, arg2})
+	fake.registerNewUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) RegisterNewUserCallCount() int {
+	fake.registerNewUserMutex.RLock()
+	defer fake.registerNewUserMutex.RUnlock()
+	return len(fake.registerNewUserArgsForCall)
+}
+
+func (fake *FakeRepository) RegisterNewUserCalls(stub func(context.Context, user.User) (user.User, error)) {
+	fake.registerNewUserMutex.Lock()
+	defer fake.registerNewUserMutex.Unlock()
+	fake.RegisterNewUserStub = stub
+}
+
+func (fake *FakeRepository) RegisterNewUserArgsForCall(i int) (context.Context, user.User) {
+	fake.registerNewUserMutex.RLock()
+	defer fake.registerNewUserMutex.RUnlock()
+	argsForCall := fake.registerNewUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) RegisterNewUserReturns(result1 user.User, result2 error) {
+	fake.registerNewUserMutex.Lock()
+	defer fake.registerNewUserMutex.Unlock()
+	fake.RegisterNewUserStub = nil
+	fake.registerNewUserReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) RegisterNewUserReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.registerNewUserMutex.Lock()
+	defer fake.registerNewUserMutex.Unlock()
+	fake.RegisterNewUserStub = nil
+	if fake.registerNewUserReturnsOnCall == nil {
+		fake.registerNewUserReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.registerNewUserReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) RehashPassword(arg1 context.Context, arg2 int, arg3 string, arg4 string) error {
+	fake.rehashPasswordMutex.Lock()
+	ret, specificReturn := fake.rehashPasswordReturnsOnCall[len(fake.rehashPasswordArgsForCall)]
+	fake.rehashPasswordArgsForCall = append(fake.rehashPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RehashPasswordStub
+	fakeReturns := fake.rehashPasswordReturns
+	fake.recordInvocation("RehashPassword", []interface{}{arg1, arg2, arg3, arg4})
+	fake.rehashPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) RehashPasswordCallCount() int {
+	fake.rehashPasswordMutex.RLock()
+	defer fake.rehashPasswordMutex.RUnlock()
+	return len(fake.rehashPasswordArgsForCall)
+}
+
+func (fake *FakeRepository) RehashPasswordCalls(stub func(context.Context, int, string, string) error) {
+	fake.rehashPasswordMutex.Lock()
+	defer fake.rehashPasswordMutex.Unlock()
+	fake.RehashPasswordStub = stub
+}
+
+func (fake *FakeRepository) RehashPasswordArgsForCall(i int) (context.Context, int, string, string) {
+	fake.rehashPasswordMutex.RLock()
+	defer fake.rehashPasswordMutex.RUnlock()
+	argsForCall := fake.rehashPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) RehashPasswordReturns(result1 error) {
+	fake.rehashPasswordMutex.Lock()
+	defer fake.rehashPasswordMutex.Unlock()
+	fake.RehashPasswordStub = nil
+	fake.rehashPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RehashPasswordReturnsOnCall(i int, result1 error) {
+	fake.rehashPasswordMutex.Lock()
+	defer fake.rehashPasswordMutex.Unlock()
+	fake.RehashPasswordStub = nil
+	if fake.rehashPasswordReturnsOnCall == nil {
+		fake.rehashPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rehashPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodes(arg1 context.Context, arg2 int, arg3 []user.RecoveryCode) error {
+	var arg3Copy []user.RecoveryCode
+	if arg3 != nil {
+		arg3Copy = make([]user.RecoveryCode, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.replaceRecoveryCodesMutex.Lock()
+	ret, specificReturn := fake.replaceRecoveryCodesReturnsOnCall[len(fake.replaceRecoveryCodesArgsForCall)]
+	fake.replaceRecoveryCodesArgsForCall = append(fake.replaceRecoveryCodesArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 []user.RecoveryCode
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ReplaceRecoveryCodesStub
+	fakeReturns := fake.replaceRecoveryCodesReturns
+	fake.recordInvocation("ReplaceRecoveryCodes", []interface{}{arg1, arg2, arg3Copy})
+	fake.replaceRecoveryCodesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodesCallCount() int {
+	fake.replaceRecoveryCodesMutex.RLock()
+	defer fake.replaceRecoveryCodesMutex.RUnlock()
+	return len(fake.replaceRecoveryCodesArgsForCall)
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodesCalls(stub func(context.Context, int, []user.RecoveryCode) error) {
+	fake.replaceRecoveryCodesMutex.Lock()
+	defer fake.replaceRecoveryCodesMutex.Unlock()
+	fake.ReplaceRecoveryCodesStub = stub
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodesArgsForCall(i int) (context.Context, int, []user.RecoveryCode) {
+	fake.replaceRecoveryCodesMutex.RLock()
+	defer fake.replaceRecoveryCodesMutex.RUnlock()
+	argsForCall := fake.replaceRecoveryCodesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodesReturns(result1 error) {
+	fake.replaceRecoveryCodesMutex.Lock()
+	defer fake.replaceRecoveryCodesMutex.Unlock()
+	fake.ReplaceRecoveryCodesStub = nil
+	fake.replaceRecoveryCodesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ReplaceRecoveryCodesReturnsOnCall(i int, result1 error) {
+	fake.replaceRecoveryCodesMutex.Lock()
+	defer fake.replaceRecoveryCodesMutex.Unlock()
+	fake.ReplaceRecoveryCodesStub = nil
+	if fake.replaceRecoveryCodesReturnsOnCall == nil {
+		fake.replaceRecoveryCodesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.replaceRecoveryCodesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ResetCounter(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.resetCounterMutex.Lock()
+	ret, specificReturn := fake.resetCounterReturnsOnCall[len(fake.resetCounterArgsForCall)]
+	fake.resetCounterArgsForCall = append(fake.resetCounterArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ResetCounterStub
+	fakeReturns := fake.resetCounterReturns
+	fake.recordInvocation("ResetCounter", []interface{}{arg1, arg2, arg3})
+	fake.resetCounterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ResetCounterCallCount() int {
+	fake.resetCounterMutex.RLock()
+	defer fake.resetCounterMutex.RUnlock()
+	return len(fake.resetCounterArgsForCall)
+}
+
+func (fake *FakeRepository) ResetCounterCalls(stub func(context.Context, string, string) error) {
+	fake.resetCounterMutex.Lock()
+	defer fake.resetCounterMutex.Unlock()
+	fake.ResetCounterStub = stub
+}
+
+func (fake *FakeRepository) ResetCounterArgsForCall(i int) (context.Context, string, string) {
+	fake.resetCounterMutex.RLock()
+	defer fake.resetCounterMutex.RUnlock()
+	argsForCall := fake.resetCounterArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ResetCounterReturns(result1 error) {
+	fake.resetCounterMutex.Lock()
+	defer fake.resetCounterMutex.Unlock()
+	fake.ResetCounterStub = nil
+	fake.resetCounterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ResetCounterReturnsOnCall(i int, result1 error) {
+	fake.resetCounterMutex.Lock()
+	defer fake.resetCounterMutex.Unlock()
+	fake.ResetCounterStub = nil
+	if fake.resetCounterReturnsOnCall == nil {
+		fake.resetCounterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetCounterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ResetCounters(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.resetCountersMutex.Lock()
+	ret, specificReturn := fake.resetCountersReturnsOnCall[len(fake.resetCountersArgsForCall)]
+	fake.resetCountersArgsForCall = append(fake.resetCountersArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ResetCountersStub
+	fakeReturns := fake.resetCountersReturns
+	fake.recordInvocation("ResetCounters", []interface{}{arg1, arg2, arg3})
+	fake.resetCountersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ResetCountersCallCount() int {
+	fake.resetCountersMutex.RLock()
+	defer fake.resetCountersMutex.RUnlock()
+	return len(fake.resetCountersArgsForCall)
+}
+
+func (fake *FakeRepository) ResetCountersCalls(stub func(context.Context, string, string) error) {
+	fake.resetCountersMutex.Lock()
+	defer fake.resetCountersMutex.Unlock()
+	fake.ResetCountersStub = stub
+}
+
+func (fake *FakeRepository) ResetCountersArgsForCall(i int) (context.Context, string, string) {
+	fake.resetCountersMutex.RLock()
+	defer fake.resetCountersMutex.RUnlock()
+	argsForCall := fake.resetCountersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ResetCountersReturns(result1 error) {
+	fake.resetCountersMutex.Lock()
+	defer fake.resetCountersMutex.Unlock()
+	fake.ResetCountersStub = nil
+	fake.resetCountersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ResetCountersReturnsOnCall(i int, result1 error) {
+	fake.resetCountersMutex.Lock()
+	defer fake.resetCountersMutex.Unlock()
+	fake.ResetCountersStub = nil
+	if fake.resetCountersReturnsOnCall == nil {
+		fake.resetCountersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetCountersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeAPIKeys(arg1 context.Context, arg2 int) error {
+	fake.revokeAPIKeysMutex.Lock()
+	ret, specificReturn := fake.revokeAPIKeysReturnsOnCall[len(fake.revokeAPIKeysArgsForCall)]
+	fake.revokeAPIKeysArgsForCall = append(fake.revokeAPIKeysArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeAPIKeysStub
+	fakeReturns := fake.revokeAPIKeysReturns
+	fake.recordInvocation("RevokeAPIKeys", []interface{}{arg1, arg2})
+	fake.revokeAPIKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) RevokeAPIKeysCallCount() int {
+	fake.revokeAPIKeysMutex.RLock()
+	defer fake.revokeAPIKeysMutex.RUnlock()
+	return len(fake.revokeAPIKeysArgsForCall)
+}
+
+func (fake *FakeRepository) RevokeAPIKeysCalls(stub func(context.Context, int) error) {
+	fake.revokeAPIKeysMutex.Lock()
+	defer fake.revokeAPIKeysMutex.Unlock()
+	fake.RevokeAPIKeysStub = stub
+}
+
+func (fake *FakeRepository) RevokeAPIKeysArgsForCall(i int) (context.Context, int) {
+	fake.revokeAPIKeysMutex.RLock()
+	defer fake.revokeAPIKeysMutex.RUnlock()
+	argsForCall := fake.revokeAPIKeysArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) RevokeAPIKeysReturns(result1 error) {
+	fake.revokeAPIKeysMutex.Lock()
+	defer fake.revokeAPIKeysMutex.Unlock()
+	fake.RevokeAPIKeysStub = nil
+	fake.revokeAPIKeysReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeAPIKeysReturnsOnCall(i int, result1 error) {
+	fake.revokeAPIKeysMutex.Lock()
+	defer fake.revokeAPIKeysMutex.Unlock()
+	fake.RevokeAPIKeysStub = nil
+	if fake.revokeAPIKeysReturnsOnCall == nil {
+		fake.revokeAPIKeysReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeAPIKeysReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeSession(arg1 context.Context, arg2 string) error {
+	fake.revokeSessionMutex.Lock()
+	ret, specificReturn := fake.revokeSessionReturnsOnCall[len(fake.revokeSessionArgsForCall)]
+	fake.revokeSessionArgsForCall = append(fake.revokeSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeSessionStub
+	fakeReturns := fake.revokeSessionReturns
+	fake.recordInvocation("RevokeSession", []interface{}{arg1, arg2})
+	fake.revokeSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) RevokeSessionCallCount() int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return len(fake.revokeSessionArgsForCall)
+}
+
+func (fake *FakeRepository) RevokeSessionCalls(stub func(context.Context, string) error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = stub
+}
+
+func (fake *FakeRepository) RevokeSessionArgsForCall(i int) (context.Context, string) {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	argsForCall := fake.revokeSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) RevokeSessionReturns(result1 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	fake.revokeSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeSessionReturnsOnCall(i int, result1 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	if fake.revokeSessionReturnsOnCall == nil {
+		fake.revokeSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokens(arg1 context.Context, arg2 user.TokenPurpose, arg3 int) error {
+	fake.revokeSingleUseTokensMutex.Lock()
+	ret, specificReturn := fake.revokeSingleUseTokensReturnsOnCall[len(fake.revokeSingleUseTokensArgsForCall)]
+	fake.revokeSingleUseTokensArgsForCall = append(fake.revokeSingleUseTokensArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeSingleUseTokensStub
+	fakeReturns := fake.revokeSingleUseTokensReturns
+	fake.recordInvocation("RevokeSingleUseTokens", []interface{}{arg1, arg2, arg3})
+	fake.revokeSingleUseTokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokensCallCount() int {
+	fake.revokeSingleUseTokensMutex.RLock()
+	defer fake.revokeSingleUseTokensMutex.RUnlock()
+	return len(fake.revokeSingleUseTokensArgsForCall)
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokensCalls(stub func(context.Context, user.TokenPurpose, int) error) {
+	fake.revokeSingleUseTokensMutex.Lock()
+	defer fake.revokeSingleUseTokensMutex.Unlock()
+	fake.RevokeSingleUseTokensStub = stub
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokensArgsForCall(i int) (context.Context, user.TokenPurpose, int) {
+	fake.revokeSingleUseTokensMutex.RLock()
+	defer fake.revokeSingleUseTokensMutex.RUnlock()
+	argsForCall := fake.revokeSingleUseTokensArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokensReturns(result1 error) {
+	fake.revokeSingleUseTokensMutex.Lock()
+	defer fake.revokeSingleUseTokensMutex.Unlock()
+	fake.RevokeSingleUseTokensStub = nil
+	fake.revokeSingleUseTokensReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeSingleUseTokensReturnsOnCall(i int, result1 error) {
+	fake.revokeSingleUseTokensMutex.Lock()
+	defer fake.revokeSingleUseTokensMutex.Unlock()
+	fake.RevokeSingleUseTokensStub = nil
+	if fake.revokeSingleUseTokensReturnsOnCall == nil {
+		fake.revokeSingleUseTokensReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeSingleUseTokensReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeTokenFamily(arg1 context.Context, arg2 string) error {
+	fake.revokeTokenFamilyMutex.Lock()
+	ret, specificReturn := fake.revokeTokenFamilyReturnsOnCall[len(fake.revokeTokenFamilyArgsForCall)]
+	fake.revokeTokenFamilyArgsForCall = append(fake.revokeTokenFamilyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeTokenFamilyStub
+	fakeReturns := fake.revokeTokenFamilyReturns
+	fake.recordInvocation("RevokeTokenFamily", []interface{}{arg1, arg2})
+	fake.revokeTokenFamilyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) RevokeTokenFamilyCallCount() int {
+	fake.revokeTokenFamilyMutex.RLock()
+	defer fake.revokeTokenFamilyMutex.RUnlock()
+	return len(fake.revokeTokenFamilyArgsForCall)
+}
+
+func (fake *FakeRepository) RevokeTokenFamilyCalls(stub func(context.Context, string) error) {
+	fake.revokeTokenFamilyMutex.Lock()
+	defer fake.revokeTokenFamilyMutex.Unlock()
+	fake.RevokeTokenFamilyStub = stub
+}
+
+func (fake *FakeRepository) RevokeTokenFamilyArgsForCall(i int) (context.Context, string) {
+	fake.revokeTokenFamilyMutex.RLock()
+	defer fake.revokeTokenFamilyMutex.RUnlock()
+	argsForCall := fake.revokeTokenFamilyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) RevokeTokenFamilyReturns(result1 error) {
+	fake.revokeTokenFamilyMutex.Lock()
+	defer fake.revokeTokenFamilyMutex.Unlock()
+	fake.RevokeTokenFamilyStub = nil
+	fake.revokeTokenFamilyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) RevokeTokenFamilyReturnsOnCall(i int, result1 error) {
+	fake.revokeTokenFamilyMutex.Lock()
+	defer fake.revokeTokenFamilyMutex.Unlock()
+	fake.RevokeTokenFamilyStub = nil
+	if fake.revokeTokenFamilyReturnsOnCall == nil {
+		fake.revokeTokenFamilyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeTokenFamilyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveAuthEvent(arg1 context.Context, arg2 user.AuthEvent) error {
+	fake.saveAuthEventMutex.Lock()
+	ret, specificReturn := fake.saveAuthEventReturnsOnCall[len(fake.saveAuthEventArgsForCall)]
+	fake.saveAuthEventArgsForCall = append(fake.saveAuthEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.AuthEvent
+	}{arg1, arg2})
+	stub := fake.SaveAuthEventStub
+	fakeReturns := fake.saveAuthEventReturns
+	fake.recordInvocation("SaveAuthEvent", []interface{}{arg1, arg2})
+	fake.saveAuthEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveAuthEventCallCount() int {
+	fake.saveAuthEventMutex.RLock()
+	defer fake.saveAuthEventMutex.RUnlock()
+	return len(fake.saveAuthEventArgsForCall)
+}
+
+func (fake *FakeRepository) SaveAuthEventCalls(stub func(context.Context, user.AuthEvent) error) {
+	fake.saveAuthEventMutex.Lock()
+	defer fake.saveAuthEventMutex.Unlock()
+	fake.SaveAuthEventStub = stub
+}
+
+func (fake *FakeRepository) SaveAuthEventArgsForCall(i int) (context.Context, user.AuthEvent) {
+	fake.saveAuthEventMutex.RLock()
+	defer fake.saveAuthEventMutex.RUnlock()
+	argsForCall := fake.saveAuthEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) SaveAuthEventReturns(result1 error) {
+	fake.saveAuthEventMutex.Lock()
+	defer fake.saveAuthEventMutex.Unlock()
+	fake.SaveAuthEventStub = nil
+	fake.saveAuthEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveAuthEventReturnsOnCall(i int, result1 error) {
+	fake.saveAuthEventMutex.Lock()
+	defer fake.saveAuthEventMutex.Unlock()
+	fake.SaveAuthEventStub = nil
+	if fake.saveAuthEventReturnsOnCall == nil {
+		fake.saveAuthEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveAuthEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveEmailChange(arg1 context.Context, arg2 string, arg3 user.EmailChange, arg4 time.Duration) error {
+	fake.saveEmailChangeMutex.Lock()
+	ret, specificReturn := fake.saveEmailChangeReturnsOnCall[len(fake.saveEmailChangeArgsForCall)]
+	fake.saveEmailChangeArgsForCall = append(fake.saveEmailChangeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.EmailChange
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveEmailChangeStub
+	fakeReturns := fake.saveEmailChangeReturns
+	fake.recordInvocation("SaveEmailChange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveEmailChangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveEmailChangeCallCount() int {
+	fake.saveEmailChangeMutex.RLock()
+	defer fake.saveEmailChangeMutex.RUnlock()
+	return len(fake.saveEmailChangeArgsForCall)
+}
+
+func (fake *FakeRepository) SaveEmailChangeCalls(stub func(context.Context, string, user.EmailChange, time.Duration) error) {
+	fake.saveEmailChangeMutex.Lock()
+	defer fake.saveEmailChangeMutex.Unlock()
+	fake.SaveEmailChangeStub = stub
+}
+
+func (fake *FakeRepository) SaveEmailChangeArgsForCall(i int) (context.Context, string, user.EmailChange, time.Duration) {
+	fake.saveEmailChangeMutex.RLock()
+	defer fake.saveEmailChangeMutex.RUnlock()
+	argsForCall := fake.saveEmailChangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) SaveEmailChangeReturns(result1 error) {
+	fake.saveEmailChangeMutex.Lock()
+	defer fake.saveEmailChangeMutex.Unlock()
+	fake.SaveEmailChangeStub = nil
+	fake.saveEmailChangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveEmailChangeReturnsOnCall(i int, result1 error) {
+	fake.saveEmailChangeMutex.Lock()
+	defer fake.saveEmailChangeMutex.Unlock()
+	fake.SaveEmailChangeStub = nil
+	if fake.saveEmailChangeReturnsOnCall == nil {
+		fake.saveEmailChangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEmailChangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveIdentity(arg1 context.Context, arg2 user.UserIdentity) error {
+	fake.saveIdentityMutex.Lock()
+	ret, specificReturn := fake.saveIdentityReturnsOnCall[len(fake.saveIdentityArgsForCall)]
+	fake.saveIdentityArgsForCall = append(fake.saveIdentityArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.UserIdentity
+	}{arg1, arg2})
+	stub := fake.SaveIdentityStub
+	fakeReturns := fake.saveIdentityReturns
+	fake.recordInvocation("SaveIdentity", []interface{}{arg1, arg2})
+	fake.saveIdentityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveIdentityCallCount() int {
+	fake.saveIdentityMutex.RLock()
+	defer fake.saveIdentityMutex.RUnlock()
+	return len(fake.saveIdentityArgsForCall)
+}
+
+func (fake *FakeRepository) SaveIdentityCalls(stub func(context.Context, user.UserIdentity) error) {
+	fake.saveIdentityMutex.Lock()
+	defer fake.saveIdentityMutex.Unlock()
+	fake.SaveIdentityStub = stub
+}
+
+func (fake *FakeRepository) SaveIdentityArgsForCall(i int) (context.Context, user.UserIdentity) {
+	fake.saveIdentityMutex.RLock()
+	defer fake.saveIdentityMutex.RUnlock()
+	argsForCall := fake.saveIdentityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) SaveIdentityReturns(result1 error) {
+	fake.saveIdentityMutex.Lock()
+	defer fake.saveIdentityMutex.Unlock()
+	fake.SaveIdentityStub = nil
+	fake.saveIdentityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveIdentityReturnsOnCall(i int, result1 error) {
+	fake.saveIdentityMutex.Lock()
+	defer fake.saveIdentityMutex.Unlock()
+	fake.SaveIdentityStub = nil
+	if fake.saveIdentityReturnsOnCall == nil {
+		fake.saveIdentityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveIdentityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveOIDCState(arg1 context.Context, arg2 string, arg3 user.OIDCState, arg4 time.Duration) error {
+	fake.saveOIDCStateMutex.Lock()
+	ret, specificReturn := fake.saveOIDCStateReturnsOnCall[len(fake.saveOIDCStateArgsForCall)]
+	fake.saveOIDCStateArgsForCall = append(fake.saveOIDCStateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.OIDCState
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveOIDCStateStub
+	fakeReturns := fake.saveOIDCStateReturns
+	fake.recordInvocation("SaveOIDCState", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveOIDCStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveOIDCStateCallCount() int {
+	fake.saveOIDCStateMutex.RLock()
+	defer fake.saveOIDCStateMutex.RUnlock()
+	return len(fake.saveOIDCStateArgsForCall)
+}
+
+func (fake *FakeRepository) SaveOIDCStateCalls(stub func(context.Context, string, user.OIDCState, time.Duration) error) {
+	fake.saveOIDCStateMutex.Lock()
+	defer fake.saveOIDCStateMutex.Unlock()
+	fake.SaveOIDCStateStub = stub
+}
+
+func (fake *FakeRepository) SaveOIDCStateArgsForCall(i int) (context.Context, string, user.OIDCState, time.Duration) {
+	fake.saveOIDCStateMutex.RLock()
+	defer fake.saveOIDCStateMutex.RUnlock()
+	argsForCall := fake.saveOIDCStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) SaveOIDCStateReturns(result1 error) {
+	fake.saveOIDCStateMutex.Lock()
+	defer fake.saveOIDCStateMutex.Unlock()
+	fake.SaveOIDCStateStub = nil
+	fake.saveOIDCStateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveOIDCStateReturnsOnCall(i int, result1 error) {
+	fake.saveOIDCStateMutex.Lock()
+	defer fake.saveOIDCStateMutex.Unlock()
+	fake.SaveOIDCStateStub = nil
+	if fake.saveOIDCStateReturnsOnCall == nil {
+		fake.saveOIDCStateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOIDCStateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SavePhoneOTP(arg1 context.Context, arg2 user.TokenPurpose, arg3 string, arg4 user.PhoneOTP, arg5 time.Duration) error {
+	fake.savePhoneOTPMutex.Lock()
+	ret, specificReturn := fake.savePhoneOTPReturnsOnCall[len(fake.savePhoneOTPArgsForCall)]
+	fake.savePhoneOTPArgsForCall = append(fake.savePhoneOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+		arg4 user.PhoneOTP
+		arg5 time.Duration
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SavePhoneOTPStub
+	fakeReturns := fake.savePhoneOTPReturns
+	fake.recordInvocation("SavePhoneOTP", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.savePhoneOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SavePhoneOTPCallCount() int {
+	fake.savePhoneOTPMutex.RLock()
+	defer fake.savePhoneOTPMutex.RUnlock()
+	return len(fake.savePhoneOTPArgsForCall)
+}
+
+func (fake *FakeRepository) SavePhoneOTPCalls(stub func(context.Context, user.TokenPurpose, string, user.PhoneOTP, time.Duration) error) {
+	fake.savePhoneOTPMutex.Lock()
+	defer fake.savePhoneOTPMutex.Unlock()
+	fake.SavePhoneOTPStub = stub
+}
+
+func (fake *FakeRepository) SavePhoneOTPArgsForCall(i int) (context.Context, user.TokenPurpose, string, user.PhoneOTP, time.Duration) {
+	fake.savePhoneOTPMutex.RLock()
+	defer fake.savePhoneOTPMutex.RUnlock()
+	argsForCall := fake.savePhoneOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRepository) SavePhoneOTPReturns(result1 error) {
+	fake.savePhoneOTPMutex.Lock()
+	defer fake.savePhoneOTPMutex.Unlock()
+	fake.SavePhoneOTPStub = nil
+	fake.savePhoneOTPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SavePhoneOTPReturnsOnCall(i int, result1 error) {
+	fake.savePhoneOTPMutex.Lock()
+	defer fake.savePhoneOTPMutex.Unlock()
+	fake.SavePhoneOTPStub = nil
+	if fake.savePhoneOTPReturnsOnCall == nil {
+		fake.savePhoneOTPReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.savePhoneOTPReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveRefreshToken(arg1 context.Context, arg2 string, arg3 user.RefreshToken, arg4 time.Duration) error {
+	fake.saveRefreshTokenMutex.Lock()
+	ret, specificReturn := fake.saveRefreshTokenReturnsOnCall[len(fake.saveRefreshTokenArgsForCall)]
+	fake.saveRefreshTokenArgsForCall = append(fake.saveRefreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 user.RefreshToken
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveRefreshTokenStub
+	fakeReturns := fake.saveRefreshTokenReturns
+	fake.recordInvocation("SaveRefreshToken", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveRefreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveRefreshTokenCallCount() int {
+	fake.saveRefreshTokenMutex.RLock()
+	defer fake.saveRefreshTokenMutex.RUnlock()
+	return len(fake.saveRefreshTokenArgsForCall)
+}
+
+func (fake *FakeRepository) SaveRefreshTokenCalls(stub func(context.Context, string, user.RefreshToken, time.Duration) error) {
+	fake.saveRefreshTokenMutex.Lock()
+	defer fake.saveRefreshTokenMutex.Unlock()
+	fake.SaveRefreshTokenStub = stub
+}
+
+func (fake *FakeRepository) SaveRefreshTokenArgsForCall(i int) (context.Context, string, user.RefreshToken, time.Duration) {
+	fake.saveRefreshTokenMutex.RLock()
+	defer fake.saveRefreshTokenMutex.RUnlock()
+	argsForCall := fake.saveRefreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) SaveRefreshTokenReturns(result1 error) {
+	fake.saveRefreshTokenMutex.Lock()
+	defer fake.saveRefreshTokenMutex.Unlock()
+	fake.SaveRefreshTokenStub = nil
+	fake.saveRefreshTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveRefreshTokenReturnsOnCall(i int, result1 error) {
+	fake.saveRefreshTokenMutex.Lock()
+	defer fake.saveRefreshTokenMutex.Unlock()
+	fake.SaveRefreshTokenStub = nil
+	if fake.saveRefreshTokenReturnsOnCall == nil {
+		fake.saveRefreshTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveRefreshTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveSession(arg1 context.Context, arg2 user.Session) error {
+	fake.saveSessionMutex.Lock()
+	ret, specificReturn := fake.saveSessionReturnsOnCall[len(fake.saveSessionArgsForCall)]
+	fake.saveSessionArgsForCall = append(fake.saveSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.Session
+	}{arg1, arg2})
+	stub := fake.SaveSessionStub
+	fakeReturns := fake.saveSessionReturns
+	fake.recordInvocation("SaveSession", []interface{}{arg1, arg2})
+	fake.saveSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveSessionCallCount() int {
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	return len(fake.saveSessionArgsForCall)
+}
+
+func (fake *FakeRepository) SaveSessionCalls(stub func(context.Context, user.Session) error) {
+	fake.saveSessionMutex.Lock()
+	defer fake.saveSessionMutex.Unlock()
+	fake.SaveSessionStub = stub
+}
+
+func (fake *FakeRepository) SaveSessionArgsForCall(i int) (context.Context, user.Session) {
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	argsForCall := fake.saveSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) SaveSessionReturns(result1 error) {
+	fake.saveSessionMutex.Lock()
+	defer fake.saveSessionMutex.Unlock()
+	fake.SaveSessionStub = nil
+	fake.saveSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveSessionReturnsOnCall(i int, result1 error) {
+	fake.saveSessionMutex.Lock()
+	defer fake.saveSessionMutex.Unlock()
+	fake.SaveSessionStub = nil
+	if fake.saveSessionReturnsOnCall == nil {
+		fake.saveSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveSingleUseToken(arg1 context.Context, arg2 user.TokenPurpose, arg3 string, arg4 int, arg5 time.Duration) error {
+	fake.saveSingleUseTokenMutex.Lock()
+	ret, specificReturn := fake.saveSingleUseTokenReturnsOnCall[len(fake.saveSingleUseTokenArgsForCall)]
+	fake.saveSingleUseTokenArgsForCall = append(fake.saveSingleUseTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+		arg4 int
+		arg5 time.Duration
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SaveSingleUseTokenStub
+	fakeReturns := fake.saveSingleUseTokenReturns
+	fake.recordInvocation("SaveSingleUseToken", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.saveSingleUseTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveSingleUseTokenCallCount() int {
+	fake.saveSingleUseTokenMutex.RLock()
+	defer fake.saveSingleUseTokenMutex.RUnlock()
+	return len(fake.saveSingleUseTokenArgsForCall)
+}
+
+func (fake *FakeRepository) SaveSingleUseTokenCalls(stub func(context.Context, user.TokenPurpose, string, int, time.Duration) error) {
+	fake.saveSingleUseTokenMutex.Lock()
+	defer fake.saveSingleUseTokenMutex.Unlock()
+	fake.SaveSingleUseTokenStub = stub
+}
+
+func (fake *FakeRepository) SaveSingleUseTokenArgsForCall(i int) (context.Context, user.TokenPurpose, string, int, time.Duration) {
+	fake.saveSingleUseTokenMutex.RLock()
+	defer fake.saveSingleUseTokenMutex.RUnlock()
+	argsForCall := fake.saveSingleUseTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRepository) SaveSingleUseTokenReturns(result1 error) {
+	fake.saveSingleUseTokenMutex.Lock()
+	defer fake.saveSingleUseTokenMutex.Unlock()
+	fake.SaveSingleUseTokenStub = nil
+	fake.saveSingleUseTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveSingleUseTokenReturnsOnCall(i int, result1 error) {
+	fake.saveSingleUseTokenMutex.Lock()
+	defer fake.saveSingleUseTokenMutex.Unlock()
+	fake.SaveSingleUseTokenStub = nil
+	if fake.saveSingleUseTokenReturnsOnCall == nil {
+		fake.saveSingleUseTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveSingleUseTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveTokenFamily(arg1 context.Context, arg2 string, arg3 int, arg4 time.Duration) error {
+	fake.saveTokenFamilyMutex.Lock()
+	ret, specificReturn := fake.saveTokenFamilyReturnsOnCall[len(fake.saveTokenFamilyArgsForCall)]
+	fake.saveTokenFamilyArgsForCall = append(fake.saveTokenFamilyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveTokenFamilyStub
+	fakeReturns := fake.saveTokenFamilyReturns
+	fake.recordInvocation("SaveTokenFamily", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveTokenFamilyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SaveTokenFamilyCallCount() int {
+	fake.saveTokenFamilyMutex.RLock()
+	defer fake.saveTokenFamilyMutex.RUnlock()
+	return len(fake.saveTokenFamilyArgsForCall)
+}
+
+func (fake *FakeRepository) SaveTokenFamilyCalls(stub func(context.Context, string, int, time.Duration) error) {
+	fake.saveTokenFamilyMutex.Lock()
+	defer fake.saveTokenFamilyMutex.Unlock()
+	fake.SaveTokenFamilyStub = stub
+}
+
+func (fake *FakeRepository) SaveTokenFamilyArgsForCall(i int) (context.Context, string, int, time.Duration) {
+	fake.saveTokenFamilyMutex.RLock()
+	defer fake.saveTokenFamilyMutex.RUnlock()
+	argsForCall := fake.saveTokenFamilyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) SaveTokenFamilyReturns(result1 error) {
+	fake.saveTokenFamilyMutex.Lock()
+	defer fake.saveTokenFamilyMutex.Unlock()
+	fake.SaveTokenFamilyStub = nil
+	fake.saveTokenFamilyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveTokenFamilyReturnsOnCall(i int, result1 error) {
+	fake.saveTokenFamilyMutex.Lock()
+	defer fake.saveTokenFamilyMutex.Unlock()
+	fake.SaveTokenFamilyStub = nil
+	if fake.saveTokenFamilyReturnsOnCall == nil {
+		fake.saveTokenFamilyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTokenFamilyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateEmail(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.updateEmailMutex.Lock()
+	ret, specificReturn := fake.updateEmailReturnsOnCall[len(fake.updateEmailArgsForCall)]
+	fake.updateEmailArgsForCall = append(fake.updateEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateEmailStub
+	fakeReturns := fake.updateEmailReturns
+	fake.recordInvocation("UpdateEmail", []interface{}{arg1, arg2, arg3})
+	fake.updateEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateEmailCallCount() int {
+	fake.updateEmailMutex.RLock()
+	defer fake.updateEmailMutex.RUnlock()
+	return len(fake.updateEmailArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateEmailCalls(stub func(context.Context, int, string) error) {
+	fake.updateEmailMutex.Lock()
+	defer fake.updateEmailMutex.Unlock()
+	fake.UpdateEmailStub = stub
+}
+
+func (fake *FakeRepository) UpdateEmailArgsForCall(i int) (context.Context, int, string) {
+	fake.updateEmailMutex.RLock()
+	defer fake.updateEmailMutex.RUnlock()
+	argsForCall := fake.updateEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UpdateEmailReturns(result1 error) {
+	fake.updateEmailMutex.Lock()
+	defer fake.updateEmailMutex.Unlock()
+	fake.UpdateEmailStub = nil
+	fake.updateEmailReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateEmailReturnsOnCall(i int, result1 error) {
+	fake.updateEmailMutex.Lock()
+	defer fake.updateEmailMutex.Unlock()
+	fake.UpdateEmailStub = nil
+	if fake.updateEmailReturnsOnCall == nil {
+		fake.updateEmailReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateEmailReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateEmailVerified(arg1 context.Context, arg2 int) error {
+	fake.updateEmailVerifiedMutex.Lock()
+	ret, specificReturn := fake.updateEmailVerifiedReturnsOnCall[len(fake.updateEmailVerifiedArgsForCall)]
+	fake.updateEmailVerifiedArgsForCall = append(fake.updateEmailVerifiedArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.UpdateEmailVerifiedStub
+	fakeReturns := fake.updateEmailVerifiedReturns
+	fake.recordInvocation("UpdateEmailVerified", []interface{}{arg1, arg2})
+	fake.updateEmailVerifiedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateEmailVerifiedCallCount() int {
+	fake.updateEmailVerifiedMutex.RLock()
+	defer fake.updateEmailVerifiedMutex.RUnlock()
+	return len(fake.updateEmailVerifiedArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateEmailVerifiedCalls(stub func(context.Context, int) error) {
+	fake.updateEmailVerifiedMutex.Lock()
+	defer fake.updateEmailVerifiedMutex.Unlock()
+	fake.UpdateEmailVerifiedStub = stub
+}
+
+func (fake *FakeRepository) UpdateEmailVerifiedArgsForCall(i int) (context.Context, int) {
+	fake.updateEmailVerifiedMutex.RLock()
+	defer fake.updateEmailVerifiedMutex.RUnlock()
+	argsForCall := fake.updateEmailVerifiedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) UpdateEmailVerifiedReturns(result1 error) {
+	fake.updateEmailVerifiedMutex.Lock()
+	defer fake.updateEmailVerifiedMutex.Unlock()
+	fake.UpdateEmailVerifiedStub = nil
+	fake.updateEmailVerifiedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateEmailVerifiedReturnsOnCall(i int, result1 error) {
+	fake.updateEmailVerifiedMutex.Lock()
+	defer fake.updateEmailVerifiedMutex.Unlock()
+	fake.UpdateEmailVerifiedStub = nil
+	if fake.updateEmailVerifiedReturnsOnCall == nil {
+		fake.updateEmailVerifiedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateEmailVerifiedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdatePassword(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.updatePasswordMutex.Lock()
+	ret, specificReturn := fake.updatePasswordReturnsOnCall[len(fake.updatePasswordArgsForCall)]
+	fake.updatePasswordArgsForCall = append(fake.updatePasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UpdatePasswordStub
+	fakeReturns := fake.updatePasswordReturns
+	fake.recordInvocation("UpdatePassword", []interface{}{arg1, arg2, arg3})
+	fake.updatePasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdatePasswordCallCount() int {
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	return len(fake.updatePasswordArgsForCall)
+}
+
+func (fake *FakeRepository) UpdatePasswordCalls(stub func(context.Context, int, string) error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = stub
+}
+
+func (fake *FakeRepository) UpdatePasswordArgsForCall(i int) (context.Context, int, string) {
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	argsForCall := fake.updatePasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UpdatePasswordReturns(result1 error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = nil
+	fake.updatePasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdatePasswordReturnsOnCall(i int, result1 error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = nil
+	if fake.updatePasswordReturnsOnCall == nil {
+		fake.updatePasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdatePhoneVerified(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.updatePhoneVerifiedMutex.Lock()
+	ret, specificReturn := fake.updatePhoneVerifiedReturnsOnCall[len(fake.updatePhoneVerifiedArgsForCall)]
+	fake.updatePhoneVerifiedArgsForCall = append(fake.updatePhoneVerifiedArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UpdatePhoneVerifiedStub
+	fakeReturns := fake.updatePhoneVerifiedReturns
+	fake.recordInvocation("UpdatePhoneVerified", []interface{}{arg1, arg2, arg3})
+	fake.updatePhoneVerifiedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdatePhoneVerifiedCallCount() int {
+	fake.updatePhoneVerifiedMutex.RLock()
+	defer fake.updatePhoneVerifiedMutex.RUnlock()
+	return len(fake.updatePhoneVerifiedArgsForCall)
+}
+
+func (fake *FakeRepository) UpdatePhoneVerifiedCalls(stub func(context.Context, int, string) error) {
+	fake.updatePhoneVerifiedMutex.Lock()
+	defer fake.updatePhoneVerifiedMutex.Unlock()
+	fake.UpdatePhoneVerifiedStub = stub
+}
+
+func (fake *FakeRepository) UpdatePhoneVerifiedArgsForCall(i int) (context.Context, int, string) {
+	fake.updatePhoneVerifiedMutex.RLock()
+	defer fake.updatePhoneVerifiedMutex.RUnlock()
+	argsForCall := fake.updatePhoneVerifiedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UpdatePhoneVerifiedReturns(result1 error) {
+	fake.updatePhoneVerifiedMutex.Lock()
+	defer fake.updatePhoneVerifiedMutex.Unlock()
+	fake.UpdatePhoneVerifiedStub = nil
+	fake.updatePhoneVerifiedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdatePhoneVerifiedReturnsOnCall(i int, result1 error) {
+	fake.updatePhoneVerifiedMutex.Lock()
+	defer fake.updatePhoneVerifiedMutex.Unlock()
+	fake.UpdatePhoneVerifiedStub = nil
+	if fake.updatePhoneVerifiedReturnsOnCall == nil {
+		fake.updatePhoneVerifiedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePhoneVerifiedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateProfile(arg1 context.Context, arg2 int, arg3 string, arg4 string, arg5 *time.Time) error {
+	fake.updateProfileMutex.Lock()
+	ret, specificReturn := fake.updateProfileReturnsOnCall[len(fake.updateProfileArgsForCall)]
+	fake.updateProfileArgsForCall = append(fake.updateProfileArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 string
+		arg5 *time.Time
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UpdateProfileStub
+	fakeReturns := fake.updateProfileReturns
+	fake.recordInvocation("UpdateProfile", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.updateProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateProfileCallCount() int {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	return len(fake.updateProfileArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateProfileCalls(stub func(context.Context, int, string, string, *time.Time) error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = stub
+}
+
+func (fake *FakeRepository) UpdateProfileArgsForCall(i int) (context.Context, int, string, string, *time.Time) {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	argsForCall := fake.updateProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRepository) UpdateProfileReturns(result1 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	fake.updateProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateProfileReturnsOnCall(i int, result1 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	if fake.updateProfileReturnsOnCall == nil {
+		fake.updateProfileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateProfileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeen(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.updateSessionLastSeenMutex.Lock()
+	ret, specificReturn := fake.updateSessionLastSeenReturnsOnCall[len(fake.updateSessionLastSeenArgsForCall)]
+	fake.updateSessionLastSeenArgsForCall = append(fake.updateSessionLastSeenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateSessionLastSeenStub
+	fakeReturns := fake.updateSessionLastSeenReturns
+	fake.recordInvocation("UpdateSessionLastSeen", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateSessionLastSeenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
 	}
-	findUserByEmailReturnsOnCall map[int]struct {
-		result1 user.User
-		result2 error
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeenCallCount() int {
+	fake.updateSessionLastSeenMutex.RLock()
+	defer fake.updateSessionLastSeenMutex.RUnlock()
+	return len(fake.updateSessionLastSeenArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeenCalls(stub func(context.Context, string, string, string) error) {
+	fake.updateSessionLastSeenMutex.Lock()
+	defer fake.updateSessionLastSeenMutex.Unlock()
+	fake.UpdateSessionLastSeenStub = stub
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeenArgsForCall(i int) (context.Context, string, string, string) {
+	fake.updateSessionLastSeenMutex.RLock()
+	defer fake.updateSessionLastSeenMutex.RUnlock()
+	argsForCall := fake.updateSessionLastSeenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeenReturns(result1 error) {
+	fake.updateSessionLastSeenMutex.Lock()
+	defer fake.updateSessionLastSeenMutex.Unlock()
+	fake.UpdateSessionLastSeenStub = nil
+	fake.updateSessionLastSeenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateSessionLastSeenReturnsOnCall(i int, result1 error) {
+	fake.updateSessionLastSeenMutex.Lock()
+	defer fake.updateSessionLastSeenMutex.Unlock()
+	fake.UpdateSessionLastSeenStub = nil
+	if fake.updateSessionLastSeenReturnsOnCall == nil {
+		fake.updateSessionLastSeenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
 	}
-	RegisterNewUserStub        func(context.Context, user.User) (user.User, error)
-	registerNewUserMutex       sync.RWMutex
-	registerNewUserArgsForCall []struct {
+	fake.updateSessionLastSeenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateStatus(arg1 context.Context, arg2 int, arg3 bool) error {
+	fake.updateStatusMutex.Lock()
+	ret, specificReturn := fake.updateStatusReturnsOnCall[len(fake.updateStatusArgsForCall)]
+	fake.updateStatusArgsForCall = append(fake.updateStatusArgsForCall, struct {
 		arg1 context.Context
-		arg2 user.User
+		arg2 int
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateStatusStub
+	fakeReturns := fake.updateStatusReturns
+	fake.recordInvocation("UpdateStatus", []interface{}{arg1, arg2, arg3})
+	fake.updateStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
-	registerNewUserReturns struct {
-		result1 user.User
-		result2 error
+	if specificReturn {
+		return ret.result1
 	}
-	registerNewUserReturnsOnCall map[int]struct {
-		result1 user.User
-		result2 error
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateStatusCallCount() int {
+	fake.updateStatusMutex.RLock()
+	defer fake.updateStatusMutex.RUnlock()
+	return len(fake.updateStatusArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateStatusCalls(stub func(context.Context, int, bool) error) {
+	fake.updateStatusMutex.Lock()
+	defer fake.updateStatusMutex.Unlock()
+	fake.UpdateStatusStub = stub
+}
+
+func (fake *FakeRepository) UpdateStatusArgsForCall(i int) (context.Context, int, bool) {
+	fake.updateStatusMutex.RLock()
+	defer fake.updateStatusMutex.RUnlock()
+	argsForCall := fake.updateStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UpdateStatusReturns(result1 error) {
+	fake.updateStatusMutex.Lock()
+	defer fake.updateStatusMutex.Unlock()
+	fake.UpdateStatusStub = nil
+	fake.updateStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateStatusReturnsOnCall(i int, result1 error) {
+	fake.updateStatusMutex.Lock()
+	defer fake.updateStatusMutex.Unlock()
+	fake.UpdateStatusStub = nil
+	if fake.updateStatusReturnsOnCall == nil {
+		fake.updateStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	fake.updateStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeRepository) FindUserByEmail(arg1 context.Context, arg2 string) (user.User, error) {
-	fake.findUserByEmailMutex.Lock()
-	ret, specificReturn := fake.findUserByEmailReturnsOnCall[len(fake.findUserByEmailArgsForCall)]
-	fake.findUserByEmailArgsForCall = append(fake.findUserByEmailArgsForCall, struct {
+func (fake *FakeRepository) UpdateTOTP(arg1 context.Context, arg2 int, arg3 string, arg4 *time.Time) error {
+	fake.updateTOTPMutex.Lock()
+	ret, specificReturn := fake.updateTOTPReturnsOnCall[len(fake.updateTOTPArgsForCall)]
+	fake.updateTOTPArgsForCall = append(fake.updateTOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+		arg4 *time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateTOTPStub
+	fakeReturns := fake.updateTOTPReturns
+	fake.recordInvocation("UpdateTOTP", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateTOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) UpdateTOTPCallCount() int {
+	fake.updateTOTPMutex.RLock()
+	defer fake.updateTOTPMutex.RUnlock()
+	return len(fake.updateTOTPArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateTOTPCalls(stub func(context.Context, int, string, *time.Time) error) {
+	fake.updateTOTPMutex.Lock()
+	defer fake.updateTOTPMutex.Unlock()
+	fake.UpdateTOTPStub = stub
+}
+
+func (fake *FakeRepository) UpdateTOTPArgsForCall(i int) (context.Context, int, string, *time.Time) {
+	fake.updateTOTPMutex.RLock()
+	defer fake.updateTOTPMutex.RUnlock()
+	argsForCall := fake.updateTOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRepository) UpdateTOTPReturns(result1 error) {
+	fake.updateTOTPMutex.Lock()
+	defer fake.updateTOTPMutex.Unlock()
+	fake.UpdateTOTPStub = nil
+	fake.updateTOTPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UpdateTOTPReturnsOnCall(i int, result1 error) {
+	fake.updateTOTPMutex.Lock()
+	defer fake.updateTOTPMutex.Unlock()
+	fake.UpdateTOTPStub = nil
+	if fake.updateTOTPReturnsOnCall == nil {
+		fake.updateTOTPReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTOTPReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) UseEmailChange(arg1 context.Context, arg2 string) (user.EmailChange, error) {
+	fake.useEmailChangeMutex.Lock()
+	ret, specificReturn := fake.useEmailChangeReturnsOnCall[len(fake.useEmailChangeArgsForCall)]
+	fake.useEmailChangeArgsForCall = append(fake.useEmailChangeArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 	}{arg1, arg2})
-	stub := fake.FindUserByEmailStub
-	fakeReturns := fake.findUserByEmailReturns
-	fake.recordInvocation("FindUserByEmail", []interface{}{arg1, arg2})
-	fake.findUserByEmailMutex.Unlock()
+	stub := fake.UseEmailChangeStub
+	fakeReturns := fake.useEmailChangeReturns
+	fake.recordInvocation("UseEmailChange", []interface{}{arg1, arg2})
+	fake.useEmailChangeMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -60,62 +3625,62 @@ func (fake *FakeRepository) FindUserByEmail(arg1 context.Context, arg2 string) (
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeRepository) FindUserByEmailCallCount() int {
-	fake.findUserByEmailMutex.RLock()
-	defer fake.findUserByEmailMutex.RUnlock()
-	return len(fake.findUserByEmailArgsForCall)
+func (fake *FakeRepository) UseEmailChangeCallCount() int {
+	fake.useEmailChangeMutex.RLock()
+	defer fake.useEmailChangeMutex.RUnlock()
+	return len(fake.useEmailChangeArgsForCall)
 }
 
-func (fake *FakeRepository) FindUserByEmailCalls(stub func(context.Context, string) (user.User, error)) {
-	fake.findUserByEmailMutex.Lock()
-	defer fake.findUserByEmailMutex.Unlock()
-	fake.FindUserByEmailStub = stub
+func (fake *FakeRepository) UseEmailChangeCalls(stub func(context.Context, string) (user.EmailChange, error)) {
+	fake.useEmailChangeMutex.Lock()
+	defer fake.useEmailChangeMutex.Unlock()
+	fake.UseEmailChangeStub = stub
 }
 
-func (fake *FakeRepository) FindUserByEmailArgsForCall(i int) (context.Context, string) {
-	fake.findUserByEmailMutex.RLock()
-	defer fake.findUserByEmailMutex.RUnlock()
-	argsForCall := fake.findUserByEmailArgsForCall[i]
+func (fake *FakeRepository) UseEmailChangeArgsForCall(i int) (context.Context, string) {
+	fake.useEmailChangeMutex.RLock()
+	defer fake.useEmailChangeMutex.RUnlock()
+	argsForCall := fake.useEmailChangeArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) FindUserByEmailReturns(result1 user.User, result2 error) {
-	fake.findUserByEmailMutex.Lock()
-	defer fake.findUserByEmailMutex.Unlock()
-	fake.FindUserByEmailStub = nil
-	fake.findUserByEmailReturns = struct {
-		result1 user.User
+func (fake *FakeRepository) UseEmailChangeReturns(result1 user.EmailChange, result2 error) {
+	fake.useEmailChangeMutex.Lock()
+	defer fake.useEmailChangeMutex.Unlock()
+	fake.UseEmailChangeStub = nil
+	fake.useEmailChangeReturns = struct {
+		result1 user.EmailChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) FindUserByEmailReturnsOnCall(i int, result1 user.User, result2 error) {
-	fake.findUserByEmailMutex.Lock()
-	defer fake.findUserByEmailMutex.Unlock()
-	fake.FindUserByEmailStub = nil
-	if fake.findUserByEmailReturnsOnCall == nil {
-		fake.findUserByEmailReturnsOnCall = make(map[int]struct {
-			result1 user.User
+func (fake *FakeRepository) UseEmailChangeReturnsOnCall(i int, result1 user.EmailChange, result2 error) {
+	fake.useEmailChangeMutex.Lock()
+	defer fake.useEmailChangeMutex.Unlock()
+	fake.UseEmailChangeStub = nil
+	if fake.useEmailChangeReturnsOnCall == nil {
+		fake.useEmailChangeReturnsOnCall = make(map[int]struct {
+			result1 user.EmailChange
 			result2 error
 		})
 	}
-	fake.findUserByEmailReturnsOnCall[i] = struct {
-		result1 user.User
+	fake.useEmailChangeReturnsOnCall[i] = struct {
+		result1 user.EmailChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) RegisterNewUser(arg1 context.Context, arg2 user.User) (user.User, error) {
-	fake.registerNewUserMutex.Lock()
-	ret, specificReturn := fake.registerNewUserReturnsOnCall[len(fake.registerNewUserArgsForCall)]
-	fake.registerNewUserArgsForCall = append(fake.registerNewUserArgsForCall, struct {
+func (fake *FakeRepository) UseOIDCState(arg1 context.Context, arg2 string) (user.OIDCState, error) {
+	fake.useOIDCStateMutex.Lock()
+	ret, specificReturn := fake.useOIDCStateReturnsOnCall[len(fake.useOIDCStateArgsForCall)]
+	fake.useOIDCStateArgsForCall = append(fake.useOIDCStateArgsForCall, struct {
 		arg1 context.Context
-		arg2 user.User
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.RegisterNewUserStub
-	fakeReturns := fake.registerNewUserReturns
-	fake.recordInvocation("RegisterNewUser", []interface{}{arg1, arg2})
-	fake.registerNewUserMutex.Unlock()
+	stub := fake.UseOIDCStateStub
+	fakeReturns := fake.useOIDCStateReturns
+	fake.recordInvocation("UseOIDCState", []interface{}{arg1, arg2})
+	fake.useOIDCStateMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -125,47 +3690,245 @@ func (fake *FakeRepository) RegisterNewUser(arg1 context.Context, arg2 user.User
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeRepository) RegisterNewUserCallCount() int {
-	fake.registerNewUserMutex.RLock()
-	defer fake.registerNewUserMutex.RUnlock()
-	return len(fake.registerNewUserArgsForCall)
+func (fake *FakeRepository) UseOIDCStateCallCount() int {
+	fake.useOIDCStateMutex.RLock()
+	defer fake.useOIDCStateMutex.RUnlock()
+	return len(fake.useOIDCStateArgsForCall)
 }
 
-func (fake *FakeRepository) RegisterNewUserCalls(stub func(context.Context, user.User) (user.User, error)) {
-	fake.registerNewUserMutex.Lock()
-	defer fake.registerNewUserMutex.Unlock()
-	fake.RegisterNewUserStub = stub
+func (fake *FakeRepository) UseOIDCStateCalls(stub func(context.Context, string) (user.OIDCState, error)) {
+	fake.useOIDCStateMutex.Lock()
+	defer fake.useOIDCStateMutex.Unlock()
+	fake.UseOIDCStateStub = stub
 }
 
-func (fake *FakeRepository) RegisterNewUserArgsForCall(i int) (context.Context, user.User) {
-	fake.registerNewUserMutex.RLock()
-	defer fake.registerNewUserMutex.RUnlock()
-	argsForCall := fake.registerNewUserArgsForCall[i]
+func (fake *FakeRepository) UseOIDCStateArgsForCall(i int) (context.Context, string) {
+	fake.useOIDCStateMutex.RLock()
+	defer fake.useOIDCStateMutex.RUnlock()
+	argsForCall := fake.useOIDCStateArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) RegisterNewUserReturns(result1 user.User, result2 error) {
-	fake.registerNewUserMutex.Lock()
-	defer fake.registerNewUserMutex.Unlock()
-	fake.RegisterNewUserStub = nil
-	fake.registerNewUserReturns = struct {
-		result1 user.User
+func (fake *FakeRepository) UseOIDCStateReturns(result1 user.OIDCState, result2 error) {
+	fake.useOIDCStateMutex.Lock()
+	defer fake.useOIDCStateMutex.Unlock()
+	fake.UseOIDCStateStub = nil
+	fake.useOIDCStateReturns = struct {
+		result1 user.OIDCState
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) RegisterNewUserReturnsOnCall(i int, result1 user.User, result2 error) {
-	fake.registerNewUserMutex.Lock()
-	defer fake.registerNewUserMutex.Unlock()
-	fake.RegisterNewUserStub = nil
-	if fake.registerNewUserReturnsOnCall == nil {
-		fake.registerNewUserReturnsOnCall = make(map[int]struct {
-			result1 user.User
+func (fake *FakeRepository) UseOIDCStateReturnsOnCall(i int, result1 user.OIDCState, result2 error) {
+	fake.useOIDCStateMutex.Lock()
+	defer fake.useOIDCStateMutex.Unlock()
+	fake.UseOIDCStateStub = nil
+	if fake.useOIDCStateReturnsOnCall == nil {
+		fake.useOIDCStateReturnsOnCall = make(map[int]struct {
+			result1 user.OIDCState
 			result2 error
 		})
 	}
-	fake.registerNewUserReturnsOnCall[i] = struct {
-		result1 user.User
+	fake.useOIDCStateReturnsOnCall[i] = struct {
+		result1 user.OIDCState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseRecoveryCode(arg1 context.Context, arg2 int, arg3 string) (bool, error) {
+	fake.useRecoveryCodeMutex.Lock()
+	ret, specificReturn := fake.useRecoveryCodeReturnsOnCall[len(fake.useRecoveryCodeArgsForCall)]
+	fake.useRecoveryCodeArgsForCall = append(fake.useRecoveryCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UseRecoveryCodeStub
+	fakeReturns := fake.useRecoveryCodeReturns
+	fake.recordInvocation("UseRecoveryCode", []interface{}{arg1, arg2, arg3})
+	fake.useRecoveryCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) UseRecoveryCodeCallCount() int {
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	return len(fake.useRecoveryCodeArgsForCall)
+}
+
+func (fake *FakeRepository) UseRecoveryCodeCalls(stub func(context.Context, int, string) (bool, error)) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = stub
+}
+
+func (fake *FakeRepository) UseRecoveryCodeArgsForCall(i int) (context.Context, int, string) {
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	argsForCall := fake.useRecoveryCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UseRecoveryCodeReturns(result1 bool, result2 error) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = nil
+	fake.useRecoveryCodeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseRecoveryCodeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = nil
+	if fake.useRecoveryCodeReturnsOnCall == nil {
+		fake.useRecoveryCodeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.useRecoveryCodeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseRefreshToken(arg1 context.Context, arg2 string, arg3 time.Duration) (bool, error) {
+	fake.useRefreshTokenMutex.Lock()
+	ret, specificReturn := fake.useRefreshTokenReturnsOnCall[len(fake.useRefreshTokenArgsForCall)]
+	fake.useRefreshTokenArgsForCall = append(fake.useRefreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.UseRefreshTokenStub
+	fakeReturns := fake.useRefreshTokenReturns
+	fake.recordInvocation("UseRefreshToken", []interface{}{arg1, arg2, arg3})
+	fake.useRefreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) UseRefreshTokenCallCount() int {
+	fake.useRefreshTokenMutex.RLock()
+	defer fake.useRefreshTokenMutex.RUnlock()
+	return len(fake.useRefreshTokenArgsForCall)
+}
+
+func (fake *FakeRepository) UseRefreshTokenCalls(stub func(context.Context, string, time.Duration) (bool, error)) {
+	fake.useRefreshTokenMutex.Lock()
+	defer fake.useRefreshTokenMutex.Unlock()
+	fake.UseRefreshTokenStub = stub
+}
+
+func (fake *FakeRepository) UseRefreshTokenArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.useRefreshTokenMutex.RLock()
+	defer fake.useRefreshTokenMutex.RUnlock()
+	argsForCall := fake.useRefreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UseRefreshTokenReturns(result1 bool, result2 error) {
+	fake.useRefreshTokenMutex.Lock()
+	defer fake.useRefreshTokenMutex.Unlock()
+	fake.UseRefreshTokenStub = nil
+	fake.useRefreshTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseRefreshTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.useRefreshTokenMutex.Lock()
+	defer fake.useRefreshTokenMutex.Unlock()
+	fake.UseRefreshTokenStub = nil
+	if fake.useRefreshTokenReturnsOnCall == nil {
+		fake.useRefreshTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.useRefreshTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseSingleUseToken(arg1 context.Context, arg2 user.TokenPurpose, arg3 string) (int, error) {
+	fake.useSingleUseTokenMutex.Lock()
+	ret, specificReturn := fake.useSingleUseTokenReturnsOnCall[len(fake.useSingleUseTokenArgsForCall)]
+	fake.useSingleUseTokenArgsForCall = append(fake.useSingleUseTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.TokenPurpose
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UseSingleUseTokenStub
+	fakeReturns := fake.useSingleUseTokenReturns
+	fake.recordInvocation("UseSingleUseToken", []interface{}{arg1, arg2, arg3})
+	fake.useSingleUseTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) UseSingleUseTokenCallCount() int {
+	fake.useSingleUseTokenMutex.RLock()
+	defer fake.useSingleUseTokenMutex.RUnlock()
+	return len(fake.useSingleUseTokenArgsForCall)
+}
+
+func (fake *FakeRepository) UseSingleUseTokenCalls(stub func(context.Context, user.TokenPurpose, string) (int, error)) {
+	fake.useSingleUseTokenMutex.Lock()
+	defer fake.useSingleUseTokenMutex.Unlock()
+	fake.UseSingleUseTokenStub = stub
+}
+
+func (fake *FakeRepository) UseSingleUseTokenArgsForCall(i int) (context.Context, user.TokenPurpose, string) {
+	fake.useSingleUseTokenMutex.RLock()
+	defer fake.useSingleUseTokenMutex.RUnlock()
+	argsForCall := fake.useSingleUseTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) UseSingleUseTokenReturns(result1 int, result2 error) {
+	fake.useSingleUseTokenMutex.Lock()
+	defer fake.useSingleUseTokenMutex.Unlock()
+	fake.UseSingleUseTokenStub = nil
+	fake.useSingleUseTokenReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UseSingleUseTokenReturnsOnCall(i int, result1 int, result2 error) {
+	fake.useSingleUseTokenMutex.Lock()
+	defer fake.useSingleUseTokenMutex.Unlock()
+	fake.UseSingleUseTokenStub = nil
+	if fake.useSingleUseTokenReturnsOnCall == nil {
+		fake.useSingleUseTokenReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.useSingleUseTokenReturnsOnCall[i] = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
@@ -173,10 +3936,106 @@ func (fake *FakeRepository) RegisterNewUserReturnsOnCall(i int, result1 user.Use
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acquireRateLimitMutex.RLock()
+	defer fake.acquireRateLimitMutex.RUnlock()
+	fake.clearRateLimitMutex.RLock()
+	defer fake.clearRateLimitMutex.RUnlock()
+	fake.clearRateLimitsMutex.RLock()
+	defer fake.clearRateLimitsMutex.RUnlock()
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	fake.deletePhoneOTPMutex.RLock()
+	defer fake.deletePhoneOTPMutex.RUnlock()
+	fake.findIdentityMutex.RLock()
+	defer fake.findIdentityMutex.RUnlock()
 	fake.findUserByEmailMutex.RLock()
 	defer fake.findUserByEmailMutex.RUnlock()
+	fake.findUserByIDMutex.RLock()
+	defer fake.findUserByIDMutex.RUnlock()
+	fake.findUserByVerifiedPhoneMutex.RLock()
+	defer fake.findUserByVerifiedPhoneMutex.RUnlock()
+	fake.getActiveSessionsMutex.RLock()
+	defer fake.getActiveSessionsMutex.RUnlock()
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	fake.getPhoneOTPMutex.RLock()
+	defer fake.getPhoneOTPMutex.RUnlock()
+	fake.getRateLimitMutex.RLock()
+	defer fake.getRateLimitMutex.RUnlock()
+	fake.getRefreshTokenMutex.RLock()
+	defer fake.getRefreshTokenMutex.RUnlock()
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	fake.getSingleUseTokenMutex.RLock()
+	defer fake.getSingleUseTokenMutex.RUnlock()
+	fake.incrementCounterMutex.RLock()
+	defer fake.incrementCounterMutex.RUnlock()
+	fake.isTokenFamilyActiveMutex.RLock()
+	defer fake.isTokenFamilyActiveMutex.RUnlock()
+	fake.registerIdentityUserMutex.RLock()
+	defer fake.registerIdentityUserMutex.RUnlock()
 	fake.registerNewUserMutex.RLock()
 	defer fake.registerNewUserMutex.RUnlock()
+	fake.rehashPasswordMutex.RLock()
+	defer fake.rehashPasswordMutex.RUnlock()
+	fake.replaceRecoveryCodesMutex.RLock()
+	defer fake.replaceRecoveryCodesMutex.RUnlock()
+	fake.resetCounterMutex.RLock()
+	defer fake.resetCounterMutex.RUnlock()
+	fake.resetCountersMutex.RLock()
+	defer fake.resetCountersMutex.RUnlock()
+	fake.revokeAPIKeysMutex.RLock()
+	defer fake.revokeAPIKeysMutex.RUnlock()
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	fake.revokeSingleUseTokensMutex.RLock()
+	defer fake.revokeSingleUseTokensMutex.RUnlock()
+	fake.revokeTokenFamilyMutex.RLock()
+	defer fake.revokeTokenFamilyMutex.RUnlock()
+	fake.saveAuthEventMutex.RLock()
+	defer fake.saveAuthEventMutex.RUnlock()
+	fake.saveEmailChangeMutex.RLock()
+	defer fake.saveEmailChangeMutex.RUnlock()
+	fake.saveIdentityMutex.RLock()
+	defer fake.saveIdentityMutex.RUnlock()
+	fake.saveOIDCStateMutex.RLock()
+	defer fake.saveOIDCStateMutex.RUnlock()
+	fake.savePhoneOTPMutex.RLock()
+	defer fake.savePhoneOTPMutex.RUnlock()
+	fake.saveRefreshTokenMutex.RLock()
+	defer fake.saveRefreshTokenMutex.RUnlock()
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	fake.saveSingleUseTokenMutex.RLock()
+	defer fake.saveSingleUseTokenMutex.RUnlock()
+	fake.saveTokenFamilyMutex.RLock()
+	defer fake.saveTokenFamilyMutex.RUnlock()
+	fake.updateEmailMutex.RLock()
+	defer fake.updateEmailMutex.RUnlock()
+	fake.updateEmailVerifiedMutex.RLock()
+	defer fake.updateEmailVerifiedMutex.RUnlock()
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	fake.updatePhoneVerifiedMutex.RLock()
+	defer fake.updatePhoneVerifiedMutex.RUnlock()
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	fake.updateSessionLastSeenMutex.RLock()
+	defer fake.updateSessionLastSeenMutex.RUnlock()
+	fake.updateStatusMutex.RLock()
+	defer fake.updateStatusMutex.RUnlock()
+	fake.updateTOTPMutex.RLock()
+	defer fake.updateTOTPMutex.RUnlock()
+	fake.useEmailChangeMutex.RLock()
+	defer fake.useEmailChangeMutex.RUnlock()
+	fake.useOIDCStateMutex.RLock()
+	defer fake.useOIDCStateMutex.RUnlock()
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	fake.useRefreshTokenMutex.RLock()
+	defer fake.useRefreshTokenMutex.RUnlock()
+	fake.useSingleUseTokenMutex.RLock()
+	defer fake.useSingleUseTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
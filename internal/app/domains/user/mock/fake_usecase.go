@@ -8,6 +8,162 @@ import (
 )
 
 type FakeUsecase struct {
+	AuthorizeOIDCStub        func(context.Context, string) (user.OIDCAuthorizeResponse, error)
+	authorizeOIDCMutex       sync.RWMutex
+	authorizeOIDCArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	authorizeOIDCReturns struct {
+		result1 user.OIDCAuthorizeResponse
+		result2 error
+	}
+	authorizeOIDCReturnsOnCall map[int]struct {
+		result1 user.OIDCAuthorizeResponse
+		result2 error
+	}
+	BlockAccountStub        func(context.Context, int, string) error
+	blockAccountMutex       sync.RWMutex
+	blockAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	blockAccountReturns struct {
+		result1 error
+	}
+	blockAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ChangePasswordStub        func(context.Context, user.ChangePasswordRequest) error
+	changePasswordMutex       sync.RWMutex
+	changePasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ChangePasswordRequest
+	}
+	changePasswordReturns struct {
+		result1 error
+	}
+	changePasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ConfirmTOTPStub        func(context.Context, user.ConfirmTOTPRequest) (user.RecoveryCodesResponse, error)
+	confirmTOTPMutex       sync.RWMutex
+	confirmTOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ConfirmTOTPRequest
+	}
+	confirmTOTPReturns struct {
+		result1 user.RecoveryCodesResponse
+		result2 error
+	}
+	confirmTOTPReturnsOnCall map[int]struct {
+		result1 user.RecoveryCodesResponse
+		result2 error
+	}
+	DeactivateAccountStub        func(context.Context, user.DeactivateAccountRequest) error
+	deactivateAccountMutex       sync.RWMutex
+	deactivateAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.DeactivateAccountRequest
+	}
+	deactivateAccountReturns struct {
+		result1 error
+	}
+	deactivateAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DisableTOTPStub        func(context.Context, user.DisableTOTPRequest) error
+	disableTOTPMutex       sync.RWMutex
+	disableTOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.DisableTOTPRequest
+	}
+	disableTOTPReturns struct {
+		result1 error
+	}
+	disableTOTPReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EndSessionStub        func(context.Context, int) error
+	endSessionMutex       sync.RWMutex
+	endSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	endSessionReturns struct {
+		result1 error
+	}
+	endSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnrollTOTPStub        func(context.Context) (user.EnrollTOTPResponse, error)
+	enrollTOTPMutex       sync.RWMutex
+	enrollTOTPArgsForCall []struct {
+		arg1 context.Context
+	}
+	enrollTOTPReturns struct {
+		result1 user.EnrollTOTPResponse
+		result2 error
+	}
+	enrollTOTPReturnsOnCall map[int]struct {
+		result1 user.EnrollTOTPResponse
+		result2 error
+	}
+	ForgotPasswordStub        func(context.Context, user.ForgotPasswordRequest) error
+	forgotPasswordMutex       sync.RWMutex
+	forgotPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ForgotPasswordRequest
+	}
+	forgotPasswordReturns struct {
+		result1 error
+	}
+	forgotPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetAuthEventsStub        func(context.Context, user.ListRequest) ([]user.AuthEvent, int, error)
+	getAuthEventsMutex       sync.RWMutex
+	getAuthEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ListRequest
+	}
+	getAuthEventsReturns struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}
+	getAuthEventsReturnsOnCall map[int]struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}
+	GetProfileStub        func(context.Context) (user.User, error)
+	getProfileMutex       sync.RWMutex
+	getProfileArgsForCall []struct {
+		arg1 context.Context
+	}
+	getProfileReturns struct {
+		result1 user.User
+		result2 error
+	}
+	getProfileReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	GetSessionsStub        func(context.Context) ([]user.Session, error)
+	getSessionsMutex       sync.RWMutex
+	getSessionsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSessionsReturns struct {
+		result1 []user.Session
+		result2 error
+	}
+	getSessionsReturnsOnCall map[int]struct {
+		result1 []user.Session
+		result2 error
+	}
 	LoginStub        func(context.Context, user.LoginRequest) (user.LoginResponse, error)
 	loginMutex       sync.RWMutex
 	loginArgsForCall []struct {
@@ -22,6 +178,74 @@ type FakeUsecase struct {
 		result1 user.LoginResponse
 		result2 error
 	}
+	LoginOIDCStub        func(context.Context, user.OIDCCallbackRequest) (user.LoginResponse, error)
+	loginOIDCMutex       sync.RWMutex
+	loginOIDCArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.OIDCCallbackRequest
+	}
+	loginOIDCReturns struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	loginOIDCReturnsOnCall map[int]struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	LoginPhoneStub        func(context.Context, user.PhoneLoginRequest) (user.LoginResponse, error)
+	loginPhoneMutex       sync.RWMutex
+	loginPhoneArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.PhoneLoginRequest
+	}
+	loginPhoneReturns struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	loginPhoneReturnsOnCall map[int]struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	LoginTOTPStub        func(context.Context, user.LoginTOTPRequest) (user.LoginResponse, error)
+	loginTOTPMutex       sync.RWMutex
+	loginTOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.LoginTOTPRequest
+	}
+	loginTOTPReturns struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	loginTOTPReturnsOnCall map[int]struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	LogoutStub        func(context.Context, user.LogoutRequest) error
+	logoutMutex       sync.RWMutex
+	logoutArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.LogoutRequest
+	}
+	logoutReturns struct {
+		result1 error
+	}
+	logoutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RefreshTokenStub        func(context.Context, user.RefreshTokenRequest) (user.LoginResponse, error)
+	refreshTokenMutex       sync.RWMutex
+	refreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.RefreshTokenRequest
+	}
+	refreshTokenReturns struct {
+		result1 user.LoginResponse
+		result2 error
+	}
+	refreshTokenReturnsOnCall map[int]struct {
+		result1 user.LoginResponse
+		result2 error
+	}
 	RegisterStub        func(context.Context, user.RegisterRequest) (user.User, error)
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
@@ -36,21 +260,155 @@ type FakeUsecase struct {
 		result1 user.User
 		result2 error
 	}
+	RequestPhoneLoginStub        func(context.Context, user.PhoneLoginOTPRequest) error
+	requestPhoneLoginMutex       sync.RWMutex
+	requestPhoneLoginArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.PhoneLoginOTPRequest
+	}
+	requestPhoneLoginReturns struct {
+		result1 error
+	}
+	requestPhoneLoginReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RequestPhoneVerificationStub        func(context.Context) error
+	requestPhoneVerificationMutex       sync.RWMutex
+	requestPhoneVerificationArgsForCall []struct {
+		arg1 context.Context
+	}
+	requestPhoneVerificationReturns struct {
+		result1 error
+	}
+	requestPhoneVerificationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResendVerificationEmailStub        func(context.Context, user.ResendVerificationRequest) error
+	resendVerificationEmailMutex       sync.RWMutex
+	resendVerificationEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ResendVerificationRequest
+	}
+	resendVerificationEmailReturns struct {
+		result1 error
+	}
+	resendVerificationEmailReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResetPasswordStub        func(context.Context, user.ResetPasswordRequest) error
+	resetPasswordMutex       sync.RWMutex
+	resetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.ResetPasswordRequest
+	}
+	resetPasswordReturns struct {
+		result1 error
+	}
+	resetPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResetTOTPStub        func(context.Context, int) error
+	resetTOTPMutex       sync.RWMutex
+	resetTOTPArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	resetTOTPReturns struct {
+		result1 error
+	}
+	resetTOTPReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeAccessStub        func(context.Context, int) error
+	revokeAccessMutex       sync.RWMutex
+	revokeAccessArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	revokeAccessReturns struct {
+		result1 error
+	}
+	revokeAccessReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnblockAccountStub        func(context.Context, int, string) error
+	unblockAccountMutex       sync.RWMutex
+	unblockAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}
+	unblockAccountReturns struct {
+		result1 error
+	}
+	unblockAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnlockAccountStub        func(context.Context, int) error
+	unlockAccountMutex       sync.RWMutex
+	unlockAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	unlockAccountReturns struct {
+		result1 error
+	}
+	unlockAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateProfileStub        func(context.Context, user.UpdateProfileRequest) (user.User, error)
+	updateProfileMutex       sync.RWMutex
+	updateProfileArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.UpdateProfileRequest
+	}
+	updateProfileReturns struct {
+		result1 user.User
+		result2 error
+	}
+	updateProfileReturnsOnCall map[int]struct {
+		result1 user.User
+		result2 error
+	}
+	VerifyEmailStub        func(context.Context, user.VerifyEmailRequest) error
+	verifyEmailMutex       sync.RWMutex
+	verifyEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.VerifyEmailRequest
+	}
+	verifyEmailReturns struct {
+		result1 error
+	}
+	verifyEmailReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VerifyPhoneStub        func(context.Context, user.VerifyPhoneRequest) error
+	verifyPhoneMutex       sync.RWMutex
+	verifyPhoneArgsForCall []struct {
+		arg1 context.Context
+		arg2 user.VerifyPhoneRequest
+	}
+	verifyPhoneReturns struct {
+		result1 error
+	}
+	verifyPhoneReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeUsecase) Login(arg1 context.Context, arg2 user.LoginRequest) (user.LoginResponse, error) {
-	fake.loginMutex.Lock()
-	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
-	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+func (fake *FakeUsecase) AuthorizeOIDC(arg1 context.Context, arg2 string) (user.OIDCAuthorizeResponse, error) {
+	fake.authorizeOIDCMutex.Lock()
+	ret, specificReturn := fake.authorizeOIDCReturnsOnCall[len(fake.authorizeOIDCArgsForCall)]
+	fake.authorizeOIDCArgsForCall = append(fake.authorizeOIDCArgsForCall, struct {
 		arg1 context.Context
-		arg2 user.LoginRequest
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.LoginStub
-	fakeReturns := fake.loginReturns
-	fake.recordInvocation("Login", []interface{}{arg1, arg2})
-	fake.loginMutex.Unlock()
+	stub := fake.AuthorizeOIDCStub
+	fakeReturns := fake.authorizeOIDCReturns
+	fake.recordInvocation("AuthorizeOIDC", []interface{}{arg1, arg2})
+	fake.authorizeOIDCMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -60,62 +418,187 @@ func (fake *FakeUsecase) Login(arg1 context.Context, arg2 user.LoginRequest) (us
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeUsecase) LoginCallCount() int {
-	fake.loginMutex.RLock()
-	defer fake.loginMutex.RUnlock()
-	return len(fake.loginArgsForCall)
+func (fake *FakeUsecase) AuthorizeOIDCCallCount() int {
+	fake.authorizeOIDCMutex.RLock()
+	defer fake.authorizeOIDCMutex.RUnlock()
+	return len(fake.authorizeOIDCArgsForCall)
 }
 
-func (fake *FakeUsecase) LoginCalls(stub func(context.Context, user.LoginRequest) (user.LoginResponse, error)) {
-	fake.loginMutex.Lock()
-	defer fake.loginMutex.Unlock()
-	fake.LoginStub = stub
+func (fake *FakeUsecase) AuthorizeOIDCCalls(stub func(context.Context, string) (user.OIDCAuthorizeResponse, error)) {
+	fake.authorizeOIDCMutex.Lock()
+	defer fake.authorizeOIDCMutex.Unlock()
+	fake.AuthorizeOIDCStub = stub
 }
 
-func (fake *FakeUsecase) LoginArgsForCall(i int) (context.Context, user.LoginRequest) {
-	fake.loginMutex.RLock()
-	defer fake.loginMutex.RUnlock()
-	argsForCall := fake.loginArgsForCall[i]
+func (fake *FakeUsecase) AuthorizeOIDCArgsForCall(i int) (context.Context, string) {
+	fake.authorizeOIDCMutex.RLock()
+	defer fake.authorizeOIDCMutex.RUnlock()
+	argsForCall := fake.authorizeOIDCArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeUsecase) LoginReturns(result1 user.LoginResponse, result2 error) {
-	fake.loginMutex.Lock()
-	defer fake.loginMutex.Unlock()
-	fake.LoginStub = nil
-	fake.loginReturns = struct {
-		result1 user.LoginResponse
+func (fake *FakeUsecase) AuthorizeOIDCReturns(result1 user.OIDCAuthorizeResponse, result2 error) {
+	fake.authorizeOIDCMutex.Lock()
+	defer fake.authorizeOIDCMutex.Unlock()
+	fake.AuthorizeOIDCStub = nil
+	fake.authorizeOIDCReturns = struct {
+		result1 user.OIDCAuthorizeResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeUsecase) LoginReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
-	fake.loginMutex.Lock()
-	defer fake.loginMutex.Unlock()
-	fake.LoginStub = nil
-	if fake.loginReturnsOnCall == nil {
-		fake.loginReturnsOnCall = make(map[int]struct {
-			result1 user.LoginResponse
+func (fake *FakeUsecase) AuthorizeOIDCReturnsOnCall(i int, result1 user.OIDCAuthorizeResponse, result2 error) {
+	fake.authorizeOIDCMutex.Lock()
+	defer fake.authorizeOIDCMutex.Unlock()
+	fake.AuthorizeOIDCStub = nil
+	if fake.authorizeOIDCReturnsOnCall == nil {
+		fake.authorizeOIDCReturnsOnCall = make(map[int]struct {
+			result1 user.OIDCAuthorizeResponse
 			result2 error
 		})
 	}
-	fake.loginReturnsOnCall[i] = struct {
-		result1 user.LoginResponse
+	fake.authorizeOIDCReturnsOnCall[i] = struct {
+		result1 user.OIDCAuthorizeResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeUsecase) Register(arg1 context.Context, arg2 user.RegisterRequest) (user.User, error) {
-	fake.registerMutex.Lock()
-	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
-	fake.registerArgsForCall = append(fake.registerArgsForCall, struct {
+func (fake *FakeUsecase) BlockAccount(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.blockAccountMutex.Lock()
+	ret, specificReturn := fake.blockAccountReturnsOnCall[len(fake.blockAccountArgsForCall)]
+	fake.blockAccountArgsForCall = append(fake.blockAccountArgsForCall, struct {
 		arg1 context.Context
-		arg2 user.RegisterRequest
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.BlockAccountStub
+	fakeReturns := fake.blockAccountReturns
+	fake.recordInvocation("BlockAccount", []interface{}{arg1, arg2, arg3})
+	fake.blockAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) BlockAccountCallCount() int {
+	fake.blockAccountMutex.RLock()
+	defer fake.blockAccountMutex.RUnlock()
+	return len(fake.blockAccountArgsForCall)
+}
+
+func (fake *FakeUsecase) BlockAccountCalls(stub func(context.Context, int, string) error) {
+	fake.blockAccountMutex.Lock()
+	defer fake.blockAccountMutex.Unlock()
+	fake.BlockAccountStub = stub
+}
+
+func (fake *FakeUsecase) BlockAccountArgsForCall(i int) (context.Context, int, string) {
+	fake.blockAccountMutex.RLock()
+	defer fake.blockAccountMutex.RUnlock()
+	argsForCall := fake.blockAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeUsecase) BlockAccountReturns(result1 error) {
+	fake.blockAccountMutex.Lock()
+	defer fake.blockAccountMutex.Unlock()
+	fake.BlockAccountStub = nil
+	fake.blockAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) BlockAccountReturnsOnCall(i int, result1 error) {
+	fake.blockAccountMutex.Lock()
+	defer fake.blockAccountMutex.Unlock()
+	fake.BlockAccountStub = nil
+	if fake.blockAccountReturnsOnCall == nil {
+		fake.blockAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.blockAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ChangePassword(arg1 context.Context, arg2 user.ChangePasswordRequest) error {
+	fake.changePasswordMutex.Lock()
+	ret, specificReturn := fake.changePasswordReturnsOnCall[len(fake.changePasswordArgsForCall)]
+	fake.changePasswordArgsForCall = append(fake.changePasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ChangePasswordRequest
 	}{arg1, arg2})
-	stub := fake.RegisterStub
-	fakeReturns := fake.registerReturns
-	fake.recordInvocation("Register", []interface{}{arg1, arg2})
-	fake.registerMutex.Unlock()
+	stub := fake.ChangePasswordStub
+	fakeReturns := fake.changePasswordReturns
+	fake.recordInvocation("ChangePassword", []interface{}{arg1, arg2})
+	fake.changePasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) ChangePasswordCallCount() int {
+	fake.changePasswordMutex.RLock()
+	defer fake.changePasswordMutex.RUnlock()
+	return len(fake.changePasswordArgsForCall)
+}
+
+func (fake *FakeUsecase) ChangePasswordCalls(stub func(context.Context, user.ChangePasswordRequest) error) {
+	fake.changePasswordMutex.Lock()
+	defer fake.changePasswordMutex.Unlock()
+	fake.ChangePasswordStub = stub
+}
+
+func (fake *FakeUsecase) ChangePasswordArgsForCall(i int) (context.Context, user.ChangePasswordRequest) {
+	fake.changePasswordMutex.RLock()
+	defer fake.changePasswordMutex.RUnlock()
+	argsForCall := fake.changePasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) ChangePasswordReturns(result1 error) {
+	fake.changePasswordMutex.Lock()
+	defer fake.changePasswordMutex.Unlock()
+	fake.ChangePasswordStub = nil
+	fake.changePasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ChangePasswordReturnsOnCall(i int, result1 error) {
+	fake.changePasswordMutex.Lock()
+	defer fake.changePasswordMutex.Unlock()
+	fake.ChangePasswordStub = nil
+	if fake.changePasswordReturnsOnCall == nil {
+		fake.changePasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.changePasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ConfirmTOTP(arg1 context.Context, arg2 user.ConfirmTOTPRequest) (user.RecoveryCodesResponse, error) {
+	fake.confirmTOTPMutex.Lock()
+	ret, specificReturn := fake.confirmTOTPReturnsOnCall[len(fake.confirmTOTPArgsForCall)]
+	fake.confirmTOTPArgsForCall = append(fake.confirmTOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ConfirmTOTPRequest
+	}{arg1, arg2})
+	stub := fake.ConfirmTOTPStub
+	fakeReturns := fake.confirmTOTPReturns
+	fake.recordInvocation("ConfirmTOTP", []interface{}{arg1, arg2})
+	fake.confirmTOTPMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -125,58 +608,1759 @@ func (fake *FakeUsecase) Register(arg1 context.Context, arg2 user.RegisterReques
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeUsecase) RegisterCallCount() int {
-	fake.registerMutex.RLock()
-	defer fake.registerMutex.RUnlock()
-	return len(fake.registerArgsForCall)
+func (fake *FakeUsecase) ConfirmTOTPCallCount() int {
+	fake.confirmTOTPMutex.RLock()
+	defer fake.confirmTOTPMutex.RUnlock()
+	return len(fake.confirmTOTPArgsForCall)
 }
 
-func (fake *FakeUsecase) RegisterCalls(stub func(context.Context, user.RegisterRequest) (user.User, error)) {
-	fake.registerMutex.Lock()
-	defer fake.registerMutex.Unlock()
-	fake.RegisterStub = stub
+func (fake *FakeUsecase) ConfirmTOTPCalls(stub func(context.Context, user.ConfirmTOTPRequest) (user.RecoveryCodesResponse, error)) {
+	fake.confirmTOTPMutex.Lock()
+	defer fake.confirmTOTPMutex.Unlock()
+	fake.ConfirmTOTPStub = stub
 }
 
-func (fake *FakeUsecase) RegisterArgsForCall(i int) (context.Context, user.RegisterRequest) {
-	fake.registerMutex.RLock()
-	defer fake.registerMutex.RUnlock()
-	argsForCall := fake.registerArgsForCall[i]
+func (fake *FakeUsecase) ConfirmTOTPArgsForCall(i int) (context.Context, user.ConfirmTOTPRequest) {
+	fake.confirmTOTPMutex.RLock()
+	defer fake.confirmTOTPMutex.RUnlock()
+	argsForCall := fake.confirmTOTPArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeUsecase) RegisterReturns(result1 user.User, result2 error) {
-	fake.registerMutex.Lock()
-	defer fake.registerMutex.Unlock()
-	fake.RegisterStub = nil
-	fake.registerReturns = struct {
-		result1 user.User
+func (fake *FakeUsecase) ConfirmTOTPReturns(result1 user.RecoveryCodesResponse, result2 error) {
+	fake.confirmTOTPMutex.Lock()
+	defer fake.confirmTOTPMutex.Unlock()
+	fake.ConfirmTOTPStub = nil
+	fake.confirmTOTPReturns = struct {
+		result1 user.RecoveryCodesResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeUsecase) RegisterReturnsOnCall(i int, result1 user.User, result2 error) {
-	fake.registerMutex.Lock()
-	defer fake.registerMutex.Unlock()
-	fake.RegisterStub = nil
-	if fake.registerReturnsOnCall == nil {
-		fake.registerReturnsOnCall = make(map[int]struct {
-			result1 user.User
+func (fake *FakeUsecase) ConfirmTOTPReturnsOnCall(i int, result1 user.RecoveryCodesResponse, result2 error) {
+	fake.confirmTOTPMutex.Lock()
+	defer fake.confirmTOTPMutex.Unlock()
+	fake.ConfirmTOTPStub = nil
+	if fake.confirmTOTPReturnsOnCall == nil {
+		fake.confirmTOTPReturnsOnCall = make(map[int]struct {
+			result1 user.RecoveryCodesResponse
 			result2 error
 		})
 	}
-	fake.registerReturnsOnCall[i] = struct {
+	fake.confirmTOTPReturnsOnCall[i] = struct {
+		result1 user.RecoveryCodesResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) DeactivateAccount(arg1 context.Context, arg2 user.DeactivateAccountRequest) error {
+	fake.deactivateAccountMutex.Lock()
+	ret, specificReturn := fake.deactivateAccountReturnsOnCall[len(fake.deactivateAccountArgsForCall)]
+	fake.deactivateAccountArgsForCall = append(fake.deactivateAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.DeactivateAccountRequest
+	}{arg1, arg2})
+	stub := fake.DeactivateAccountStub
+	fakeReturns := fake.deactivateAccountReturns
+	fake.recordInvocation("DeactivateAccount", []interface{}{arg1, arg2})
+	fake.deactivateAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) DeactivateAccountCallCount() int {
+	fake.deactivateAccountMutex.RLock()
+	defer fake.deactivateAccountMutex.RUnlock()
+	return len(fake.deactivateAccountArgsForCall)
+}
+
+func (fake *FakeUsecase) DeactivateAccountCalls(stub func(context.Context, user.DeactivateAccountRequest) error) {
+	fake.deactivateAccountMutex.Lock()
+	defer fake.deactivateAccountMutex.Unlock()
+	fake.DeactivateAccountStub = stub
+}
+
+func (fake *FakeUsecase) DeactivateAccountArgsForCall(i int) (context.Context, user.DeactivateAccountRequest) {
+	fake.deactivateAccountMutex.RLock()
+	defer fake.deactivateAccountMutex.RUnlock()
+	argsForCall := fake.deactivateAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) DeactivateAccountReturns(result1 error) {
+	fake.deactivateAccountMutex.Lock()
+	defer fake.deactivateAccountMutex.Unlock()
+	fake.DeactivateAccountStub = nil
+	fake.deactivateAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) DeactivateAccountReturnsOnCall(i int, result1 error) {
+	fake.deactivateAccountMutex.Lock()
+	defer fake.deactivateAccountMutex.Unlock()
+	fake.DeactivateAccountStub = nil
+	if fake.deactivateAccountReturnsOnCall == nil {
+		fake.deactivateAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deactivateAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) DisableTOTP(arg1 context.Context, arg2 user.DisableTOTPRequest) error {
+	fake.disableTOTPMutex.Lock()
+	ret, specificReturn := fake.disableTOTPReturnsOnCall[len(fake.disableTOTPArgsForCall)]
+	fake.disableTOTPArgsForCall = append(fake.disableTOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.DisableTOTPRequest
+	}{arg1, arg2})
+	stub := fake.DisableTOTPStub
+	fakeReturns := fake.disableTOTPReturns
+	fake.recordInvocation("DisableTOTP", []interface{}{arg1, arg2})
+	fake.disableTOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) DisableTOTPCallCount() int {
+	fake.disableTOTPMutex.RLock()
+	defer fake.disableTOTPMutex.RUnlock()
+	return len(fake.disableTOTPArgsForCall)
+}
+
+func (fake *FakeUsecase) DisableTOTPCalls(stub func(context.Context, user.DisableTOTPRequest) error) {
+	fake.disableTOTPMutex.Lock()
+	defer fake.disableTOTPMutex.Unlock()
+	fake.DisableTOTPStub = stub
+}
+
+func (fake *FakeUsecase) DisableTOTPArgsForCall(i int) (context.Context, user.DisableTOTPRequest) {
+	fake.disableTOTPMutex.RLock()
+	defer fake.disableTOTPMutex.RUnlock()
+	argsForCall := fake.disableTOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) DisableTOTPReturns(result1 error) {
+	fake.disableTOTPMutex.Lock()
+	defer fake.disableTOTPMutex.Unlock()
+	fake.DisableTOTPStub = nil
+	fake.disableTOTPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) DisableTOTPReturnsOnCall(i int, result1 error) {
+	fake.disableTOTPMutex.Lock()
+	defer fake.disableTOTPMutex.Unlock()
+	fake.DisableTOTPStub = nil
+	if fake.disableTOTPReturnsOnCall == nil {
+		fake.disableTOTPReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disableTOTPReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) EndSession(arg1 context.Context, arg2 int) error {
+	fake.endSessionMutex.Lock()
+	ret, specificReturn := fake.endSessionReturnsOnCall[len(fake.endSessionArgsForCall)]
+	fake.endSessionArgsForCall = append(fake.endSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.EndSessionStub
+	fakeReturns := fake.endSessionReturns
+	fake.recordInvocation("EndSession", []interface{}{arg1, arg2})
+	fake.endSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) EndSessionCallCount() int {
+	fake.endSessionMutex.RLock()
+	defer fake.endSessionMutex.RUnlock()
+	return len(fake.endSessionArgsForCall)
+}
+
+func (fake *FakeUsecase) EndSessionCalls(stub func(context.Context, int) error) {
+	fake.endSessionMutex.Lock()
+	defer fake.endSessionMutex.Unlock()
+	fake.EndSessionStub = stub
+}
+
+func (fake *FakeUsecase) EndSessionArgsForCall(i int) (context.Context, int) {
+	fake.endSessionMutex.RLock()
+	defer fake.endSessionMutex.RUnlock()
+	argsForCall := fake.endSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) EndSessionReturns(result1 error) {
+	fake.endSessionMutex.Lock()
+	defer fake.endSessionMutex.Unlock()
+	fake.EndSessionStub = nil
+	fake.endSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) EndSessionReturnsOnCall(i int, result1 error) {
+	fake.endSessionMutex.Lock()
+	defer fake.endSessionMutex.Unlock()
+	fake.EndSessionStub = nil
+	if fake.endSessionReturnsOnCall == nil {
+		fake.endSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) EnrollTOTP(arg1 context.Context) (user.EnrollTOTPResponse, error) {
+	fake.enrollTOTPMutex.Lock()
+	ret, specificReturn := fake.enrollTOTPReturnsOnCall[len(fake.enrollTOTPArgsForCall)]
+	fake.enrollTOTPArgsForCall = append(fake.enrollTOTPArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnrollTOTPStub
+	fakeReturns := fake.enrollTOTPReturns
+	fake.recordInvocation("EnrollTOTP", []interface{}{arg1})
+	fake.enrollTOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) EnrollTOTPCallCount() int {
+	fake.enrollTOTPMutex.RLock()
+	defer fake.enrollTOTPMutex.RUnlock()
+	return len(fake.enrollTOTPArgsForCall)
+}
+
+func (fake *FakeUsecase) EnrollTOTPCalls(stub func(context.Context) (user.EnrollTOTPResponse, error)) {
+	fake.enrollTOTPMutex.Lock()
+	defer fake.enrollTOTPMutex.Unlock()
+	fake.EnrollTOTPStub = stub
+}
+
+func (fake *FakeUsecase) EnrollTOTPArgsForCall(i int) context.Context {
+	fake.enrollTOTPMutex.RLock()
+	defer fake.enrollTOTPMutex.RUnlock()
+	argsForCall := fake.enrollTOTPArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUsecase) EnrollTOTPReturns(result1 user.EnrollTOTPResponse, result2 error) {
+	fake.enrollTOTPMutex.Lock()
+	defer fake.enrollTOTPMutex.Unlock()
+	fake.EnrollTOTPStub = nil
+	fake.enrollTOTPReturns = struct {
+		result1 user.EnrollTOTPResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) EnrollTOTPReturnsOnCall(i int, result1 user.EnrollTOTPResponse, result2 error) {
+	fake.enrollTOTPMutex.Lock()
+	defer fake.enrollTOTPMutex.Unlock()
+	fake.EnrollTOTPStub = nil
+	if fake.enrollTOTPReturnsOnCall == nil {
+		fake.enrollTOTPReturnsOnCall = make(map[int]struct {
+			result1 user.EnrollTOTPResponse
+			result2 error
+		})
+	}
+	fake.enrollTOTPReturnsOnCall[i] = struct {
+		result1 user.EnrollTOTPResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) ForgotPassword(arg1 context.Context, arg2 user.ForgotPasswordRequest) error {
+	fake.forgotPasswordMutex.Lock()
+	ret, specificReturn := fake.forgotPasswordReturnsOnCall[len(fake.forgotPasswordArgsForCall)]
+	fake.forgotPasswordArgsForCall = append(fake.forgotPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ForgotPasswordRequest
+	}{arg1, arg2})
+	stub := fake.ForgotPasswordStub
+	fakeReturns := fake.forgotPasswordReturns
+	fake.recordInvocation("ForgotPassword", []interface{}{arg1, arg2})
+	fake.forgotPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) ForgotPasswordCallCount() int {
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	return len(fake.forgotPasswordArgsForCall)
+}
+
+func (fake *FakeUsecase) ForgotPasswordCalls(stub func(context.Context, user.ForgotPasswordRequest) error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = stub
+}
+
+func (fake *FakeUsecase) ForgotPasswordArgsForCall(i int) (context.Context, user.ForgotPasswordRequest) {
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	argsForCall := fake.forgotPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) ForgotPasswordReturns(result1 error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = nil
+	fake.forgotPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ForgotPasswordReturnsOnCall(i int, result1 error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = nil
+	if fake.forgotPasswordReturnsOnCall == nil {
+		fake.forgotPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.forgotPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) GetAuthEvents(arg1 context.Context, arg2 user.ListRequest) ([]user.AuthEvent, int, error) {
+	fake.getAuthEventsMutex.Lock()
+	ret, specificReturn := fake.getAuthEventsReturnsOnCall[len(fake.getAuthEventsArgsForCall)]
+	fake.getAuthEventsArgsForCall = append(fake.getAuthEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ListRequest
+	}{arg1, arg2})
+	stub := fake.GetAuthEventsStub
+	fakeReturns := fake.getAuthEventsReturns
+	fake.recordInvocation("GetAuthEvents", []interface{}{arg1, arg2})
+	fake.getAuthEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeUsecase) GetAuthEventsCallCount() int {
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	return len(fake.getAuthEventsArgsForCall)
+}
+
+func (fake *FakeUsecase) GetAuthEventsCalls(stub func(context.Context, user.ListRequest) ([]user.AuthEvent, int, error)) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = stub
+}
+
+func (fake *FakeUsecase) GetAuthEventsArgsForCall(i int) (context.Context, user.ListRequest) {
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	argsForCall := fake.getAuthEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) GetAuthEventsReturns(result1 []user.AuthEvent, result2 int, result3 error) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = nil
+	fake.getAuthEventsReturns = struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUsecase) GetAuthEventsReturnsOnCall(i int, result1 []user.AuthEvent, result2 int, result3 error) {
+	fake.getAuthEventsMutex.Lock()
+	defer fake.getAuthEventsMutex.Unlock()
+	fake.GetAuthEventsStub = nil
+	if fake.getAuthEventsReturnsOnCall == nil {
+		fake.getAuthEventsReturnsOnCall = make(map[int]struct {
+			result1 []user.AuthEvent
+			result2 int
+			result3 error
+		})
+	}
+	fake.getAuthEventsReturnsOnCall[i] = struct {
+		result1 []user.AuthEvent
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUsecase) GetProfile(arg1 context.Context) (user.User, error) {
+	fake.getProfileMutex.Lock()
+	ret, specificReturn := fake.getProfileReturnsOnCall[len(fake.getProfileArgsForCall)]
+	fake.getProfileArgsForCall = append(fake.getProfileArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetProfileStub
+	fakeReturns := fake.getProfileReturns
+	fake.recordInvocation("GetProfile", []interface{}{arg1})
+	fake.getProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) GetProfileCallCount() int {
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	return len(fake.getProfileArgsForCall)
+}
+
+func (fake *FakeUsecase) GetProfileCalls(stub func(context.Context) (user.User, error)) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = stub
+}
+
+func (fake *FakeUsecase) GetProfileArgsForCall(i int) context.Context {
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	argsForCall := fake.getProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUsecase) GetProfileReturns(result1 user.User, result2 error) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = nil
+	fake.getProfileReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) GetProfileReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = nil
+	if fake.getProfileReturnsOnCall == nil {
+		fake.getProfileReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.getProfileReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) GetSessions(arg1 context.Context) ([]user.Session, error) {
+	fake.getSessionsMutex.Lock()
+	ret, specificReturn := fake.getSessionsReturnsOnCall[len(fake.getSessionsArgsForCall)]
+	fake.getSessionsArgsForCall = append(fake.getSessionsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSessionsStub
+	fakeReturns := fake.getSessionsReturns
+	fake.recordInvocation("GetSessions", []interface{}{arg1})
+	fake.getSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) GetSessionsCallCount() int {
+	fake.getSessionsMutex.RLock()
+	defer fake.getSessionsMutex.RUnlock()
+	return len(fake.getSessionsArgsForCall)
+}
+
+func (fake *FakeUsecase) GetSessionsCalls(stub func(context.Context) ([]user.Session, error)) {
+	fake.getSessionsMutex.Lock()
+	defer fake.getSessionsMutex.Unlock()
+	fake.GetSessionsStub = stub
+}
+
+func (fake *FakeUsecase) GetSessionsArgsForCall(i int) context.Context {
+	fake.getSessionsMutex.RLock()
+	defer fake.getSessionsMutex.RUnlock()
+	argsForCall := fake.getSessionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUsecase) GetSessionsReturns(result1 []user.Session, result2 error) {
+	fake.getSessionsMutex.Lock()
+	defer fake.getSessionsMutex.Unlock()
+	fake.GetSessionsStub = nil
+	fake.getSessionsReturns = struct {
+		result1 []user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) GetSessionsReturnsOnCall(i int, result1 []user.Session, result2 error) {
+	fake.getSessionsMutex.Lock()
+	defer fake.getSessionsMutex.Unlock()
+	fake.GetSessionsStub = nil
+	if fake.getSessionsReturnsOnCall == nil {
+		fake.getSessionsReturnsOnCall = make(map[int]struct {
+			result1 []user.Session
+			result2 error
+		})
+	}
+	fake.getSessionsReturnsOnCall[i] = struct {
+		result1 []user.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) Login(arg1 context.Context, arg2 user.LoginRequest) (user.LoginResponse, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.LoginRequest
+	}{arg1, arg2})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{arg1, arg2})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeUsecase) LoginCalls(stub func(context.Context, user.LoginRequest) (user.LoginResponse, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeUsecase) LoginArgsForCall(i int) (context.Context, user.LoginRequest) {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) LoginReturns(result1 user.LoginResponse, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 user.LoginResponse
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginOIDC(arg1 context.Context, arg2 user.OIDCCallbackRequest) (user.LoginResponse, error) {
+	fake.loginOIDCMutex.Lock()
+	ret, specificReturn := fake.loginOIDCReturnsOnCall[len(fake.loginOIDCArgsForCall)]
+	fake.loginOIDCArgsForCall = append(fake.loginOIDCArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.OIDCCallbackRequest
+	}{arg1, arg2})
+	stub := fake.LoginOIDCStub
+	fakeReturns := fake.loginOIDCReturns
+	fake.recordInvocation("LoginOIDC", []interface{}{arg1, arg2})
+	fake.loginOIDCMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) LoginOIDCCallCount() int {
+	fake.loginOIDCMutex.RLock()
+	defer fake.loginOIDCMutex.RUnlock()
+	return len(fake.loginOIDCArgsForCall)
+}
+
+func (fake *FakeUsecase) LoginOIDCCalls(stub func(context.Context, user.OIDCCallbackRequest) (user.LoginResponse, error)) {
+	fake.loginOIDCMutex.Lock()
+	defer fake.loginOIDCMutex.Unlock()
+	fake.LoginOIDCStub = stub
+}
+
+func (fake *FakeUsecase) LoginOIDCArgsForCall(i int) (context.Context, user.OIDCCallbackRequest) {
+	fake.loginOIDCMutex.RLock()
+	defer fake.loginOIDCMutex.RUnlock()
+	argsForCall := fake.loginOIDCArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) LoginOIDCReturns(result1 user.LoginResponse, result2 error) {
+	fake.loginOIDCMutex.Lock()
+	defer fake.loginOIDCMutex.Unlock()
+	fake.LoginOIDCStub = nil
+	fake.loginOIDCReturns = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginOIDCReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
+	fake.loginOIDCMutex.Lock()
+	defer fake.loginOIDCMutex.Unlock()
+	fake.LoginOIDCStub = nil
+	if fake.loginOIDCReturnsOnCall == nil {
+		fake.loginOIDCReturnsOnCall = make(map[int]struct {
+			result1 user.LoginResponse
+			result2 error
+		})
+	}
+	fake.loginOIDCReturnsOnCall[i] = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginPhone(arg1 context.Context, arg2 user.PhoneLoginRequest) (user.LoginResponse, error) {
+	fake.loginPhoneMutex.Lock()
+	ret, specificReturn := fake.loginPhoneReturnsOnCall[len(fake.loginPhoneArgsForCall)]
+	fake.loginPhoneArgsForCall = append(fake.loginPhoneArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.PhoneLoginRequest
+	}{arg1, arg2})
+	stub := fake.LoginPhoneStub
+	fakeReturns := fake.loginPhoneReturns
+	fake.recordInvocation("LoginPhone", []interface{}{arg1, arg2})
+	fake.loginPhoneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) LoginPhoneCallCount() int {
+	fake.loginPhoneMutex.RLock()
+	defer fake.loginPhoneMutex.RUnlock()
+	return len(fake.loginPhoneArgsForCall)
+}
+
+func (fake *FakeUsecase) LoginPhoneCalls(stub func(context.Context, user.PhoneLoginRequest) (user.LoginResponse, error)) {
+	fake.loginPhoneMutex.Lock()
+	defer fake.loginPhoneMutex.Unlock()
+	fake.LoginPhoneStub = stub
+}
+
+func (fake *FakeUsecase) LoginPhoneArgsForCall(i int) (context.Context, user.PhoneLoginRequest) {
+	fake.loginPhoneMutex.RLock()
+	defer fake.loginPhoneMutex.RUnlock()
+	argsForCall := fake.loginPhoneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) LoginPhoneReturns(result1 user.LoginResponse, result2 error) {
+	fake.loginPhoneMutex.Lock()
+	defer fake.loginPhoneMutex.Unlock()
+	fake.LoginPhoneStub = nil
+	fake.loginPhoneReturns = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginPhoneReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
+	fake.loginPhoneMutex.Lock()
+	defer fake.loginPhoneMutex.Unlock()
+	fake.LoginPhoneStub = nil
+	if fake.loginPhoneReturnsOnCall == nil {
+		fake.loginPhoneReturnsOnCall = make(map[int]struct {
+			result1 user.LoginResponse
+			result2 error
+		})
+	}
+	fake.loginPhoneReturnsOnCall[i] = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginTOTP(arg1 context.Context, arg2 user.LoginTOTPRequest) (user.LoginResponse, error) {
+	fake.loginTOTPMutex.Lock()
+	ret, specificReturn := fake.loginTOTPReturnsOnCall[len(fake.loginTOTPArgsForCall)]
+	fake.loginTOTPArgsForCall = append(fake.loginTOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.LoginTOTPRequest
+	}{arg1, arg2})
+	stub := fake.LoginTOTPStub
+	fakeReturns := fake.loginTOTPReturns
+	fake.recordInvocation("LoginTOTP", []interface{}{arg1, arg2})
+	fake.loginTOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) LoginTOTPCallCount() int {
+	fake.loginTOTPMutex.RLock()
+	defer fake.loginTOTPMutex.RUnlock()
+	return len(fake.loginTOTPArgsForCall)
+}
+
+func (fake *FakeUsecase) LoginTOTPCalls(stub func(context.Context, user.LoginTOTPRequest) (user.LoginResponse, error)) {
+	fake.loginTOTPMutex.Lock()
+	defer fake.loginTOTPMutex.Unlock()
+	fake.LoginTOTPStub = stub
+}
+
+func (fake *FakeUsecase) LoginTOTPArgsForCall(i int) (context.Context, user.LoginTOTPRequest) {
+	fake.loginTOTPMutex.RLock()
+	defer fake.loginTOTPMutex.RUnlock()
+	argsForCall := fake.loginTOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) LoginTOTPReturns(result1 user.LoginResponse, result2 error) {
+	fake.loginTOTPMutex.Lock()
+	defer fake.loginTOTPMutex.Unlock()
+	fake.LoginTOTPStub = nil
+	fake.loginTOTPReturns = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) LoginTOTPReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
+	fake.loginTOTPMutex.Lock()
+	defer fake.loginTOTPMutex.Unlock()
+	fake.LoginTOTPStub = nil
+	if fake.loginTOTPReturnsOnCall == nil {
+		fake.loginTOTPReturnsOnCall = make(map[int]struct {
+			result1 user.LoginResponse
+			result2 error
+		})
+	}
+	fake.loginTOTPReturnsOnCall[i] = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) Logout(arg1 context.Context, arg2 user.LogoutRequest) error {
+	fake.logoutMutex.Lock()
+	ret, specificReturn := fake.logoutReturnsOnCall[len(fake.logoutArgsForCall)]
+	fake.logoutArgsForCall = append(fake.logoutArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.LogoutRequest
+	}{arg1, arg2})
+	stub := fake.LogoutStub
+	fakeReturns := fake.logoutReturns
+	fake.recordInvocation("Logout", []interface{}{arg1, arg2})
+	fake.logoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) LogoutCallCount() int {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	return len(fake.logoutArgsForCall)
+}
+
+func (fake *FakeUsecase) LogoutCalls(stub func(context.Context, user.LogoutRequest) error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = stub
+}
+
+func (fake *FakeUsecase) LogoutArgsForCall(i int) (context.Context, user.LogoutRequest) {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	argsForCall := fake.logoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) LogoutReturns(result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	fake.logoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) LogoutReturnsOnCall(i int, result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	if fake.logoutReturnsOnCall == nil {
+		fake.logoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.logoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RefreshToken(arg1 context.Context, arg2 user.RefreshTokenRequest) (user.LoginResponse, error) {
+	fake.refreshTokenMutex.Lock()
+	ret, specificReturn := fake.refreshTokenReturnsOnCall[len(fake.refreshTokenArgsForCall)]
+	fake.refreshTokenArgsForCall = append(fake.refreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.RefreshTokenRequest
+	}{arg1, arg2})
+	stub := fake.RefreshTokenStub
+	fakeReturns := fake.refreshTokenReturns
+	fake.recordInvocation("RefreshToken", []interface{}{arg1, arg2})
+	fake.refreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) RefreshTokenCallCount() int {
+	fake.refreshTokenMutex.RLock()
+	defer fake.refreshTokenMutex.RUnlock()
+	return len(fake.refreshTokenArgsForCall)
+}
+
+func (fake *FakeUsecase) RefreshTokenCalls(stub func(context.Context, user.RefreshTokenRequest) (user.LoginResponse, error)) {
+	fake.refreshTokenMutex.Lock()
+	defer fake.refreshTokenMutex.Unlock()
+	fake.RefreshTokenStub = stub
+}
+
+func (fake *FakeUsecase) RefreshTokenArgsForCall(i int) (context.Context, user.RefreshTokenRequest) {
+	fake.refreshTokenMutex.RLock()
+	defer fake.refreshTokenMutex.RUnlock()
+	argsForCall := fake.refreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) RefreshTokenReturns(result1 user.LoginResponse, result2 error) {
+	fake.refreshTokenMutex.Lock()
+	defer fake.refreshTokenMutex.Unlock()
+	fake.RefreshTokenStub = nil
+	fake.refreshTokenReturns = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) RefreshTokenReturnsOnCall(i int, result1 user.LoginResponse, result2 error) {
+	fake.refreshTokenMutex.Lock()
+	defer fake.refreshTokenMutex.Unlock()
+	fake.RefreshTokenStub = nil
+	if fake.refreshTokenReturnsOnCall == nil {
+		fake.refreshTokenReturnsOnCall = make(map[int]struct {
+			result1 user.LoginResponse
+			result2 error
+		})
+	}
+	fake.refreshTokenReturnsOnCall[i] = struct {
+		result1 user.LoginResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) Register(arg1 context.Context, arg2 user.RegisterRequest) (user.User, error) {
+	fake.registerMutex.Lock()
+	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
+	fake.registerArgsForCall = append(fake.registerArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.RegisterRequest
+	}{arg1, arg2})
+	stub := fake.RegisterStub
+	fakeReturns := fake.registerReturns
+	fake.recordInvocation("Register", []interface{}{arg1, arg2})
+	fake.registerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) RegisterCallCount() int {
+	fake.registerMutex.RLock()
+	defer fake.registerMutex.RUnlock()
+	return len(fake.registerArgsForCall)
+}
+
+func (fake *FakeUsecase) RegisterCalls(stub func(context.Context, user.RegisterRequest) (user.User, error)) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = stub
+}
+
+func (fake *FakeUsecase) RegisterArgsForCall(i int) (context.Context, user.RegisterRequest) {
+	fake.registerMutex.RLock()
+	defer fake.registerMutex.RUnlock()
+	argsForCall := fake.registerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) RegisterReturns(result1 user.User, result2 error) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = nil
+	fake.registerReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) RegisterReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.registerMutex.Lock()
+	defer fake.registerMutex.Unlock()
+	fake.RegisterStub = nil
+	if fake.registerReturnsOnCall == nil {
+		fake.registerReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.registerReturnsOnCall[i] = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) RequestPhoneLogin(arg1 context.Context, arg2 user.PhoneLoginOTPRequest) error {
+	fake.requestPhoneLoginMutex.Lock()
+	ret, specificReturn := fake.requestPhoneLoginReturnsOnCall[len(fake.requestPhoneLoginArgsForCall)]
+	fake.requestPhoneLoginArgsForCall = append(fake.requestPhoneLoginArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.PhoneLoginOTPRequest
+	}{arg1, arg2})
+	stub := fake.RequestPhoneLoginStub
+	fakeReturns := fake.requestPhoneLoginReturns
+	fake.recordInvocation("RequestPhoneLogin", []interface{}{arg1, arg2})
+	fake.requestPhoneLoginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) RequestPhoneLoginCallCount() int {
+	fake.requestPhoneLoginMutex.RLock()
+	defer fake.requestPhoneLoginMutex.RUnlock()
+	return len(fake.requestPhoneLoginArgsForCall)
+}
+
+func (fake *FakeUsecase) RequestPhoneLoginCalls(stub func(context.Context, user.PhoneLoginOTPRequest) error) {
+	fake.requestPhoneLoginMutex.Lock()
+	defer fake.requestPhoneLoginMutex.Unlock()
+	fake.RequestPhoneLoginStub = stub
+}
+
+func (fake *FakeUsecase) RequestPhoneLoginArgsForCall(i int) (context.Context, user.PhoneLoginOTPRequest) {
+	fake.requestPhoneLoginMutex.RLock()
+	defer fake.requestPhoneLoginMutex.RUnlock()
+	argsForCall := fake.requestPhoneLoginArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) RequestPhoneLoginReturns(result1 error) {
+	fake.requestPhoneLoginMutex.Lock()
+	defer fake.requestPhoneLoginMutex.Unlock()
+	fake.RequestPhoneLoginStub = nil
+	fake.requestPhoneLoginReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RequestPhoneLoginReturnsOnCall(i int, result1 error) {
+	fake.requestPhoneLoginMutex.Lock()
+	defer fake.requestPhoneLoginMutex.Unlock()
+	fake.RequestPhoneLoginStub = nil
+	if fake.requestPhoneLoginReturnsOnCall == nil {
+		fake.requestPhoneLoginReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestPhoneLoginReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RequestPhoneVerification(arg1 context.Context) error {
+	fake.requestPhoneVerificationMutex.Lock()
+	ret, specificReturn := fake.requestPhoneVerificationReturnsOnCall[len(fake.requestPhoneVerificationArgsForCall)]
+	fake.requestPhoneVerificationArgsForCall = append(fake.requestPhoneVerificationArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RequestPhoneVerificationStub
+	fakeReturns := fake.requestPhoneVerificationReturns
+	fake.recordInvocation("RequestPhoneVerification", []interface{}{arg1})
+	fake.requestPhoneVerificationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) RequestPhoneVerificationCallCount() int {
+	fake.requestPhoneVerificationMutex.RLock()
+	defer fake.requestPhoneVerificationMutex.RUnlock()
+	return len(fake.requestPhoneVerificationArgsForCall)
+}
+
+func (fake *FakeUsecase) RequestPhoneVerificationCalls(stub func(context.Context) error) {
+	fake.requestPhoneVerificationMutex.Lock()
+	defer fake.requestPhoneVerificationMutex.Unlock()
+	fake.RequestPhoneVerificationStub = stub
+}
+
+func (fake *FakeUsecase) RequestPhoneVerificationArgsForCall(i int) context.Context {
+	fake.requestPhoneVerificationMutex.RLock()
+	defer fake.requestPhoneVerificationMutex.RUnlock()
+	argsForCall := fake.requestPhoneVerificationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUsecase) RequestPhoneVerificationReturns(result1 error) {
+	fake.requestPhoneVerificationMutex.Lock()
+	defer fake.requestPhoneVerificationMutex.Unlock()
+	fake.RequestPhoneVerificationStub = nil
+	fake.requestPhoneVerificationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RequestPhoneVerificationReturnsOnCall(i int, result1 error) {
+	fake.requestPhoneVerificationMutex.Lock()
+	defer fake.requestPhoneVerificationMutex.Unlock()
+	fake.RequestPhoneVerificationStub = nil
+	if fake.requestPhoneVerificationReturnsOnCall == nil {
+		fake.requestPhoneVerificationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestPhoneVerificationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResendVerificationEmail(arg1 context.Context, arg2 user.ResendVerificationRequest) error {
+	fake.resendVerificationEmailMutex.Lock()
+	ret, specificReturn := fake.resendVerificationEmailReturnsOnCall[len(fake.resendVerificationEmailArgsForCall)]
+	fake.resendVerificationEmailArgsForCall = append(fake.resendVerificationEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ResendVerificationRequest
+	}{arg1, arg2})
+	stub := fake.ResendVerificationEmailStub
+	fakeReturns := fake.resendVerificationEmailReturns
+	fake.recordInvocation("ResendVerificationEmail", []interface{}{arg1, arg2})
+	fake.resendVerificationEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) ResendVerificationEmailCallCount() int {
+	fake.resendVerificationEmailMutex.RLock()
+	defer fake.resendVerificationEmailMutex.RUnlock()
+	return len(fake.resendVerificationEmailArgsForCall)
+}
+
+func (fake *FakeUsecase) ResendVerificationEmailCalls(stub func(context.Context, user.ResendVerificationRequest) error) {
+	fake.resendVerificationEmailMutex.Lock()
+	defer fake.resendVerificationEmailMutex.Unlock()
+	fake.ResendVerificationEmailStub = stub
+}
+
+func (fake *FakeUsecase) ResendVerificationEmailArgsForCall(i int) (context.Context, user.ResendVerificationRequest) {
+	fake.resendVerificationEmailMutex.RLock()
+	defer fake.resendVerificationEmailMutex.RUnlock()
+	argsForCall := fake.resendVerificationEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) ResendVerificationEmailReturns(result1 error) {
+	fake.resendVerificationEmailMutex.Lock()
+	defer fake.resendVerificationEmailMutex.Unlock()
+	fake.ResendVerificationEmailStub = nil
+	fake.resendVerificationEmailReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResendVerificationEmailReturnsOnCall(i int, result1 error) {
+	fake.resendVerificationEmailMutex.Lock()
+	defer fake.resendVerificationEmailMutex.Unlock()
+	fake.ResendVerificationEmailStub = nil
+	if fake.resendVerificationEmailReturnsOnCall == nil {
+		fake.resendVerificationEmailReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resendVerificationEmailReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResetPassword(arg1 context.Context, arg2 user.ResetPasswordRequest) error {
+	fake.resetPasswordMutex.Lock()
+	ret, specificReturn := fake.resetPasswordReturnsOnCall[len(fake.resetPasswordArgsForCall)]
+	fake.resetPasswordArgsForCall = append(fake.resetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.ResetPasswordRequest
+	}{arg1, arg2})
+	stub := fake.ResetPasswordStub
+	fakeReturns := fake.resetPasswordReturns
+	fake.recordInvocation("ResetPassword", []interface{}{arg1, arg2})
+	fake.resetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) ResetPasswordCallCount() int {
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	return len(fake.resetPasswordArgsForCall)
+}
+
+func (fake *FakeUsecase) ResetPasswordCalls(stub func(context.Context, user.ResetPasswordRequest) error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = stub
+}
+
+func (fake *FakeUsecase) ResetPasswordArgsForCall(i int) (context.Context, user.ResetPasswordRequest) {
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	argsForCall := fake.resetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) ResetPasswordReturns(result1 error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = nil
+	fake.resetPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResetPasswordReturnsOnCall(i int, result1 error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = nil
+	if fake.resetPasswordReturnsOnCall == nil {
+		fake.resetPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResetTOTP(arg1 context.Context, arg2 int) error {
+	fake.resetTOTPMutex.Lock()
+	ret, specificReturn := fake.resetTOTPReturnsOnCall[len(fake.resetTOTPArgsForCall)]
+	fake.resetTOTPArgsForCall = append(fake.resetTOTPArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ResetTOTPStub
+	fakeReturns := fake.resetTOTPReturns
+	fake.recordInvocation("ResetTOTP", []interface{}{arg1, arg2})
+	fake.resetTOTPMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) ResetTOTPCallCount() int {
+	fake.resetTOTPMutex.RLock()
+	defer fake.resetTOTPMutex.RUnlock()
+	return len(fake.resetTOTPArgsForCall)
+}
+
+func (fake *FakeUsecase) ResetTOTPCalls(stub func(context.Context, int) error) {
+	fake.resetTOTPMutex.Lock()
+	defer fake.resetTOTPMutex.Unlock()
+	fake.ResetTOTPStub = stub
+}
+
+func (fake *FakeUsecase) ResetTOTPArgsForCall(i int) (context.Context, int) {
+	fake.resetTOTPMutex.RLock()
+	defer fake.resetTOTPMutex.RUnlock()
+	argsForCall := fake.resetTOTPArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) ResetTOTPReturns(result1 error) {
+	fake.resetTOTPMutex.Lock()
+	defer fake.resetTOTPMutex.Unlock()
+	fake.ResetTOTPStub = nil
+	fake.resetTOTPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) ResetTOTPReturnsOnCall(i int, result1 error) {
+	fake.resetTOTPMutex.Lock()
+	defer fake.resetTOTPMutex.Unlock()
+	fake.ResetTOTPStub = nil
+	if fake.resetTOTPReturnsOnCall == nil {
+		fake.resetTOTPReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetTOTPReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RevokeAccess(arg1 context.Context, arg2 int) error {
+	fake.revokeAccessMutex.Lock()
+	ret, specificReturn := fake.revokeAccessReturnsOnCall[len(fake.revokeAccessArgsForCall)]
+	fake.revokeAccessArgsForCall = append(fake.revokeAccessArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeAccessStub
+	fakeReturns := fake.revokeAccessReturns
+	fake.recordInvocation("RevokeAccess", []interface{}{arg1, arg2})
+	fake.revokeAccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) RevokeAccessCallCount() int {
+	fake.revokeAccessMutex.RLock()
+	defer fake.revokeAccessMutex.RUnlock()
+	return len(fake.revokeAccessArgsForCall)
+}
+
+func (fake *FakeUsecase) RevokeAccessCalls(stub func(context.Context, int) error) {
+	fake.revokeAccessMutex.Lock()
+	defer fake.revokeAccessMutex.Unlock()
+	fake.RevokeAccessStub = stub
+}
+
+func (fake *FakeUsecase) RevokeAccessArgsForCall(i int) (context.Context, int) {
+	fake.revokeAccessMutex.RLock()
+	defer fake.revokeAccessMutex.RUnlock()
+	argsForCall := fake.revokeAccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) RevokeAccessReturns(result1 error) {
+	fake.revokeAccessMutex.Lock()
+	defer fake.revokeAccessMutex.Unlock()
+	fake.RevokeAccessStub = nil
+	fake.revokeAccessReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) RevokeAccessReturnsOnCall(i int, result1 error) {
+	fake.revokeAccessMutex.Lock()
+	defer fake.revokeAccessMutex.Unlock()
+	fake.RevokeAccessStub = nil
+	if fake.revokeAccessReturnsOnCall == nil {
+		fake.revokeAccessReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeAccessReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) UnblockAccount(arg1 context.Context, arg2 int, arg3 string) error {
+	fake.unblockAccountMutex.Lock()
+	ret, specificReturn := fake.unblockAccountReturnsOnCall[len(fake.unblockAccountArgsForCall)]
+	fake.unblockAccountArgsForCall = append(fake.unblockAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UnblockAccountStub
+	fakeReturns := fake.unblockAccountReturns
+	fake.recordInvocation("UnblockAccount", []interface{}{arg1, arg2, arg3})
+	fake.unblockAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) UnblockAccountCallCount() int {
+	fake.unblockAccountMutex.RLock()
+	defer fake.unblockAccountMutex.RUnlock()
+	return len(fake.unblockAccountArgsForCall)
+}
+
+func (fake *FakeUsecase) UnblockAccountCalls(stub func(context.Context, int, string) error) {
+	fake.unblockAccountMutex.Lock()
+	defer fake.unblockAccountMutex.Unlock()
+	fake.UnblockAccountStub = stub
+}
+
+func (fake *FakeUsecase) UnblockAccountArgsForCall(i int) (context.Context, int, string) {
+	fake.unblockAccountMutex.RLock()
+	defer fake.unblockAccountMutex.RUnlock()
+	argsForCall := fake.unblockAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeUsecase) UnblockAccountReturns(result1 error) {
+	fake.unblockAccountMutex.Lock()
+	defer fake.unblockAccountMutex.Unlock()
+	fake.UnblockAccountStub = nil
+	fake.unblockAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) UnblockAccountReturnsOnCall(i int, result1 error) {
+	fake.unblockAccountMutex.Lock()
+	defer fake.unblockAccountMutex.Unlock()
+	fake.UnblockAccountStub = nil
+	if fake.unblockAccountReturnsOnCall == nil {
+		fake.unblockAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unblockAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) UnlockAccount(arg1 context.Context, arg2 int) error {
+	fake.unlockAccountMutex.Lock()
+	ret, specificReturn := fake.unlockAccountReturnsOnCall[len(fake.unlockAccountArgsForCall)]
+	fake.unlockAccountArgsForCall = append(fake.unlockAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.UnlockAccountStub
+	fakeReturns := fake.unlockAccountReturns
+	fake.recordInvocation("UnlockAccount", []interface{}{arg1, arg2})
+	fake.unlockAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) UnlockAccountCallCount() int {
+	fake.unlockAccountMutex.RLock()
+	defer fake.unlockAccountMutex.RUnlock()
+	return len(fake.unlockAccountArgsForCall)
+}
+
+func (fake *FakeUsecase) UnlockAccountCalls(stub func(context.Context, int) error) {
+	fake.unlockAccountMutex.Lock()
+	defer fake.unlockAccountMutex.Unlock()
+	fake.UnlockAccountStub = stub
+}
+
+func (fake *FakeUsecase) UnlockAccountArgsForCall(i int) (context.Context, int) {
+	fake.unlockAccountMutex.RLock()
+	defer fake.unlockAccountMutex.RUnlock()
+	argsForCall := fake.unlockAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) UnlockAccountReturns(result1 error) {
+	fake.unlockAccountMutex.Lock()
+	defer fake.unlockAccountMutex.Unlock()
+	fake.UnlockAccountStub = nil
+	fake.unlockAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) UnlockAccountReturnsOnCall(i int, result1 error) {
+	fake.unlockAccountMutex.Lock()
+	defer fake.unlockAccountMutex.Unlock()
+	fake.UnlockAccountStub = nil
+	if fake.unlockAccountReturnsOnCall == nil {
+		fake.unlockAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlockAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) UpdateProfile(arg1 context.Context, arg2 user.UpdateProfileRequest) (user.User, error) {
+	fake.updateProfileMutex.Lock()
+	ret, specificReturn := fake.updateProfileReturnsOnCall[len(fake.updateProfileArgsForCall)]
+	fake.updateProfileArgsForCall = append(fake.updateProfileArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.UpdateProfileRequest
+	}{arg1, arg2})
+	stub := fake.UpdateProfileStub
+	fakeReturns := fake.updateProfileReturns
+	fake.recordInvocation("UpdateProfile", []interface{}{arg1, arg2})
+	fake.updateProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUsecase) UpdateProfileCallCount() int {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	return len(fake.updateProfileArgsForCall)
+}
+
+func (fake *FakeUsecase) UpdateProfileCalls(stub func(context.Context, user.UpdateProfileRequest) (user.User, error)) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = stub
+}
+
+func (fake *FakeUsecase) UpdateProfileArgsForCall(i int) (context.Context, user.UpdateProfileRequest) {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	argsForCall := fake.updateProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) UpdateProfileReturns(result1 user.User, result2 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	fake.updateProfileReturns = struct {
+		result1 user.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUsecase) UpdateProfileReturnsOnCall(i int, result1 user.User, result2 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	if fake.updateProfileReturnsOnCall == nil {
+		fake.updateProfileReturnsOnCall = make(map[int]struct {
+			result1 user.User
+			result2 error
+		})
+	}
+	fake.updateProfileReturnsOnCall[i] = struct {
 		result1 user.User
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeUsecase) VerifyEmail(arg1 context.Context, arg2 user.VerifyEmailRequest) error {
+	fake.verifyEmailMutex.Lock()
+	ret, specificReturn := fake.verifyEmailReturnsOnCall[len(fake.verifyEmailArgsForCall)]
+	fake.verifyEmailArgsForCall = append(fake.verifyEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.VerifyEmailRequest
+	}{arg1, arg2})
+	stub := fake.VerifyEmailStub
+	fakeReturns := fake.verifyEmailReturns
+	fake.recordInvocation("VerifyEmail", []interface{}{arg1, arg2})
+	fake.verifyEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) VerifyEmailCallCount() int {
+	fake.verifyEmailMutex.RLock()
+	defer fake.verifyEmailMutex.RUnlock()
+	return len(fake.verifyEmailArgsForCall)
+}
+
+func (fake *FakeUsecase) VerifyEmailCalls(stub func(context.Context, user.VerifyEmailRequest) error) {
+	fake.verifyEmailMutex.Lock()
+	defer fake.verifyEmailMutex.Unlock()
+	fake.VerifyEmailStub = stub
+}
+
+func (fake *FakeUsecase) VerifyEmailArgsForCall(i int) (context.Context, user.VerifyEmailRequest) {
+	fake.verifyEmailMutex.RLock()
+	defer fake.verifyEmailMutex.RUnlock()
+	argsForCall := fake.verifyEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) VerifyEmailReturns(result1 error) {
+	fake.verifyEmailMutex.Lock()
+	defer fake.verifyEmailMutex.Unlock()
+	fake.VerifyEmailStub = nil
+	fake.verifyEmailReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) VerifyEmailReturnsOnCall(i int, result1 error) {
+	fake.verifyEmailMutex.Lock()
+	defer fake.verifyEmailMutex.Unlock()
+	fake.VerifyEmailStub = nil
+	if fake.verifyEmailReturnsOnCall == nil {
+		fake.verifyEmailReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyEmailReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) VerifyPhone(arg1 context.Context, arg2 user.VerifyPhoneRequest) error {
+	fake.verifyPhoneMutex.Lock()
+	ret, specificReturn := fake.verifyPhoneReturnsOnCall[len(fake.verifyPhoneArgsForCall)]
+	fake.verifyPhoneArgsForCall = append(fake.verifyPhoneArgsForCall, struct {
+		arg1 context.Context
+		arg2 user.VerifyPhoneRequest
+	}{arg1, arg2})
+	stub := fake.VerifyPhoneStub
+	fakeReturns := fake.verifyPhoneReturns
+	fake.recordInvocation("VerifyPhone", []interface{}{arg1, arg2})
+	fake.verifyPhoneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUsecase) VerifyPhoneCallCount() int {
+	fake.verifyPhoneMutex.RLock()
+	defer fake.verifyPhoneMutex.RUnlock()
+	return len(fake.verifyPhoneArgsForCall)
+}
+
+func (fake *FakeUsecase) VerifyPhoneCalls(stub func(context.Context, user.VerifyPhoneRequest) error) {
+	fake.verifyPhoneMutex.Lock()
+	defer fake.verifyPhoneMutex.Unlock()
+	fake.VerifyPhoneStub = stub
+}
+
+func (fake *FakeUsecase) VerifyPhoneArgsForCall(i int) (context.Context, user.VerifyPhoneRequest) {
+	fake.verifyPhoneMutex.RLock()
+	defer fake.verifyPhoneMutex.RUnlock()
+	argsForCall := fake.verifyPhoneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUsecase) VerifyPhoneReturns(result1 error) {
+	fake.verifyPhoneMutex.Lock()
+	defer fake.verifyPhoneMutex.Unlock()
+	fake.VerifyPhoneStub = nil
+	fake.verifyPhoneReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUsecase) VerifyPhoneReturnsOnCall(i int, result1 error) {
+	fake.verifyPhoneMutex.Lock()
+	defer fake.verifyPhoneMutex.Unlock()
+	fake.VerifyPhoneStub = nil
+	if fake.verifyPhoneReturnsOnCall == nil {
+		fake.verifyPhoneReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyPhoneReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeUsecase) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.authorizeOIDCMutex.RLock()
+	defer fake.authorizeOIDCMutex.RUnlock()
+	fake.blockAccountMutex.RLock()
+	defer fake.blockAccountMutex.RUnlock()
+	fake.changePasswordMutex.RLock()
+	defer fake.changePasswordMutex.RUnlock()
+	fake.confirmTOTPMutex.RLock()
+	defer fake.confirmTOTPMutex.RUnlock()
+	fake.deactivateAccountMutex.RLock()
+	defer fake.deactivateAccountMutex.RUnlock()
+	fake.disableTOTPMutex.RLock()
+	defer fake.disableTOTPMutex.RUnlock()
+	fake.endSessionMutex.RLock()
+	defer fake.endSessionMutex.RUnlock()
+	fake.enrollTOTPMutex.RLock()
+	defer fake.enrollTOTPMutex.RUnlock()
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	fake.getAuthEventsMutex.RLock()
+	defer fake.getAuthEventsMutex.RUnlock()
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	fake.getSessionsMutex.RLock()
+	defer fake.getSessionsMutex.RUnlock()
 	fake.loginMutex.RLock()
 	defer fake.loginMutex.RUnlock()
+	fake.loginOIDCMutex.RLock()
+	defer fake.loginOIDCMutex.RUnlock()
+	fake.loginPhoneMutex.RLock()
+	defer fake.loginPhoneMutex.RUnlock()
+	fake.loginTOTPMutex.RLock()
+	defer fake.loginTOTPMutex.RUnlock()
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	fake.refreshTokenMutex.RLock()
+	defer fake.refreshTokenMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
+	fake.requestPhoneLoginMutex.RLock()
+	defer fake.requestPhoneLoginMutex.RUnlock()
+	fake.requestPhoneVerificationMutex.RLock()
+	defer fake.requestPhoneVerificationMutex.RUnlock()
+	fake.resendVerificationEmailMutex.RLock()
+	defer fake.resendVerificationEmailMutex.RUnlock()
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	fake.resetTOTPMutex.RLock()
+	defer fake.resetTOTPMutex.RUnlock()
+	fake.revokeAccessMutex.RLock()
+	defer fake.revokeAccessMutex.RUnlock()
+	fake.unblockAccountMutex.RLock()
+	defer fake.unblockAccountMutex.RUnlock()
+	fake.unlockAccountMutex.RLock()
+	defer fake.unlockAccountMutex.RUnlock()
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	fake.verifyEmailMutex.RLock()
+	defer fake.verifyEmailMutex.RUnlock()
+	fake.verifyPhoneMutex.RLock()
+	defer fake.verifyPhoneMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+
+	"go-skeleton-code/pkg/client"
+	"go-skeleton-code/pkg/log"
+)
+
+type authEventWriter struct {
+	userRepository Repository
+}
+
+// NewAuthEventWriter returns writer appending the event into auth events table.
+func NewAuthEventWriter(userRepository Repository) *authEventWriter {
+	return &authEventWriter{
+		userRepository: userRepository,
+	}
+}
+
+// Write completes the event with the client info from the request context. Failure only logged, the event
+// must not fail the action it records.
+func (w *authEventWriter) Write(ctx context.Context, authEvent AuthEvent) {
+	clientInfo := client.GetFromContext(ctx)
+	authEvent.IPAddress = clientInfo.IPAddress
+	authEvent.UserAgent = clientInfo.UserAgent
+
+	// Still saved when the request cancelled right after the action succeed
+	if err := w.userRepository.SaveAuthEvent(context.WithoutCancel(ctx), authEvent); err != nil {
+		log.Context(ctx).Errorf("failed saving auth event %v of user %v, %v", authEvent.Type, authEvent.UserID, err)
+	}
+}
@@ -1,3 +1,136 @@
 package user
 
-var ()
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RoleUser = "user" // Default role for registered user
+
+	refreshTokenKey       = "refresh-token:%v"
+	refreshTokenUsedKey   = "refresh-token-used:%v"
+	refreshTokenFamilyKey = "refresh-token-family:%v"
+	singleUseTokenKey     = "%v:%v"      // Token purpose and token hash
+	userTokenKey          = "%v-user:%v" // Token purpose and user id, holds every outstanding token hash
+	rateLimitKey          = "%v-rate:%v" // Rate limited action and the subject
+
+	oidcStateCookie = "oidc_state" // Binds the OIDC state to the browser starting the login
+)
+
+// KYCLevel is the identity verification level of the user, higher level has higher limit.
+type KYCLevel string
+
+const (
+	KYCLevelUnverified KYCLevel = "UNVERIFIED"
+	KYCLevelBasic      KYCLevel = "BASIC"
+	KYCLevelFull       KYCLevel = "FULL"
+)
+
+// KYCLevels ordered from the lowest level.
+var KYCLevels = []KYCLevel{KYCLevelUnverified, KYCLevelBasic, KYCLevelFull}
+
+func (l KYCLevel) IsValid() bool {
+	return l.Rank() >= 0
+}
+
+// Rank returns position of the level in KYCLevels, -1 for unknown level.
+func (l KYCLevel) Rank() int {
+	for rank, level := range KYCLevels {
+		if l == level {
+			return rank
+		}
+	}
+
+	return -1
+}
+
+type TokenPurpose string
+
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email-verification"
+	TokenPurposePasswordReset     TokenPurpose = "password-reset"
+	TokenPurposeTOTPLogin         TokenPurpose = "totp-login"
+	TokenPurposeEmailChange       TokenPurpose = "email-change"
+	TokenPurposeOIDCState         TokenPurpose = "oidc-state"
+	TokenPurposePhoneVerification TokenPurpose = "phone-verification"
+	TokenPurposePhoneLogin        TokenPurpose = "phone-login"
+)
+
+const (
+	rateLimitVerificationEmail = "verification-email"
+	rateLimitEmailChange       = "email-change"
+	rateLimitPasswordReset     = "password-reset"
+	rateLimitTOTPStep          = "totp-step" // Prevent the same OTP used twice
+	rateLimitLoginEmail        = "login-email"
+	rateLimitLoginIP           = "login-ip"
+	rateLimitLockout           = "login-lockout"
+	rateLimitPhoneOTP          = "phone-otp"
+	rateLimitPhoneLockout      = "phone-lockout"
+
+	counterTOTPAttempt      = "totp-attempt"
+	counterTOTPFailedUser   = "totp-failed-user"
+	counterPhoneOTPAttempt  = "phone-otp-attempt" // Counted per phone number across every OTP sent
+	counterPhoneOTPSent     = "phone-otp-sent"
+	counterPhoneOTPIP       = "phone-otp-ip"
+	counterLoginFailedEmail = "login-failed-email"
+	counterLoginFailedIP    = "login-failed-ip"
+	counterLoginFailedLock  = "login-failed-lockout" // Counted per email and IP address
+	counterKey              = "%v-count:%v"          // Counter name and the subject
+
+	phoneOTPDailyWindow = 24 * time.Hour
+	phoneOTPIPWindow    = time.Hour
+)
+
+type AuthEventType string
+
+const (
+	AuthEventAccountLocked    AuthEventType = "ACCOUNT_LOCKED"
+	AuthEventAccountUnlocked  AuthEventType = "ACCOUNT_UNLOCKED"
+	AuthEventDeactivated      AuthEventType = "ACCOUNT_DEACTIVATED"
+	AuthEventEmailChanged     AuthEventType = "EMAIL_CHANGED"
+	AuthEventAccountBlocked   AuthEventType = "ACCOUNT_BLOCKED"
+	AuthEventAccountUnblocked AuthEventType = "ACCOUNT_UNBLOCKED"
+	AuthEventTOTPReset        AuthEventType = "TOTP_RESET"
+	AuthEventTOTPEnabled      AuthEventType = "TOTP_ENABLED"
+	AuthEventTOTPDisabled     AuthEventType = "TOTP_DISABLED"
+	AuthEventLoginSucceeded   AuthEventType = "LOGIN_SUCCEEDED"
+	AuthEventLoginFailed      AuthEventType = "LOGIN_FAILED"
+	AuthEventLogout           AuthEventType = "LOGOUT"
+	AuthEventSessionEnded     AuthEventType = "SESSION_ENDED"
+	AuthEventTokenRefreshed   AuthEventType = "TOKEN_REFRESHED"
+	AuthEventTokenReused      AuthEventType = "REFRESH_TOKEN_REUSED"
+	AuthEventPasswordChanged  AuthEventType = "PASSWORD_CHANGED"
+	AuthEventPasswordReset    AuthEventType = "PASSWORD_RESET"
+	AuthEventPhoneVerified    AuthEventType = "PHONE_VERIFIED"
+	AuthEventRoleAssigned     AuthEventType = "ROLE_ASSIGNED"
+	AuthEventRoleChanged      AuthEventType = "ROLE_CHANGED" // Reserved, no flow changes the role of existing user yet
+)
+
+var (
+	ErrUserInactive       = errors.New("user inactive")
+	ErrRefreshTokenReused = errors.New("refresh token reused, token family revoked")
+	ErrTokenFamilyRevoked = errors.New("refresh token family revoked")
+	ErrTokenOwnerMismatch = errors.New("refresh token belongs to other user")
+	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrTokenNotFound      = errors.New("token not found or expired")
+	ErrRateLimited        = errors.New("rate limited, try again later")
+	ErrTOTPAlreadyEnabled = errors.New("two factor authentication already enabled")
+	ErrTOTPNotEnrolled    = errors.New("two factor authentication not enrolled")
+	ErrTOTPNotEnabled     = errors.New("two factor authentication not enabled")
+	ErrInvalidOTP         = errors.New("invalid one time password")
+	ErrTooManyAttempts    = errors.New("too many attempts")
+	ErrAccountLocked      = errors.New("too many failed login, account locked")
+	ErrEmailRegistered    = errors.New("email already registered")
+	ErrUserDeactivated    = errors.New("user account deactivated")
+
+	ErrPhoneNumberMissing   = errors.New("phone number not set in the profile")
+	ErrPhoneAlreadyVerified = errors.New("phone number already verified")
+	ErrPhoneRegistered      = errors.New("phone number already verified by other user")
+	ErrPhoneLoginDisabled   = errors.New("phone login disabled")
+	ErrPhoneLocked          = errors.New("too many wrong otp, phone number locked")
+
+	ErrInvalidOIDCState     = errors.New("invalid or expired oidc state")
+	ErrOIDCEmailNotVerified = errors.New("email not verified by the provider")
+	ErrIdentityLinked       = errors.New("identity already linked to other user")
+)
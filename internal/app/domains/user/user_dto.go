@@ -1,18 +1,129 @@
 package user
 
+type ListRequest struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// setDefault follows the same boundary used by gorm pagination query.
+func (r *ListRequest) setDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	switch {
+	case r.Limit > 1000:
+		r.Limit = 1000
+	case r.Limit <= 0:
+		r.Limit = 10
+	}
+}
+
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
 }
 
 type LoginResponse struct {
-	Email string `json:"email"`
-	Token string `json:"token"`
+	Email        string `json:"email"`
+	Token        string `json:"token,omitempty"`         // Access token
+	ExpiredAt    int64  `json:"expired_at,omitempty"`    // Access token expiration time
+	RefreshToken string `json:"refresh_token,omitempty"` // Single use token for requesting new access token
+	TOTPRequired bool   `json:"totp_required,omitempty"` // Password verified, continue login with the OTP
+	LoginToken   string `json:"login_token,omitempty"`   // Partial login token for the OTP verification
+}
+
+type LoginTOTPRequest struct {
+	LoginToken string `json:"login_token" validate:"required"`
+	Code       string `json:"code" validate:"required"` // OTP or recovery code
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // Optional, the refresh token family revoked as well
 }
 
 type RegisterRequest struct {
-	FullName    string `json:"full_name"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
-	Password    string `json:"password"`
+	FullName    string `json:"full_name" validate:"required,max=128"`
+	Email       string `json:"email" validate:"required,email,max=128"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,e164"` // International format, e.g. +628123456789
+	Password    string `json:"password" validate:"required,password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,password"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,password,nefield=OldPassword"`
+}
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type PhoneLoginOTPRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+}
+
+type PhoneLoginRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+	Code        string `json:"code" validate:"required"`
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"` // Redirect the user to this URL
+	State            string `json:"-"`                 // Saved in cookie, the callback only accepted from the same browser
+}
+
+type OIDCCallbackRequest struct {
+	Provider     string `json:"-"`
+	BrowserState string `json:"-"` // State from the cookie
+	Code         string `json:"code" validate:"required"`
+	State        string `json:"state" validate:"required"`
+}
+
+type UpdateProfileRequest struct {
+	FullName    string `json:"full_name" validate:"required,max=128"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,e164"`
+	Email       string `json:"email" validate:"omitempty,email,max=128"` // Applied after the new email verified
+}
+
+type DeactivateAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth URI for QR code
+}
+
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"` // Only shown once
+}
+
+type DisableTOTPRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"` // OTP or recovery code
 }
@@ -2,22 +2,33 @@ package user
 
 import (
 	"context"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	middleware "go-skeleton-code/internal/app/middleware/http/gin"
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
 	response "go-skeleton-code/pkg/response/gin"
 )
 
 type httpHandler struct {
-	timeout     time.Duration
-	userUsecase Usecase
+	timeout        time.Duration
+	userUsecase    Usecase
+	jwtManager     jwt.Manager
+	revocationList jwt.RevocationList
 }
 
-func NewHTTPHandler(userUsecase Usecase, timeout time.Duration) interface{ InitRoutes(g *gin.RouterGroup) } {
+func NewHTTPHandler(userUsecase Usecase, timeout time.Duration, jwtManager jwt.Manager, revocationList jwt.RevocationList) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
 	return &httpHandler{
-		timeout:     timeout,
-		userUsecase: userUsecase,
+		timeout:        timeout,
+		userUsecase:    userUsecase,
+		jwtManager:     jwtManager,
+		revocationList: revocationList,
 	}
 }
 
@@ -26,6 +37,31 @@ func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
 	{
 		v1.POST("/login", h.LoginHandler)
 		v1.POST("/register", h.RegisterHandler)
+		v1.POST("/token/refresh", h.RefreshTokenHandler)
+		v1.POST("/verify", h.VerifyEmailHandler)
+		v1.POST("/verify/resend", h.ResendVerificationHandler)
+		v1.POST("/password/forgot", h.ForgotPasswordHandler)
+		v1.POST("/password/reset", h.ResetPasswordHandler)
+		v1.POST("/login/totp", h.LoginTOTPHandler)
+		v1.POST("/login/phone/request", h.RequestPhoneLoginHandler)
+		v1.POST("/login/phone", h.LoginPhoneHandler)
+		v1.GET("/oidc/:provider/authorize", h.AuthorizeOIDCHandler)
+		v1.POST("/oidc/:provider/callback", h.LoginOIDCHandler)
+
+		authenticated := v1.Group("", middleware.ValidateJwtToken(h.jwtManager, h.revocationList))
+		authenticated.POST("/logout", h.LogoutHandler)
+		authenticated.POST("/password/change", h.ChangePasswordHandler)
+		authenticated.POST("/totp/enroll", h.EnrollTOTPHandler)
+		authenticated.POST("/totp/confirm", h.ConfirmTOTPHandler)
+		authenticated.POST("/totp/disable", h.DisableTOTPHandler)
+		authenticated.POST("/phone/verify/request", h.RequestPhoneVerificationHandler)
+		authenticated.POST("/phone/verify", h.VerifyPhoneHandler)
+		authenticated.GET("/sessions", h.GetSessionsHandler)
+		authenticated.DELETE("/sessions/:id", h.EndSessionHandler)
+		authenticated.GET("/security-events", h.GetAuthEventsHandler)
+		authenticated.GET("/me", h.GetProfileHandler)
+		authenticated.PUT("/me", h.UpdateProfileHandler)
+		authenticated.POST("/me/deactivate", h.DeactivateAccountHandler)
 	}
 }
 
@@ -67,3 +103,416 @@ func (h *httpHandler) RegisterHandler(c *gin.Context) {
 
 	response.Success(c, registerResult)
 }
+
+func (h *httpHandler) RefreshTokenHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload RefreshTokenRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	refreshResult, err := h.userUsecase.RefreshToken(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, refreshResult)
+}
+
+func (h *httpHandler) LogoutHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	// Request body is optional
+	var requestPayload LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.Bind(&requestPayload); err != nil {
+			response.Failed(c, err)
+			return
+		}
+	}
+
+	if err := h.userUsecase.Logout(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) VerifyEmailHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload VerifyEmailRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.VerifyEmail(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) ResendVerificationHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ResendVerificationRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.ResendVerificationEmail(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) ForgotPasswordHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ForgotPasswordRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.ForgotPassword(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) ResetPasswordHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ResetPasswordRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.ResetPassword(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) ChangePasswordHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ChangePasswordRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.ChangePassword(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) LoginTOTPHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload LoginTOTPRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	loginResult, err := h.userUsecase.LoginTOTP(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, loginResult)
+}
+
+func (h *httpHandler) EnrollTOTPHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	enrollResult, err := h.userUsecase.EnrollTOTP(ctx)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, enrollResult)
+}
+
+func (h *httpHandler) ConfirmTOTPHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ConfirmTOTPRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	confirmResult, err := h.userUsecase.ConfirmTOTP(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, confirmResult)
+}
+
+func (h *httpHandler) DisableTOTPHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload DisableTOTPRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.DisableTOTP(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) RequestPhoneVerificationHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	if err := h.userUsecase.RequestPhoneVerification(ctx); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) VerifyPhoneHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload VerifyPhoneRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.VerifyPhone(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) RequestPhoneLoginHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload PhoneLoginOTPRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.RequestPhoneLogin(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) LoginPhoneHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload PhoneLoginRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	loginResult, err := h.userUsecase.LoginPhone(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, loginResult)
+}
+
+func (h *httpHandler) AuthorizeOIDCHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	authorizeResult, err := h.userUsecase.AuthorizeOIDC(ctx, c.Param("provider"))
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	setOIDCStateCookie(c, authorizeResult.State, 0)
+	response.Success(c, authorizeResult)
+}
+
+func (h *httpHandler) LoginOIDCHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload OIDCCallbackRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+	requestPayload.Provider = c.Param("provider")
+	requestPayload.BrowserState, _ = c.Cookie(oidcStateCookie)
+
+	// State can only be used once, remove it regardless of the result
+	setOIDCStateCookie(c, "", -1)
+
+	loginResult, err := h.userUsecase.LoginOIDC(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, loginResult)
+}
+
+// setOIDCStateCookie saves the state only readable by this service, negative max age removes the cookie.
+func setOIDCStateCookie(c *gin.Context, state string, maxAge int) {
+	// Lax allows the cookie on the redirect back from the provider
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, maxAge, "/", "", true, true)
+}
+
+func (h *httpHandler) GetSessionsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	sessions, err := h.userUsecase.GetSessions(ctx)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, sessions)
+}
+
+func (h *httpHandler) GetAuthEventsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload ListRequest
+	if err := c.BindQuery(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	requestPayload.setDefault()
+
+	authEvents, total, err := h.userUsecase.GetAuthEvents(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.SuccessList(c, authEvents, requestPayload.Page, requestPayload.Limit, total)
+}
+
+func (h *httpHandler) EndSessionHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Failed(c, serverError.ErrDataNotFound(err))
+		return
+	}
+
+	if err := h.userUsecase.EndSession(ctx, id); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *httpHandler) GetProfileHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	profile, err := h.userUsecase.GetProfile(ctx)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, profile)
+}
+
+func (h *httpHandler) UpdateProfileHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload UpdateProfileRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	profile, err := h.userUsecase.UpdateProfile(ctx, requestPayload)
+	if err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, profile)
+}
+
+func (h *httpHandler) DeactivateAccountHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	var requestPayload DeactivateAccountRequest
+	if err := c.Bind(&requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	if err := h.userUsecase.DeactivateAccount(ctx, requestPayload); err != nil {
+		response.Failed(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -3,33 +3,259 @@ package user
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
 type User struct {
-	ID          int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
-	FullName    string     `json:"full_name" gorm:"column:full_name;type:varchar;size:255"`
-	Email       string     `json:"email" gorm:"column:email;type:varchar;size:255"`
-	PhoneNumber string     `json:"phone_number" gorm:"column:phone_number;type:varchar;size:255"`
-	Password    string     `json:"password" gorm:"column:password;type:varchar;size:255"`
-	Status      bool       `json:"status" gorm:"column:status;type:tinyint"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;type:datetime"`
-	DeletedAt   *time.Time `json:"deleted_at" gorm:"column:deleted_at;type:datetime"`
+	ID              int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	FullName        string     `json:"full_name" gorm:"column:full_name;type:varchar;size:255"`
+	Email           string     `json:"email" gorm:"column:email;type:varchar;size:255"`
+	PhoneNumber     string     `json:"phone_number" gorm:"column:phone_number;type:varchar;size:255"`
+	Password        string     `json:"-" gorm:"column:password;type:varchar;size:255"`
+	Role            string     `json:"role" gorm:"column:role;type:varchar;size:32"`
+	Permissions     string     `json:"permissions" gorm:"column:permissions;type:text"` // Comma separated permission
+	Status          bool       `json:"status" gorm:"column:status;type:tinyint"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at" gorm:"column:email_verified_at;type:datetime"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at" gorm:"column:phone_verified_at;type:datetime"` // Cleared when the phone number changed
+	TOTPSecret      string     `json:"-" gorm:"column:totp_secret;type:text"`                           // Encrypted, set when enrollment started
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at" gorm:"column:totp_enabled_at;type:datetime"`     // Set when enrollment confirmed
+	KYCLevel        KYCLevel   `json:"kyc_level" gorm:"column:kyc_level;type:varchar;size:32"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"column:updated_at;type:datetime"`
+	DeletedAt       *time.Time `json:"deleted_at" gorm:"column:deleted_at;type:datetime"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
 
+// PermissionList returns user permissions as a list.
+func (u User) PermissionList() []string {
+	var permissions []string
+	for _, permission := range strings.Split(u.Permissions, ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+// IsTOTPEnabled reports whether login requires the OTP.
+func (u User) IsTOTPEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// RecoveryCode replaces the OTP once when the authenticator is not available.
+type RecoveryCode struct {
+	ID        int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID    int        `json:"user_id" gorm:"column:user_id;type:int"`
+	CodeHash  string     `json:"-" gorm:"column:code_hash;type:varchar;size:64"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at;type:datetime"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// AuthEvent is an audit record of security related event of the user.
+type AuthEvent struct {
+	ID        int           `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID    int           `json:"user_id" gorm:"column:user_id;type:int"`
+	Email     string        `json:"email" gorm:"column:email;type:varchar;size:255"`
+	Type      AuthEventType `json:"type" gorm:"column:type;type:varchar;size:32"`
+	IPAddress string        `json:"ip_address" gorm:"column:ip_address;type:varchar;size:64"`
+	UserAgent string        `json:"user_agent" gorm:"column:user_agent;type:text"`
+	Detail    string        `json:"detail" gorm:"column:detail;type:text"`
+	CreatedAt time.Time     `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (AuthEvent) TableName() string {
+	return "auth_events"
+}
+
+// RefreshToken stored in redis with hashed token as the key. Every rotation
+// issues new token within the same family, until the family revoked.
+type RefreshToken struct {
+	UserID     int    `json:"user_id"`
+	FamilyID   string `json:"family_id"`
+	IssuedAt   int64  `json:"issued_at"`
+	IssuedAtMs int64  `json:"issued_at_ms"` // Issued time in millisecond, compared with user revocation time
+}
+
+// Session is a login on a device, refresh token rotated within the session keeps the same family id.
+type Session struct {
+	ID         int        `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID     int        `json:"user_id" gorm:"column:user_id;type:int"`
+	FamilyID   string     `json:"-" gorm:"column:family_id;type:varchar;size:64"` // Refresh token family, also the sid claim
+	IPAddress  string     `json:"ip_address" gorm:"column:ip_address;type:varchar;size:64"`
+	UserAgent  string     `json:"user_agent" gorm:"column:user_agent;type:text"`
+	Current    bool       `json:"current" gorm:"-"` // Session of the token used in the request
+	LastSeenAt time.Time  `json:"last_seen_at" gorm:"column:last_seen_at;type:datetime"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"column:revoked_at;type:datetime"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// UserIdentity links account on external OIDC provider to the user.
+type UserIdentity struct {
+	ID        int       `json:"id" gorm:"column:id;type:int;primaryKey;autoIncrement"`
+	UserID    int       `json:"user_id" gorm:"column:user_id;type:int"`
+	Provider  string    `json:"provider" gorm:"column:provider;type:varchar;size:64"`
+	Subject   string    `json:"-" gorm:"column:subject;type:varchar;size:255"` // User id on the provider
+	Email     string    `json:"email" gorm:"column:email;type:varchar;size:255"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:datetime"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// OIDCState is kept between redirecting to the provider and the callback.
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// PhoneOTP is the OTP sent to the phone number, only the hash stored.
+type PhoneOTP struct {
+	UserID   int    `json:"user_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+// EmailChange waits for verification of the new email before applied.
+type EmailChange struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+}
+
 //counterfeiter:generate -o ./mock . Usecase
 type Usecase interface {
 	Login(ctx context.Context, loginReq LoginRequest) (LoginResponse, error)
 	Register(ctx context.Context, registerReq RegisterRequest) (User, error)
+	RefreshToken(ctx context.Context, refreshReq RefreshTokenRequest) (LoginResponse, error)
+	Logout(ctx context.Context, logoutReq LogoutRequest) error
+	VerifyEmail(ctx context.Context, verifyReq VerifyEmailRequest) error
+	ResendVerificationEmail(ctx context.Context, resendReq ResendVerificationRequest) error
+	ForgotPassword(ctx context.Context, forgotReq ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, resetReq ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, changeReq ChangePasswordRequest) error
+
+	// Two factor authentication
+	LoginTOTP(ctx context.Context, loginReq LoginTOTPRequest) (LoginResponse, error)
+	EnrollTOTP(ctx context.Context) (EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, confirmReq ConfirmTOTPRequest) (RecoveryCodesResponse, error)
+	DisableTOTP(ctx context.Context, disableReq DisableTOTPRequest) error
+
+	// Phone number verification and passwordless login
+	RequestPhoneVerification(ctx context.Context) error
+	VerifyPhone(ctx context.Context, verifyReq VerifyPhoneRequest) error
+	RequestPhoneLogin(ctx context.Context, phoneReq PhoneLoginOTPRequest) error
+	LoginPhone(ctx context.Context, loginReq PhoneLoginRequest) (LoginResponse, error)
+
+	// Login using external OIDC provider
+	AuthorizeOIDC(ctx context.Context, providerName string) (OIDCAuthorizeResponse, error)
+	LoginOIDC(ctx context.Context, callbackReq OIDCCallbackRequest) (LoginResponse, error)
+
+	// Session of the logged in user
+	GetSessions(ctx context.Context) ([]Session, error)
+	EndSession(ctx context.Context, id int) error
+
+	// Security history of the logged in user
+	GetAuthEvents(ctx context.Context, listReq ListRequest) ([]AuthEvent, int, error)
+
+	// Profile of the logged in user
+	GetProfile(ctx context.Context) (User, error)
+	UpdateProfile(ctx context.Context, profileReq UpdateProfileRequest) (User, error)
+	DeactivateAccount(ctx context.Context, deactivateReq DeactivateAccountRequest) error
+
+	// Used by admin
+	RevokeAccess(ctx context.Context, userID int) error
+	UnlockAccount(ctx context.Context, userID int) error
+	BlockAccount(ctx context.Context, userID int, reason string) error
+	UnblockAccount(ctx context.Context, userID int, reason string) error
+	ResetTOTP(ctx context.Context, userID int) error
+}
+
+// AuthEventWriter records security related event of the user, the client info taken from the context.
+//
+//counterfeiter:generate -o ./mock . AuthEventWriter
+type AuthEventWriter interface {
+	Write(ctx context.Context, authEvent AuthEvent)
 }
 
 //counterfeiter:generate -o ./mock . Repository
 type Repository interface {
 	FindUserByEmail(ctx context.Context, email string) (User, error)
+	FindUserByID(ctx context.Context, id int) (User, error)
+	FindUserByVerifiedPhone(ctx context.Context, phoneNumber string) (User, error)
 	RegisterNewUser(ctx context.Context, user User) (User, error)
+
+	// Refresh token
+	SaveRefreshToken(ctx context.Context, tokenHash string, refreshToken RefreshToken, ttl time.Duration) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error)
+	UseRefreshToken(ctx context.Context, tokenHash string, ttl time.Duration) (bool, error)
+	SaveTokenFamily(ctx context.Context, familyID string, userID int, ttl time.Duration) error
+	IsTokenFamilyActive(ctx context.Context, familyID string) (bool, error)
+	RevokeTokenFamily(ctx context.Context, familyID string) error
+
+	// Single use token, e.g. email verification
+	SaveSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string, userID int, ttl time.Duration) error
+	RevokeSingleUseTokens(ctx context.Context, purpose TokenPurpose, userID int) error
+	GetSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (int, error)
+	UseSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (int, error)
+	AcquireRateLimit(ctx context.Context, action, subject string, interval time.Duration) (bool, error)
+	GetRateLimit(ctx context.Context, action, subject string) (time.Duration, error)
+	ClearRateLimit(ctx context.Context, action, subject string) error
+	IncrementCounter(ctx context.Context, counter, subject string, ttl time.Duration) (int64, error)
+	ResetCounter(ctx context.Context, counter, subject string) error
+	ClearRateLimits(ctx context.Context, action, subjectPrefix string) error
+	ResetCounters(ctx context.Context, counter, subjectPrefix string) error
+
+	UpdateEmailVerified(ctx context.Context, id int) error
+	UpdatePassword(ctx context.Context, id int, hashedPassword string) error
+	RehashPassword(ctx context.Context, id int, oldHash, newHash string) error
+	UpdateTOTP(ctx context.Context, id int, encryptedSecret string, enabledAt *time.Time) error
+	UpdateProfile(ctx context.Context, id int, fullName, phoneNumber string, phoneVerifiedAt *time.Time) error
+	UpdateEmail(ctx context.Context, id int, email string) error
+	UpdatePhoneVerified(ctx context.Context, id int, phoneNumber string) error
+	UpdateStatus(ctx context.Context, id int, status bool) error
+	DeactivateUser(ctx context.Context, id int) error
+	RevokeAPIKeys(ctx context.Context, userID int) error
+
+	// Login session
+	SaveSession(ctx context.Context, session Session) error
+	UpdateSessionLastSeen(ctx context.Context, familyID, ipAddress, userAgent string) error
+	GetActiveSessions(ctx context.Context, userID int, since time.Time) ([]Session, error)
+	GetSession(ctx context.Context, userID, id int) (Session, error)
+	RevokeSession(ctx context.Context, familyID string) error
+
+	// Phone OTP
+	SavePhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string, phoneOTP PhoneOTP, ttl time.Duration) error
+	GetPhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string) (PhoneOTP, error)
+	DeletePhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string) (bool, error)
+
+	// External identity
+	FindIdentity(ctx context.Context, provider, subject string) (UserIdentity, error)
+	SaveIdentity(ctx context.Context, identity UserIdentity) error
+	RegisterIdentityUser(ctx context.Context, user User, identity UserIdentity) (User, error)
+	SaveOIDCState(ctx context.Context, stateHash string, oidcState OIDCState, ttl time.Duration) error
+	UseOIDCState(ctx context.Context, stateHash string) (OIDCState, error)
+
+	// Email change waiting for verification
+	SaveEmailChange(ctx context.Context, tokenHash string, emailChange EmailChange, ttl time.Duration) error
+	UseEmailChange(ctx context.Context, tokenHash string) (EmailChange, error)
+
+	// Recovery code
+	ReplaceRecoveryCodes(ctx context.Context, userID int, recoveryCodes []RecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+
+	SaveAuthEvent(ctx context.Context, authEvent AuthEvent) error
+	GetAuthEvents(ctx context.Context, userID, page, limit int) ([]AuthEvent, int, error)
 }
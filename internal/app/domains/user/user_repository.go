@@ -2,22 +2,30 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
-	"go-skeleton-code/pkg/log"
-
+	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
+
+	gormpkg "go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/log"
 )
 
 type repository struct {
 	readDB  *gorm.DB
 	writeDB *gorm.DB
+	redis   *redis.Client
 }
 
 // NewRepository returns new user Repository.
-func NewRepository(readDB *gorm.DB, writeDB *gorm.DB) *repository {
+func NewRepository(readDB *gorm.DB, writeDB *gorm.DB, redis *redis.Client) *repository {
 	return &repository{
 		readDB:  readDB,
 		writeDB: writeDB,
+		redis:   redis,
 	}
 }
 
@@ -25,7 +33,31 @@ func (r *repository) FindUserByEmail(ctx context.Context, email string) (User, e
 	defer log.Context(ctx).RecordDuration("find user by email").Stop()
 
 	var user User
-	if err := r.readDB.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.readDB.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *repository) FindUserByID(ctx context.Context, id int) (User, error) {
+	defer log.Context(ctx).RecordDuration("find user by id").Stop()
+
+	var user User
+	if err := r.readDB.WithContext(ctx).First(&user, id).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *repository) FindUserByVerifiedPhone(ctx context.Context, phoneNumber string) (User, error) {
+	defer log.Context(ctx).RecordDuration("find user by verified phone").Stop()
+
+	var user User
+	if err := r.readDB.WithContext(ctx).Where("phone_number = ? AND phone_verified_at IS NOT NULL", phoneNumber).First(&user).Error; err != nil {
 		log.Context(ctx).Error(err)
 		return User{}, err
 	}
@@ -43,3 +75,773 @@ func (r *repository) RegisterNewUser(ctx context.Context, user User) (User, erro
 
 	return user, nil
 }
+
+func (r *repository) UpdateEmailVerified(ctx context.Context, id int) error {
+	defer log.Context(ctx).RecordDuration("update email verified").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", time.Now()).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdatePassword(ctx context.Context, id int, hashedPassword string) error {
+	defer log.Context(ctx).RecordDuration("update user password").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("password", hashedPassword).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// RehashPassword only replaces the hash when the password not changed since the old hash read.
+func (r *repository) RehashPassword(ctx context.Context, id int, oldHash, newHash string) error {
+	defer log.Context(ctx).RecordDuration("rehash user password").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND password = ?", id, oldHash).
+		Update("password", newHash).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateTOTP(ctx context.Context, id int, encryptedSecret string, enabledAt *time.Time) error {
+	defer log.Context(ctx).RecordDuration("update user totp").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	err := writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"totp_secret": encryptedSecret, "totp_enabled_at": enabledAt}).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProfile stores the phone verification decided by the caller, nil when the phone number changed.
+func (r *repository) UpdateProfile(ctx context.Context, id int, fullName, phoneNumber string, phoneVerifiedAt *time.Time) error {
+	defer log.Context(ctx).RecordDuration("update user profile").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"full_name":         fullName,
+			"phone_number":      phoneNumber,
+			"phone_verified_at": phoneVerifiedAt,
+		}).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateEmail replaces the email with already verified one.
+func (r *repository) UpdateEmail(ctx context.Context, id int, email string) error {
+	defer log.Context(ctx).RecordDuration("update user email").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"email": email, "email_verified_at": time.Now()}).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePhoneVerified only marks the phone number still set in the profile.
+func (r *repository) UpdatePhoneVerified(ctx context.Context, id int, phoneNumber string) error {
+	defer log.Context(ctx).RecordDuration("update phone verified").Stop()
+
+	result := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND phone_number = ?", id, phoneNumber).
+		Update("phone_verified_at", time.Now())
+	if result.Error != nil {
+		log.Context(ctx).Error(result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus returns gorm.ErrRecordNotFound for deactivated user.
+func (r *repository) UpdateStatus(ctx context.Context, id int, status bool) error {
+	defer log.Context(ctx).RecordDuration("update user status").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	result := writeDB.WithContext(ctx).Model(&User{}).Where("id = ? AND deleted_at IS NULL", id).Update("status", status)
+	if result.Error != nil {
+		log.Context(ctx).Error(result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RevokeAPIKeys revokes every active API key of the user, stored in the API key table since the key never expires.
+func (r *repository) RevokeAPIKeys(ctx context.Context, userID int) error {
+	defer log.Context(ctx).RecordDuration("revoke user api keys").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	err := writeDB.WithContext(ctx).
+		Table("api_keys").
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) DeactivateUser(ctx context.Context, id int) error {
+	defer log.Context(ctx).RecordDuration("deactivate user").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"status": false, "deleted_at": time.Now()}).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes removes every previous code of the user.
+func (r *repository) ReplaceRecoveryCodes(ctx context.Context, userID int, recoveryCodes []RecoveryCode) error {
+	defer log.Context(ctx).RecordDuration("replace recovery codes").Stop()
+
+	writeDB := r.writeDB
+	if tx := gormpkg.GetTransactionFromContext(ctx); tx != nil {
+		writeDB = tx
+	}
+
+	err := writeDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(recoveryCodes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&recoveryCodes).Error
+	})
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks the code as used, returns false when the code not found or already used.
+func (r *repository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	defer log.Context(ctx).RecordDuration("use recovery code").Stop()
+
+	result := r.writeDB.WithContext(ctx).
+		Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		log.Context(ctx).Error(result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) SaveAuthEvent(ctx context.Context, authEvent AuthEvent) error {
+	defer log.Context(ctx).RecordDuration("save auth event").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&authEvent).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetAuthEvents(ctx context.Context, userID, page, limit int) ([]AuthEvent, int, error) {
+	defer log.Context(ctx).RecordDuration("get auth events").Stop()
+
+	var (
+		total      int64
+		authEvents = make([]AuthEvent, 0)
+		query      = r.readDB.WithContext(ctx).Model(&AuthEvent{}).Where("user_id = ?", userID).Session(&gorm.Session{})
+	)
+
+	if err := query.Count(&total).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	if err := query.Scopes(gormpkg.CreatePaginationQuery(page, limit, "", "")).Find(&authEvents).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return nil, 0, err
+	}
+
+	return authEvents, int(total), nil
+}
+
+func (r *repository) SaveRefreshToken(ctx context.Context, tokenHash string, refreshToken RefreshToken, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration("save refresh token").Stop()
+
+	refreshTokenJSON, err := json.Marshal(refreshToken)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if err := r.redis.Set(ctx, fmt.Sprintf(refreshTokenKey, tokenHash), refreshTokenJSON, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	defer log.Context(ctx).RecordDuration("get refresh token").Stop()
+
+	refreshTokenJSON, err := r.redis.Get(ctx, fmt.Sprintf(refreshTokenKey, tokenHash)).Bytes()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return RefreshToken{}, err
+	}
+
+	var refreshToken RefreshToken
+	if err := json.Unmarshal(refreshTokenJSON, &refreshToken); err != nil {
+		log.Context(ctx).Error(err)
+		return RefreshToken{}, err
+	}
+
+	return refreshToken, nil
+}
+
+// UseRefreshToken mark the token as used, returns false when the token already used before.
+func (r *repository) UseRefreshToken(ctx context.Context, tokenHash string, ttl time.Duration) (bool, error) {
+	defer log.Context(ctx).RecordDuration("use refresh token").Stop()
+
+	firstUse, err := r.redis.SetNX(ctx, fmt.Sprintf(refreshTokenUsedKey, tokenHash), time.Now().Unix(), ttl).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return firstUse, nil
+}
+
+func (r *repository) SaveTokenFamily(ctx context.Context, familyID string, userID int, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration("save refresh token family").Stop()
+
+	if err := r.redis.Set(ctx, fmt.Sprintf(refreshTokenFamilyKey, familyID), userID, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) IsTokenFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	defer log.Context(ctx).RecordDuration("check refresh token family").Stop()
+
+	total, err := r.redis.Exists(ctx, fmt.Sprintf(refreshTokenFamilyKey, familyID)).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *repository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	defer log.Context(ctx).RecordDuration("revoke refresh token family").Stop()
+
+	if err := r.redis.Del(ctx, fmt.Sprintf(refreshTokenFamilyKey, familyID)).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) SaveSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string, userID int, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("save %v token", purpose)).Stop()
+
+	userTokens := fmt.Sprintf(userTokenKey, purpose, userID)
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf(singleUseTokenKey, purpose, tokenHash), userID, ttl)
+		pipe.SAdd(ctx, userTokens, tokenHash)
+		pipe.Expire(ctx, userTokens, ttl)
+		return nil
+	})
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// RevokeSingleUseTokens deletes every outstanding token of the user for the purpose.
+func (r *repository) RevokeSingleUseTokens(ctx context.Context, purpose TokenPurpose, userID int) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("revoke %v tokens", purpose)).Stop()
+
+	userTokens := fmt.Sprintf(userTokenKey, purpose, userID)
+	tokenHashes, err := r.redis.SMembers(ctx, userTokens).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	keys := []string{userTokens}
+	for _, tokenHash := range tokenHashes {
+		keys = append(keys, fmt.Sprintf(singleUseTokenKey, purpose, tokenHash))
+	}
+
+	if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (int, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("get %v token", purpose)).Stop()
+
+	userID, err := r.redis.Get(ctx, fmt.Sprintf(singleUseTokenKey, purpose, tokenHash)).Int()
+	if err == redis.Nil {
+		return 0, ErrTokenNotFound
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// UseSingleUseToken returns user id of the token and delete the token, so the token can not be used twice.
+func (r *repository) UseSingleUseToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (int, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("use %v token", purpose)).Stop()
+
+	userID, err := r.redis.GetDel(ctx, fmt.Sprintf(singleUseTokenKey, purpose, tokenHash)).Int()
+	if err == redis.Nil {
+		return 0, ErrTokenNotFound
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (r *repository) SaveSession(ctx context.Context, session Session) error {
+	defer log.Context(ctx).RecordDuration("save user session").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&session).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateSessionLastSeen(ctx context.Context, familyID, ipAddress, userAgent string) error {
+	defer log.Context(ctx).RecordDuration("update user session last seen").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&Session{}).
+		Where("family_id = ?", familyID).
+		Updates(map[string]any{"ip_address": ipAddress, "user_agent": userAgent, "last_seen_at": time.Now()}).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetActiveSessions returns session not revoked and seen after the time, latest seen first.
+func (r *repository) GetActiveSessions(ctx context.Context, userID int, since time.Time) ([]Session, error) {
+	defer log.Context(ctx).RecordDuration("get active user sessions").Stop()
+
+	sessions := make([]Session, 0)
+	err := r.readDB.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, since).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *repository) GetSession(ctx context.Context, userID, id int) (Session, error) {
+	defer log.Context(ctx).RecordDuration("get user session").Stop()
+
+	var session Session
+	if err := r.readDB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&session).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *repository) RevokeSession(ctx context.Context, familyID string) error {
+	defer log.Context(ctx).RecordDuration("revoke user session").Stop()
+
+	err := r.writeDB.WithContext(ctx).
+		Model(&Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) FindIdentity(ctx context.Context, provider, subject string) (UserIdentity, error) {
+	defer log.Context(ctx).RecordDuration("find user identity").Stop()
+
+	var identity UserIdentity
+	if err := r.readDB.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return UserIdentity{}, err
+	}
+
+	return identity, nil
+}
+
+func (r *repository) SaveIdentity(ctx context.Context, identity UserIdentity) error {
+	defer log.Context(ctx).RecordDuration("save user identity").Stop()
+
+	if err := r.writeDB.WithContext(ctx).Create(&identity).Error; err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// RegisterIdentityUser creates the user and links the identity in one transaction, returns ErrIdentityLinked
+// when the identity linked by concurrent request.
+func (r *repository) RegisterIdentityUser(ctx context.Context, user User, identity UserIdentity) (User, error) {
+	defer log.Context(ctx).RecordDuration("register identity user").Stop()
+
+	err := r.writeDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		if err := tx.Create(&identity).Error; err != nil {
+			if gormpkg.IsUniqueViolation(err) {
+				return ErrIdentityLinked
+			}
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *repository) SavePhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string, phoneOTP PhoneOTP, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("save %v otp", purpose)).Stop()
+
+	phoneOTPJSON, err := json.Marshal(phoneOTP)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if err := r.redis.Set(ctx, fmt.Sprintf(singleUseTokenKey, purpose, phoneNumber), phoneOTPJSON, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetPhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string) (PhoneOTP, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("get %v otp", purpose)).Stop()
+
+	phoneOTPJSON, err := r.redis.Get(ctx, fmt.Sprintf(singleUseTokenKey, purpose, phoneNumber)).Bytes()
+	if err == redis.Nil {
+		return PhoneOTP{}, ErrTokenNotFound
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return PhoneOTP{}, err
+	}
+
+	var phoneOTP PhoneOTP
+	if err := json.Unmarshal(phoneOTPJSON, &phoneOTP); err != nil {
+		log.Context(ctx).Error(err)
+		return PhoneOTP{}, err
+	}
+
+	return phoneOTP, nil
+}
+
+// DeletePhoneOTP returns false when the OTP already deleted, e.g. used by concurrent request.
+func (r *repository) DeletePhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber string) (bool, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("delete %v otp", purpose)).Stop()
+
+	deleted, err := r.redis.Del(ctx, fmt.Sprintf(singleUseTokenKey, purpose, phoneNumber)).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+func (r *repository) SaveOIDCState(ctx context.Context, stateHash string, oidcState OIDCState, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration("save oidc state").Stop()
+
+	oidcStateJSON, err := json.Marshal(oidcState)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if err := r.redis.Set(ctx, fmt.Sprintf(singleUseTokenKey, TokenPurposeOIDCState, stateHash), oidcStateJSON, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UseOIDCState(ctx context.Context, stateHash string) (OIDCState, error) {
+	defer log.Context(ctx).RecordDuration("use oidc state").Stop()
+
+	oidcStateJSON, err := r.redis.GetDel(ctx, fmt.Sprintf(singleUseTokenKey, TokenPurposeOIDCState, stateHash)).Bytes()
+	if err == redis.Nil {
+		return OIDCState{}, ErrTokenNotFound
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return OIDCState{}, err
+	}
+
+	var oidcState OIDCState
+	if err := json.Unmarshal(oidcStateJSON, &oidcState); err != nil {
+		log.Context(ctx).Error(err)
+		return OIDCState{}, err
+	}
+
+	return oidcState, nil
+}
+
+func (r *repository) SaveEmailChange(ctx context.Context, tokenHash string, emailChange EmailChange, ttl time.Duration) error {
+	defer log.Context(ctx).RecordDuration("save email change").Stop()
+
+	emailChangeJSON, err := json.Marshal(emailChange)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if err := r.redis.Set(ctx, fmt.Sprintf(singleUseTokenKey, TokenPurposeEmailChange, tokenHash), emailChangeJSON, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UseEmailChange(ctx context.Context, tokenHash string) (EmailChange, error) {
+	defer log.Context(ctx).RecordDuration("use email change").Stop()
+
+	emailChangeJSON, err := r.redis.GetDel(ctx, fmt.Sprintf(singleUseTokenKey, TokenPurposeEmailChange, tokenHash)).Bytes()
+	if err == redis.Nil {
+		return EmailChange{}, ErrTokenNotFound
+	}
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return EmailChange{}, err
+	}
+
+	var emailChange EmailChange
+	if err := json.Unmarshal(emailChangeJSON, &emailChange); err != nil {
+		log.Context(ctx).Error(err)
+		return EmailChange{}, err
+	}
+
+	return emailChange, nil
+}
+
+// AcquireRateLimit returns false when the action for the subject already performed within the interval.
+func (r *repository) AcquireRateLimit(ctx context.Context, action, subject string, interval time.Duration) (bool, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("acquire %v rate limit", action)).Stop()
+
+	acquired, err := r.redis.SetNX(ctx, fmt.Sprintf(rateLimitKey, action, subject), time.Now().Unix(), interval).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+// GetRateLimit returns remaining time before the action allowed, zero when the action allowed.
+func (r *repository) GetRateLimit(ctx context.Context, action, subject string) (time.Duration, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("get %v rate limit", action)).Stop()
+
+	remaining, err := r.redis.PTTL(ctx, fmt.Sprintf(rateLimitKey, action, subject)).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	// Negative value means the key not exist
+	return max(remaining, 0), nil
+}
+
+func (r *repository) ClearRateLimit(ctx context.Context, action, subject string) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("clear %v rate limit", action)).Stop()
+
+	if err := r.redis.Del(ctx, fmt.Sprintf(rateLimitKey, action, subject)).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearRateLimits clears the action of every subject starting with the prefix.
+func (r *repository) ClearRateLimits(ctx context.Context, action, subjectPrefix string) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("clear %v rate limits", action)).Stop()
+
+	return r.deleteMatchingKeys(ctx, fmt.Sprintf(rateLimitKey, action, escapeKeyPattern(subjectPrefix)+"*"))
+}
+
+// IncrementCounter returns the counter value after increment, counter expired ttl after the first increment.
+func (r *repository) IncrementCounter(ctx context.Context, counter, subject string, ttl time.Duration) (int64, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("increment %v counter", counter)).Stop()
+
+	key := fmt.Sprintf(counterKey, counter, subject)
+
+	total, err := r.redis.Incr(ctx, key).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return 0, err
+	}
+
+	if total == 1 {
+		if err := r.redis.Expire(ctx, key, ttl).Err(); err != nil {
+			log.Context(ctx).Error(err)
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// ResetCounters resets the counter of every subject starting with the prefix.
+func (r *repository) ResetCounters(ctx context.Context, counter, subjectPrefix string) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("reset %v counters", counter)).Stop()
+
+	return r.deleteMatchingKeys(ctx, fmt.Sprintf(counterKey, counter, escapeKeyPattern(subjectPrefix)+"*"))
+}
+
+func (r *repository) deleteMatchingKeys(ctx context.Context, pattern string) error {
+	var keys []string
+
+	iterator := r.redis.Scan(ctx, 0, pattern, 100).Iterator()
+	for iterator.Next(ctx) {
+		keys = append(keys, iterator.Val())
+	}
+	if err := iterator.Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// escapeKeyPattern escapes glob character in user supplied value, such as email.
+func escapeKeyPattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`).Replace(value)
+}
+
+func (r *repository) ResetCounter(ctx context.Context, counter, subject string) error {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("reset %v counter", counter)).Stop()
+
+	if err := r.redis.Del(ctx, fmt.Sprintf(counterKey, counter, subject)).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
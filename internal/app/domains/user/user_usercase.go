@@ -2,73 +2,1479 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-skeleton-code/pkg/log"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/golang-jwt/jwt"
-	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
 	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/client"
+	"go-skeleton-code/pkg/encryption"
 	serverError "go-skeleton-code/pkg/error"
+	gormpkg "go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/mail"
+	"go-skeleton-code/pkg/oidc"
+	"go-skeleton-code/pkg/password"
+	"go-skeleton-code/pkg/sms"
+	"go-skeleton-code/pkg/totp"
 )
 
 type usecase struct {
-	securityConfig config.Security
-	userRepository Repository
-	validator      *validator.Validate
+	securityConfig  config.Security
+	jwtManager      jwt.Manager
+	revocationList  jwt.RevocationList
+	mailSender      mail.Sender
+	smsSender       sms.Sender
+	encryptor       encryption.Encryptor
+	passwordHasher  password.Hasher
+	authEventWriter AuthEventWriter
+	userRepository  Repository
+	validator       *validator.Validate
+	oidcProviders   oidc.Providers
+
+	// Verified for unknown email, so the response time does not reveal registered email
+	dummyHash string
+}
+
+// NewUsecase returns new user usecase.
+func NewUsecase(
+	securityConfig config.Security,
+	jwtManager jwt.Manager,
+	revocationList jwt.RevocationList,
+	mailSender mail.Sender,
+	smsSender sms.Sender,
+	encryptor encryption.Encryptor,
+	passwordHasher password.Hasher,
+	authEventWriter AuthEventWriter,
+	oidcProviders oidc.Providers,
+	validator *validator.Validate,
+	userRepository Repository,
+) *usecase {
+	dummyHash, err := passwordHasher.Hash(generateDummyPassword())
+	if err != nil {
+		log.Fatalf("failed hashing dummy password, %v", err)
+	}
+
+	return &usecase{
+		securityConfig:  securityConfig,
+		jwtManager:      jwtManager,
+		revocationList:  revocationList,
+		mailSender:      mailSender,
+		smsSender:       smsSender,
+		encryptor:       encryptor,
+		passwordHasher:  passwordHasher,
+		authEventWriter: authEventWriter,
+		oidcProviders:   oidcProviders,
+		validator:       validator,
+		userRepository:  userRepository,
+		dummyHash:       dummyHash,
+	}
+}
+
+func (u *usecase) Login(ctx context.Context, loginReq LoginRequest) (LoginResponse, error) {
+	if err := u.validator.StructCtx(ctx, loginReq); err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	loginReq.Email = normalizeEmail(loginReq.Email)
+	clientInfo := client.GetFromContext(ctx)
+
+	// Reject early when the account locked or still in backoff period
+	if err := u.checkLoginAllowed(ctx, loginReq.Email, clientInfo.IPAddress); err != nil {
+		return LoginResponse{}, err
+	}
+
+	// Find user detail in repository
+	userDetail, err := u.userRepository.FindUserByEmail(ctx, loginReq.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Counted and verified as well, so unknown email behave the same as wrong password
+		_ = u.passwordHasher.Verify(u.dummyHash, loginReq.Password)
+		return LoginResponse{}, u.recordLoginFailure(ctx, User{Email: loginReq.Email}, err)
+	}
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Comparing the password with the hash
+	if err = u.passwordHasher.Verify(userDetail.Password, loginReq.Password); err != nil {
+		log.Context(ctx).Error(err)
+		return LoginResponse{}, u.recordLoginFailure(ctx, userDetail, err)
+	}
+
+	u.rehashPassword(ctx, userDetail, loginReq.Password)
+
+	return u.completeLogin(ctx, userDetail)
+}
+
+// rehashPassword upgrades hash created using previous algorithm or parameters, failure only logged
+// since the old hash still valid.
+func (u *usecase) rehashPassword(ctx context.Context, userDetail User, plainPassword string) {
+	if !u.passwordHasher.NeedsRehash(userDetail.Password) {
+		return
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(plainPassword)
+	if err != nil {
+		log.Context(ctx).Errorf("failed rehashing password, %v", err)
+		return
+	}
+
+	// Password changed by concurrent request must not be overwritten by the old password
+	if err := u.userRepository.RehashPassword(ctx, userDetail.ID, userDetail.Password, hashedPassword); err != nil {
+		log.Context(ctx).Errorf("failed updating rehashed password, %v", err)
+	}
+}
+
+// completeLogin starts session for authenticated user, or asks for the OTP when two factor authentication enabled.
+// Failed login only reset after every factor verified.
+func (u *usecase) completeLogin(ctx context.Context, userDetail User) (LoginResponse, error) {
+	if !userDetail.Status {
+		return LoginResponse{}, serverError.ErrUserBlocked(ErrUserInactive)
+	}
+
+	if userDetail.EmailVerifiedAt == nil {
+		return LoginResponse{}, serverError.ErrEmailNotVerified(ErrEmailNotVerified)
+	}
+
+	// First factor verified, token only issued after the OTP verified
+	if userDetail.IsTOTPEnabled() {
+		loginToken, err := u.createSingleUseToken(ctx, TokenPurposeTOTPLogin, userDetail.ID, u.securityConfig.TOTP.LoginTTL)
+		if err != nil {
+			return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+		}
+
+		return LoginResponse{Email: userDetail.Email, TOTPRequired: true, LoginToken: loginToken}, nil
+	}
+
+	u.resetLoginFailure(ctx, userDetail.Email)
+	return u.startSession(ctx, userDetail)
+}
+
+// LoginTOTP completes login of user with two factor authentication enabled.
+func (u *usecase) LoginTOTP(ctx context.Context, loginReq LoginTOTPRequest) (LoginResponse, error) {
+	if err := u.validator.StructCtx(ctx, loginReq); err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	tokenHash := hashToken(loginReq.LoginToken)
+
+	attempt, err := u.userRepository.IncrementCounter(ctx, counterTOTPAttempt, tokenHash, u.securityConfig.TOTP.LoginTTL)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Login token no longer usable, user must start again from the password
+	if attempt > int64(u.securityConfig.TOTP.MaxAttempts) {
+		if _, err := u.userRepository.UseSingleUseToken(ctx, TokenPurposeTOTPLogin, tokenHash); err != nil && !errors.Is(err, ErrTokenNotFound) {
+			return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+		}
+		return LoginResponse{}, serverError.ErrInvalidOTP(ErrTooManyAttempts)
+	}
+
+	userID, err := u.userRepository.GetSingleUseToken(ctx, TokenPurposeTOTPLogin, tokenHash)
+	if errors.Is(err, ErrTokenNotFound) {
+		return LoginResponse{}, serverError.ErrInvalidVerificationToken(err)
+	}
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Lockout also applies to the second factor, locked account can not complete pending login
+	if err := u.checkLoginAllowed(ctx, userDetail.Email, client.GetFromContext(ctx).IPAddress); err != nil {
+		return LoginResponse{}, err
+	}
+
+	if err := u.verifySecondFactorLimited(ctx, userDetail, loginReq.Code); err != nil {
+		if lockErr := u.recordLoginFailure(ctx, userDetail, err); errors.Is(lockErr, ErrAccountLocked) {
+			return LoginResponse{}, lockErr
+		}
+		return LoginResponse{}, err
+	}
+
+	// Consume the login token, concurrent request with the same token only succeed once
+	if _, err := u.userRepository.UseSingleUseToken(ctx, TokenPurposeTOTPLogin, tokenHash); errors.Is(err, ErrTokenNotFound) {
+		return LoginResponse{}, serverError.ErrInvalidVerificationToken(err)
+	} else if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.resetLoginFailure(ctx, userDetail.Email)
+	return u.startSession(ctx, userDetail)
+}
+
+// EnrollTOTP generates new secret, two factor authentication enabled after the first OTP confirmed.
+func (u *usecase) EnrollTOTP(ctx context.Context) (EnrollTOTPResponse, error) {
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return EnrollTOTPResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.IsTOTPEnabled() {
+		return EnrollTOTPResponse{}, serverError.ErrInvalidRequest(ErrTOTPAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return EnrollTOTPResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	encryptedSecret, err := u.encryptor.Encrypt(secret)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return EnrollTOTPResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	if err := u.userRepository.UpdateTOTP(ctx, userDetail.ID, encryptedSecret, nil); err != nil {
+		return EnrollTOTPResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	enrollResponse := EnrollTOTPResponse{
+		Secret: secret,
+		URI:    totp.URI(u.securityConfig.TOTP.Issuer, userDetail.Email, secret),
+	}
+
+	return enrollResponse, nil
+}
+
+// ConfirmTOTP enables two factor authentication and returns the recovery codes.
+func (u *usecase) ConfirmTOTP(ctx context.Context, confirmReq ConfirmTOTPRequest) (RecoveryCodesResponse, error) {
+	if err := u.validator.StructCtx(ctx, confirmReq); err != nil {
+		return RecoveryCodesResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return RecoveryCodesResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.IsTOTPEnabled() {
+		return RecoveryCodesResponse{}, serverError.ErrInvalidRequest(ErrTOTPAlreadyEnabled)
+	}
+
+	if userDetail.TOTPSecret == "" {
+		return RecoveryCodesResponse{}, serverError.ErrInvalidRequest(ErrTOTPNotEnrolled)
+	}
+
+	// Recovery code not accepted, the authenticator must be proven working
+	if err := u.limitTOTPAttempt(ctx, userDetail, func() error {
+		return u.verifyTOTP(ctx, userDetail, confirmReq.Code)
+	}); err != nil {
+		return RecoveryCodesResponse{}, err
+	}
+
+	recoveryCodes, err := u.generateRecoveryCodes(ctx, userDetail.ID)
+	if err != nil {
+		return RecoveryCodesResponse{}, err
+	}
+
+	now := time.Now()
+	if err := u.userRepository.UpdateTOTP(ctx, userDetail.ID, userDetail.TOTPSecret, &now); err != nil {
+		return RecoveryCodesResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventTOTPEnabled,
+	})
+
+	return RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTOTP requires the password and the second factor.
+func (u *usecase) DisableTOTP(ctx context.Context, disableReq DisableTOTPRequest) error {
+	if err := u.validator.StructCtx(ctx, disableReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if !userDetail.IsTOTPEnabled() {
+		return serverError.ErrInvalidRequest(ErrTOTPNotEnabled)
+	}
+
+	if err = u.passwordHasher.Verify(userDetail.Password, disableReq.Password); err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrInvalidPassword(err)
+	}
+
+	if err := u.verifySecondFactorLimited(ctx, userDetail, disableReq.Code); err != nil {
+		return err
+	}
+
+	if err := u.userRepository.UpdateTOTP(ctx, userDetail.ID, "", nil); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.userRepository.ReplaceRecoveryCodes(ctx, userDetail.ID, nil); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventTOTPDisabled,
+	})
+
+	return nil
+}
+
+// verifySecondFactorLimited is verifySecondFactor with the attempt limit per user.
+func (u *usecase) verifySecondFactorLimited(ctx context.Context, userDetail User, code string) error {
+	return u.limitTOTPAttempt(ctx, userDetail, func() error {
+		return u.verifySecondFactor(ctx, userDetail, code)
+	})
+}
+
+// limitTOTPAttempt counts failed OTP per user across login tokens and sessions, verification
+// rejected after too many failure within the login protection window. Counter reset on success.
+func (u *usecase) limitTOTPAttempt(ctx context.Context, userDetail User, verify func() error) error {
+	subject := strconv.Itoa(userDetail.ID)
+
+	attempt, err := u.userRepository.IncrementCounter(ctx, counterTOTPFailedUser, subject, u.securityConfig.LoginProtection.Window)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if attempt > int64(u.securityConfig.TOTP.MaxAttempts) {
+		return serverError.ErrTooManyRequests(ErrTooManyAttempts)
+	}
+
+	if err := verify(); err != nil {
+		return err
+	}
+
+	if err := u.userRepository.ResetCounter(ctx, counterTOTPFailedUser, subject); err != nil {
+		log.Context(ctx).Error(err)
+	}
+
+	return nil
+}
+
+// verifySecondFactor accepts the OTP or one of the unused recovery code.
+func (u *usecase) verifySecondFactor(ctx context.Context, userDetail User, code string) error {
+	err := u.verifyTOTP(ctx, userDetail, code)
+	if !errors.Is(err, ErrInvalidOTP) {
+		return err
+	}
+
+	used, err := u.userRepository.UseRecoveryCode(ctx, userDetail.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !used {
+		return serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	log.Context(ctx).Warnf("recovery code used by user %v", userDetail.ID)
+	return nil
+}
+
+func (u *usecase) verifyTOTP(ctx context.Context, userDetail User, code string) error {
+	secret, err := u.encryptor.Decrypt(userDetail.TOTPSecret)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrGeneralError(err)
+	}
+
+	step, valid := totp.Validate(secret, strings.TrimSpace(code), time.Now(), u.securityConfig.TOTP.Skew)
+	if !valid {
+		return serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	// The same code can not be used twice, even within its validity window
+	replayWindow := time.Duration(2*u.securityConfig.TOTP.Skew+1) * totp.Period
+	firstUse, err := u.userRepository.AcquireRateLimit(ctx, rateLimitTOTPStep, fmt.Sprintf("%v:%v", userDetail.ID, step), replayWindow)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !firstUse {
+		return serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes replaces every previous recovery code, only the hash stored.
+func (u *usecase) generateRecoveryCodes(ctx context.Context, userID int) ([]string, error) {
+	var (
+		codes         = make([]string, 0, u.securityConfig.TOTP.RecoveryCodes)
+		recoveryCodes = make([]RecoveryCode, 0, u.securityConfig.TOTP.RecoveryCodes)
+	)
+
+	for i := 0; i < u.securityConfig.TOTP.RecoveryCodes; i++ {
+		randomBytes := make([]byte, 5)
+		if _, err := rand.Read(randomBytes); err != nil {
+			log.Context(ctx).Error(err)
+			return nil, serverError.ErrGeneralError(err)
+		}
+
+		// Formatted as xxxx-xxxx for readability
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(randomBytes))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		recoveryCodes = append(recoveryCodes, RecoveryCode{UserID: userID, CodeHash: hashToken(code)})
+	}
+
+	if err := u.userRepository.ReplaceRecoveryCodes(ctx, userID, recoveryCodes); err != nil {
+		return nil, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return codes, nil
+}
+
+// UnlockAccount removes lockout and failed login history of the user.
+func (u *usecase) UnlockAccount(ctx context.Context, userID int) error {
+	userDetail, err := u.findUserForAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Lockout applied per IP address, clear every one of them
+	if err := u.userRepository.ClearRateLimits(ctx, rateLimitLockout, lockoutSubject(userDetail.Email, "")); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.userRepository.ResetCounters(ctx, counterLoginFailedLock, lockoutSubject(userDetail.Email, "")); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.resetLoginFailure(ctx, userDetail.Email)
+
+	// Phone login locked by wrong OTP
+	if userDetail.PhoneNumber != "" {
+		if err := u.userRepository.ClearRateLimit(ctx, rateLimitPhoneLockout, userDetail.PhoneNumber); err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+
+		if err := u.userRepository.ResetCounter(ctx, counterPhoneOTPAttempt, userDetail.PhoneNumber); err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventAccountUnlocked,
+		Detail: fmt.Sprintf("unlocked by admin %v", jwt.GetPayloadFromContext(ctx).UserID),
+	})
+
+	return nil
+}
+
+// BlockAccount prevents the user from login and trading, every issued token revoked.
+func (u *usecase) BlockAccount(ctx context.Context, userID int, reason string) error {
+	userDetail, err := u.findUserForAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepository.UpdateStatus(ctx, userDetail.ID, false); errors.Is(err, gorm.ErrRecordNotFound) {
+		return serverError.ErrInvalidRequest(ErrUserDeactivated)
+	} else if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.revokeAccess(ctx, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventAccountBlocked,
+		Detail: fmt.Sprintf("blocked by admin %v, %v", jwt.GetPayloadFromContext(ctx).UserID, reason),
+	})
+
+	return nil
+}
+
+func (u *usecase) UnblockAccount(ctx context.Context, userID int, reason string) error {
+	userDetail, err := u.findUserForAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepository.UpdateStatus(ctx, userDetail.ID, true); errors.Is(err, gorm.ErrRecordNotFound) {
+		return serverError.ErrInvalidRequest(ErrUserDeactivated)
+	} else if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventAccountUnblocked,
+		Detail: fmt.Sprintf("unblocked by admin %v, %v", jwt.GetPayloadFromContext(ctx).UserID, reason),
+	})
+
+	return nil
+}
+
+// RevokeAccess logs the user out from every device and revokes every API key of the user.
+func (u *usecase) RevokeAccess(ctx context.Context, userID int) error {
+	userDetail, err := u.findUserForAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.revokeAccess(ctx, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return nil
+}
+
+// revokeAccess revokes every token issued before now and every API key of the user. API key never expires
+// while the token revocation expires after the longest token lifetime, so the API key revoked in the database.
+func (u *usecase) revokeAccess(ctx context.Context, userID int) error {
+	if err := u.userRepository.RevokeAPIKeys(ctx, userID); err != nil {
+		return err
+	}
+
+	return u.revocationList.RevokeUser(ctx, userID)
+}
+
+// ResetTOTP disables two factor authentication of the user who lost the authenticator and the recovery codes.
+func (u *usecase) ResetTOTP(ctx context.Context, userID int) error {
+	userDetail, err := u.findUserForAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepository.UpdateTOTP(ctx, userDetail.ID, "", nil); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.userRepository.ReplaceRecoveryCodes(ctx, userDetail.ID, nil); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventTOTPReset,
+		Detail: fmt.Sprintf("reset by admin %v", jwt.GetPayloadFromContext(ctx).UserID),
+	})
+
+	return nil
+}
+
+func (u *usecase) findUserForAdmin(ctx context.Context, userID int) (User, error) {
+	userDetail, err := u.userRepository.FindUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, serverError.ErrDataNotFound(err)
+	}
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return userDetail, nil
+}
+
+// checkLoginAllowed rejects locked email and IP address combination, or email and IP address in backoff.
+func (u *usecase) checkLoginAllowed(ctx context.Context, email, ipAddress string) error {
+	remaining, err := u.userRepository.GetRateLimit(ctx, rateLimitLockout, lockoutSubject(email, ipAddress))
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if remaining > 0 {
+		return serverError.ErrAccountLocked(fmt.Errorf("%w, retry in %v", ErrAccountLocked, remaining.Round(time.Second)))
+	}
+
+	backoffSubjects := map[string]string{rateLimitLoginEmail: email}
+	if ipAddress != "" {
+		backoffSubjects[rateLimitLoginIP] = ipAddress
+	}
+
+	for action, subject := range backoffSubjects {
+		remaining, err := u.userRepository.GetRateLimit(ctx, action, subject)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+		if remaining > 0 {
+			return serverError.ErrTooManyRequests(fmt.Errorf("%w, retry in %v", ErrRateLimited, remaining.Round(time.Second)))
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts the failure per email and per IP address, then applies backoff or lockout.
+// Lockout only applies to the IP address the failure came from, so other client can not lock the owner out,
+// while the escalating email backoff still slows attempt distributed across IP addresses.
+// Returns the error for the client, redis failure only logged.
+func (u *usecase) recordLoginFailure(ctx context.Context, userDetail User, cause error) error {
+	var (
+		protection = u.securityConfig.LoginProtection
+		clientInfo = client.GetFromContext(ctx)
+		lockout    = lockoutSubject(userDetail.Email, clientInfo.IPAddress)
+	)
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventLoginFailed,
+		Detail: cause.Error(),
+	})
+
+	emailFailures, err := u.userRepository.IncrementCounter(ctx, counterLoginFailedEmail, userDetail.Email, protection.Window)
+	if err != nil {
+		return serverError.ErrInvalidUsernameOrPassword(cause)
+	}
+
+	lockoutFailures, err := u.userRepository.IncrementCounter(ctx, counterLoginFailedLock, lockout, protection.Window)
+	if err != nil {
+		return serverError.ErrInvalidUsernameOrPassword(cause)
+	}
+
+	if lockoutFailures >= int64(protection.LockoutThreshold) {
+		locked, err := u.userRepository.AcquireRateLimit(ctx, rateLimitLockout, lockout, protection.LockoutDuration)
+		if err != nil {
+			return serverError.ErrInvalidUsernameOrPassword(cause)
+		}
+
+		if locked {
+			// Start counting again after the lockout ended
+			if err := u.userRepository.ResetCounter(ctx, counterLoginFailedLock, lockout); err != nil {
+				log.Context(ctx).Error(err)
+			}
+
+			u.authEventWriter.Write(ctx, AuthEvent{
+				UserID: userDetail.ID,
+				Email:  userDetail.Email,
+				Type:   AuthEventAccountLocked,
+				Detail: fmt.Sprintf("%v failed login from %v within %v, locked for %v", lockoutFailures, clientInfo.IPAddress, protection.Window, protection.LockoutDuration),
+			})
+		}
+
+		return serverError.ErrAccountLocked(ErrAccountLocked)
+	}
+
+	if backoff := u.loginBackoff(emailFailures, protection.BackoffThreshold); backoff > 0 {
+		if _, err := u.userRepository.AcquireRateLimit(ctx, rateLimitLoginEmail, userDetail.Email, backoff); err != nil {
+			return serverError.ErrInvalidUsernameOrPassword(cause)
+		}
+	}
+
+	if clientInfo.IPAddress != "" {
+		ipFailures, err := u.userRepository.IncrementCounter(ctx, counterLoginFailedIP, clientInfo.IPAddress, protection.Window)
+		if err != nil {
+			return serverError.ErrInvalidUsernameOrPassword(cause)
+		}
+
+		if backoff := u.loginBackoff(ipFailures, protection.IPThreshold); backoff > 0 {
+			if _, err := u.userRepository.AcquireRateLimit(ctx, rateLimitLoginIP, clientInfo.IPAddress, backoff); err != nil {
+				return serverError.ErrInvalidUsernameOrPassword(cause)
+			}
+		}
+	}
+
+	return serverError.ErrInvalidUsernameOrPassword(cause)
+}
+
+// resetLoginFailure only resets the email, IP address may be shared by other user.
+func (u *usecase) resetLoginFailure(ctx context.Context, email string) {
+	if err := u.userRepository.ResetCounter(ctx, counterLoginFailedEmail, email); err != nil {
+		log.Context(ctx).Error(err)
+	}
+
+	if err := u.userRepository.ResetCounter(ctx, counterLoginFailedLock, lockoutSubject(email, client.GetFromContext(ctx).IPAddress)); err != nil {
+		log.Context(ctx).Error(err)
+	}
+
+	if err := u.userRepository.ClearRateLimit(ctx, rateLimitLoginEmail, email); err != nil {
+		log.Context(ctx).Error(err)
+	}
+}
+
+// lockoutSubject combines the email and the IP address, empty IP address returns the prefix of every IP address.
+func lockoutSubject(email, ipAddress string) string {
+	return fmt.Sprintf("%v|%v", email, ipAddress)
+}
+
+// loginBackoff doubles the backoff on every failure after the threshold.
+func (u *usecase) loginBackoff(failures int64, threshold int) time.Duration {
+	if failures < int64(threshold) {
+		return 0
+	}
+
+	protection := u.securityConfig.LoginProtection
+	exponent := failures - int64(threshold)
+	if exponent > 30 {
+		return protection.BackoffMax
+	}
+
+	return min(protection.BackoffBase<<exponent, protection.BackoffMax)
+}
+
+// RequestPhoneVerification sends OTP to the phone number in the profile.
+func (u *usecase) RequestPhoneVerification(ctx context.Context) error {
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.PhoneNumber == "" {
+		return serverError.ErrInvalidRequest(ErrPhoneNumberMissing)
+	}
+
+	if userDetail.PhoneVerifiedAt != nil {
+		return serverError.ErrInvalidRequest(ErrPhoneAlreadyVerified)
+	}
+
+	if err := u.limitPhoneOTP(ctx, userDetail.PhoneNumber); err != nil {
+		return err
+	}
+
+	return u.sendPhoneOTP(ctx, TokenPurposePhoneVerification, userDetail)
+}
+
+// VerifyPhone marks the phone number verified, the OTP only valid for the phone number it sent to.
+func (u *usecase) VerifyPhone(ctx context.Context, verifyReq VerifyPhoneRequest) error {
+	if err := u.validator.StructCtx(ctx, verifyReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.PhoneNumber == "" {
+		return serverError.ErrInvalidRequest(ErrPhoneNumberMissing)
+	}
+
+	if userDetail.PhoneVerifiedAt != nil {
+		return serverError.ErrInvalidRequest(ErrPhoneAlreadyVerified)
+	}
+
+	userID, err := u.verifyPhoneOTP(ctx, TokenPurposePhoneVerification, userDetail.PhoneNumber, verifyReq.Code)
+	if err != nil {
+		return err
+	}
+	if userID != userDetail.ID {
+		return serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	err = u.userRepository.UpdatePhoneVerified(ctx, userDetail.ID, userDetail.PhoneNumber)
+	if gormpkg.IsUniqueViolation(err) {
+		return serverError.ErrConflict(ErrPhoneRegistered)
+	}
+	// Phone number changed after the OTP sent
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventPhoneVerified,
+	})
+
+	return nil
+}
+
+// RequestPhoneLogin rate limits the phone number before looking it up and sends the OTP in background, so
+// unknown or unverified phone number gets the same response as registered phone number.
+func (u *usecase) RequestPhoneLogin(ctx context.Context, phoneReq PhoneLoginOTPRequest) error {
+	if !u.securityConfig.PhoneOTP.LoginEnabled {
+		return serverError.ErrInvalidRequest(ErrPhoneLoginDisabled)
+	}
+
+	if err := u.validator.StructCtx(ctx, phoneReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	if err := u.limitPhoneOTP(ctx, phoneReq.PhoneNumber); err != nil {
+		return err
+	}
+
+	runInBackground(ctx, func(ctx context.Context) {
+		u.sendPhoneLogin(ctx, phoneReq.PhoneNumber)
+	})
+
+	return nil
+}
+
+// sendPhoneLogin runs in background, error only logged.
+func (u *usecase) sendPhoneLogin(ctx context.Context, phoneNumber string) {
+	userDetail, err := u.userRepository.FindUserByVerifiedPhone(ctx, phoneNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err != nil {
+		log.Context(ctx).Errorf("failed finding user for phone login, %v", err)
+		return
+	}
+
+	if err := u.sendPhoneOTP(ctx, TokenPurposePhoneLogin, userDetail); err != nil {
+		log.Context(ctx).Errorf("failed sending phone login otp, %v", err)
+	}
+}
+
+// LoginPhone authenticates using OTP sent to the verified phone number, two factor authentication
+// still required when enabled.
+func (u *usecase) LoginPhone(ctx context.Context, loginReq PhoneLoginRequest) (LoginResponse, error) {
+	if !u.securityConfig.PhoneOTP.LoginEnabled {
+		return LoginResponse{}, serverError.ErrInvalidRequest(ErrPhoneLoginDisabled)
+	}
+
+	if err := u.validator.StructCtx(ctx, loginReq); err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByVerifiedPhone(ctx, loginReq.PhoneNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return LoginResponse{}, serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Same lockout and backoff as password login of the account
+	if err := u.checkLoginAllowed(ctx, userDetail.Email, client.GetFromContext(ctx).IPAddress); err != nil {
+		return LoginResponse{}, err
+	}
+
+	userID, err := u.verifyPhoneOTP(ctx, TokenPurposePhoneLogin, loginReq.PhoneNumber, loginReq.Code)
+	if err != nil {
+		u.authEventWriter.Write(ctx, AuthEvent{
+			UserID: userDetail.ID,
+			Email:  userDetail.Email,
+			Type:   AuthEventLoginFailed,
+			Detail: fmt.Sprintf("phone %v, %v", loginReq.PhoneNumber, err),
+		})
+		return LoginResponse{}, err
+	}
+
+	// Phone number changed or verified by other user after the OTP sent
+	if userID != userDetail.ID {
+		return LoginResponse{}, serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	return u.completeLogin(ctx, userDetail)
+}
+
+// limitPhoneOTP applies the resend interval and the daily limit of the phone number, and the hourly limit of
+// the IP address, before the OTP sent.
+func (u *usecase) limitPhoneOTP(ctx context.Context, phoneNumber string) error {
+	otpConfig := u.securityConfig.PhoneOTP
+
+	if ipAddress := client.GetFromContext(ctx).IPAddress; ipAddress != "" {
+		ipRequests, err := u.userRepository.IncrementCounter(ctx, counterPhoneOTPIP, ipAddress, phoneOTPIPWindow)
+		if err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+		if ipRequests > int64(otpConfig.IPLimit) {
+			return serverError.ErrTooManyRequests(ErrRateLimited)
+		}
+	}
+
+	acquired, err := u.userRepository.AcquireRateLimit(ctx, rateLimitPhoneOTP, phoneNumber, otpConfig.ResendInterval)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !acquired {
+		return serverError.ErrTooManyRequests(ErrRateLimited)
+	}
+
+	sent, err := u.userRepository.IncrementCounter(ctx, counterPhoneOTPSent, phoneNumber, phoneOTPDailyWindow)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if sent > int64(otpConfig.DailyLimit) {
+		return serverError.ErrTooManyRequests(ErrRateLimited)
+	}
+
+	return nil
+}
+
+// sendPhoneOTP sends new OTP replacing the previous one, only the OTP hash stored. Caller applies limitPhoneOTP,
+// wrong attempts of the previous OTP still counted for the new one.
+func (u *usecase) sendPhoneOTP(ctx context.Context, purpose TokenPurpose, userDetail User) error {
+	otpConfig := u.securityConfig.PhoneOTP
+
+	code, err := generateNumericCode(otpConfig.Length)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrGeneralError(err)
+	}
+
+	phoneOTP := PhoneOTP{UserID: userDetail.ID, CodeHash: hashToken(code)}
+	if err := u.userRepository.SavePhoneOTP(ctx, purpose, userDetail.PhoneNumber, phoneOTP, otpConfig.TTL); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	err = u.smsSender.Send(ctx, sms.Message{
+		To:   userDetail.PhoneNumber,
+		Body: fmt.Sprintf("Your verification code is %v, the code expires in %v. Do not share this code with anyone.", code, otpConfig.TTL),
+	})
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrGeneralError(err)
+	}
+
+	return nil
+}
+
+// verifyPhoneOTP consumes the OTP and returns the user it sent for. Wrong attempts counted per phone number across
+// every OTP sent, the phone number locked after too many wrong attempts.
+func (u *usecase) verifyPhoneOTP(ctx context.Context, purpose TokenPurpose, phoneNumber, code string) (int, error) {
+	otpConfig := u.securityConfig.PhoneOTP
+
+	remaining, err := u.userRepository.GetRateLimit(ctx, rateLimitPhoneLockout, phoneNumber)
+	if err != nil {
+		return 0, serverError.ErrGeneralDatabaseError(err)
+	}
+	if remaining > 0 {
+		return 0, serverError.ErrAccountLocked(fmt.Errorf("%w, retry in %v", ErrPhoneLocked, remaining.Round(time.Second)))
+	}
+
+	attempt, err := u.userRepository.IncrementCounter(ctx, counterPhoneOTPAttempt, phoneNumber, u.securityConfig.LoginProtection.Window)
+	if err != nil {
+		return 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// OTP no longer usable, user must request new OTP after the lockout ended
+	if attempt > int64(otpConfig.MaxAttempts) {
+		if _, err := u.userRepository.DeletePhoneOTP(ctx, purpose, phoneNumber); err != nil {
+			return 0, serverError.ErrGeneralDatabaseError(err)
+		}
+
+		if _, err := u.userRepository.AcquireRateLimit(ctx, rateLimitPhoneLockout, phoneNumber, otpConfig.LockoutDuration); err != nil {
+			return 0, serverError.ErrGeneralDatabaseError(err)
+		}
+
+		// Start counting again after the lockout ended
+		if err := u.userRepository.ResetCounter(ctx, counterPhoneOTPAttempt, phoneNumber); err != nil {
+			log.Context(ctx).Error(err)
+		}
+
+		return 0, serverError.ErrAccountLocked(ErrPhoneLocked)
+	}
+
+	phoneOTP, err := u.userRepository.GetPhoneOTP(ctx, purpose, phoneNumber)
+	if errors.Is(err, ErrTokenNotFound) {
+		return 0, serverError.ErrInvalidOTP(err)
+	}
+	if err != nil {
+		return 0, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(code)), []byte(phoneOTP.CodeHash)) != 1 {
+		return 0, serverError.ErrInvalidOTP(ErrInvalidOTP)
+	}
+
+	// Consume the OTP, concurrent request with the same OTP only succeed once
+	deleted, err := u.userRepository.DeletePhoneOTP(ctx, purpose, phoneNumber)
+	if err != nil {
+		return 0, serverError.ErrGeneralDatabaseError(err)
+	}
+	if !deleted {
+		return 0, serverError.ErrInvalidOTP(ErrTokenNotFound)
+	}
+
+	if err := u.userRepository.ResetCounter(ctx, counterPhoneOTPAttempt, phoneNumber); err != nil {
+		log.Context(ctx).Errorf("failed resetting phone otp attempt, %v", err)
+	}
+
+	return phoneOTP.UserID, nil
+}
+
+// AuthorizeOIDC starts login on the external provider, the PKCE verifier and nonce kept until the callback.
+func (u *usecase) AuthorizeOIDC(ctx context.Context, providerName string) (OIDCAuthorizeResponse, error) {
+	provider, err := u.oidcProviders.Get(providerName)
+	if err != nil {
+		return OIDCAuthorizeResponse{}, serverError.ErrDataNotFound(err)
+	}
+
+	state, err := generateRandomToken()
+	if err != nil {
+		return OIDCAuthorizeResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	nonce, err := generateRandomToken()
+	if err != nil {
+		return OIDCAuthorizeResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return OIDCAuthorizeResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return OIDCAuthorizeResponse{}, serverError.ErrExternalLoginFailed(err)
+	}
+
+	oidcState := OIDCState{Provider: provider.Name(), CodeVerifier: codeVerifier, Nonce: nonce}
+	if err := u.userRepository.SaveOIDCState(ctx, hashToken(state), oidcState, u.securityConfig.OIDC.StateTTL); err != nil {
+		return OIDCAuthorizeResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return OIDCAuthorizeResponse{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// LoginOIDC completes login after redirected back from the provider. Identity linked to existing user
+// with the same email only when both the provider and this service verified the email.
+func (u *usecase) LoginOIDC(ctx context.Context, callbackReq OIDCCallbackRequest) (LoginResponse, error) {
+	if err := u.validator.StructCtx(ctx, callbackReq); err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRequest(err)
+	}
+
+	// State must come back to the browser starting the login, prevents login using other person code
+	if subtle.ConstantTimeCompare([]byte(callbackReq.State), []byte(callbackReq.BrowserState)) != 1 {
+		return LoginResponse{}, serverError.ErrExternalLoginFailed(ErrInvalidOIDCState)
+	}
+
+	oidcState, err := u.userRepository.UseOIDCState(ctx, hashToken(callbackReq.State))
+	if errors.Is(err, ErrTokenNotFound) {
+		return LoginResponse{}, serverError.ErrExternalLoginFailed(ErrInvalidOIDCState)
+	}
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// State only valid for the provider it was issued for
+	if oidcState.Provider != callbackReq.Provider {
+		return LoginResponse{}, serverError.ErrExternalLoginFailed(ErrInvalidOIDCState)
+	}
+
+	provider, err := u.oidcProviders.Get(oidcState.Provider)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrDataNotFound(err)
+	}
+
+	identity, err := provider.Exchange(ctx, callbackReq.Code, oidcState.CodeVerifier, oidcState.Nonce)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return LoginResponse{}, serverError.ErrExternalLoginFailed(err)
+	}
+
+	userDetail, err := u.findOrLinkOIDCUser(ctx, provider.Name(), identity)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+
+	return u.completeLogin(ctx, userDetail)
+}
+
+// findOrLinkOIDCUser returns user of the linked identity, otherwise links the identity to user with the same email
+// or registers new user.
+func (u *usecase) findOrLinkOIDCUser(ctx context.Context, providerName string, identity oidc.Identity) (User, error) {
+	userIdentity, err := u.userRepository.FindIdentity(ctx, providerName, identity.Subject)
+	if err == nil {
+		userDetail, err := u.userRepository.FindUserByID(ctx, userIdentity.UserID)
+		if err != nil {
+			return User{}, serverError.ErrGeneralDatabaseError(err)
+		}
+		return userDetail, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Unverified email can be claimed by anyone, never used for linking
+	email := normalizeEmail(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return User{}, serverError.ErrExternalLoginFailed(ErrOIDCEmailNotVerified)
+	}
+
+	userIdentity = UserIdentity{
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    email,
+	}
+
+	userDetail, err := u.userRepository.FindUserByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return u.registerOIDCUser(ctx, identity.Name, userIdentity)
+	}
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Prevent taking over account registered by other person before the email verified
+	if userDetail.EmailVerifiedAt == nil {
+		return User{}, serverError.ErrEmailNotVerified(ErrEmailNotVerified)
+	}
+
+	userIdentity.UserID = userDetail.ID
+	err = u.userRepository.SaveIdentity(ctx, userIdentity)
+	if gormpkg.IsUniqueViolation(err) {
+		return User{}, serverError.ErrConflict(ErrIdentityLinked)
+	}
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return userDetail, nil
+}
+
+// registerOIDCUser creates user with random password and links the identity, password can be set later
+// using forgot password.
+func (u *usecase) registerOIDCUser(ctx context.Context, fullName string, userIdentity UserIdentity) (User, error) {
+	randomPassword, err := generateRandomToken()
+	if err != nil {
+		return User{}, serverError.ErrGeneralError(err)
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(randomPassword)
+	if err != nil {
+		return User{}, serverError.ErrGeneralError(err)
+	}
+
+	now := time.Now()
+	newUser, err := u.userRepository.RegisterIdentityUser(ctx, User{
+		FullName:        fullName,
+		Email:           userIdentity.Email,
+		Password:        hashedPassword,
+		Role:            RoleUser,
+		Status:          true,
+		KYCLevel:        KYCLevelUnverified,
+		EmailVerifiedAt: &now, // Verified by the provider
+	}, userIdentity)
+	if errors.Is(err, ErrIdentityLinked) {
+		return User{}, serverError.ErrConflict(ErrIdentityLinked)
+	}
+	if gormpkg.IsUniqueViolation(err) {
+		return User{}, serverError.ErrConflict(ErrEmailRegistered)
+	}
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.recordRoleAssigned(ctx, newUser)
+
+	return newUser, nil
+}
+
+// recordRoleAssigned writes the role given to the new user, the role only set on registration.
+func (u *usecase) recordRoleAssigned(ctx context.Context, userDetail User) {
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventRoleAssigned,
+		Detail: fmt.Sprintf("role %v", userDetail.Role),
+	})
+}
+
+// startSession records the device and issues tokens within a new refresh token family.
+func (u *usecase) startSession(ctx context.Context, userDetail User) (LoginResponse, error) {
+	familyID, err := generateRandomToken()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return LoginResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	clientInfo := client.GetFromContext(ctx)
+	session := Session{
+		UserID:     userDetail.ID,
+		FamilyID:   familyID,
+		IPAddress:  clientInfo.IPAddress,
+		UserAgent:  clientInfo.UserAgent,
+		LastSeenAt: time.Now(),
+	}
+
+	if err := u.userRepository.SaveSession(ctx, session); err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventLoginSucceeded,
+	})
+
+	return u.issueTokens(ctx, userDetail, familyID)
+}
+
+// endSession revokes the refresh token family and every access token issued for the session.
+func (u *usecase) endSession(ctx context.Context, familyID string) error {
+	if err := u.userRepository.RevokeTokenFamily(ctx, familyID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.revocationList.RevokeSession(ctx, familyID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.userRepository.RevokeSession(ctx, familyID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return nil
+}
+
+func (u *usecase) RefreshToken(ctx context.Context, refreshReq RefreshTokenRequest) (LoginResponse, error) {
+	tokenHash := hashToken(refreshReq.RefreshToken)
+
+	refreshToken, err := u.userRepository.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRefreshToken(err)
+	}
+
+	familyActive, err := u.userRepository.IsTokenFamilyActive(ctx, refreshToken.FamilyID)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if !familyActive {
+		return LoginResponse{}, serverError.ErrInvalidRefreshToken(ErrTokenFamilyRevoked)
+	}
+
+	// Every session of the user revoked after the token issued
+	revoked, err := u.revocationList.IsRevoked(ctx, jwt.Payload{
+		UserID:     refreshToken.UserID,
+		IssuedAt:   refreshToken.IssuedAt,
+		IssuedAtMs: refreshToken.IssuedAtMs,
+	})
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	if revoked {
+		return LoginResponse{}, serverError.ErrInvalidRefreshToken(jwt.ErrTokenRevoked)
+	}
+
+	firstUse, err := u.userRepository.UseRefreshToken(ctx, tokenHash, u.securityConfig.Jwt.RefreshDuration)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Token already rotated before, the token might be stolen. Revoke every token in the family
+	if !firstUse {
+		log.Context(ctx).Errorf("refresh token reused, revoking token family %v of user %v", refreshToken.FamilyID, refreshToken.UserID)
+		if err := u.endSession(ctx, refreshToken.FamilyID); err != nil {
+			return LoginResponse{}, err
+		}
+
+		u.authEventWriter.Write(ctx, AuthEvent{
+			UserID: refreshToken.UserID,
+			Type:   AuthEventTokenReused,
+			Detail: "session ended",
+		})
+
+		return LoginResponse{}, serverError.ErrInvalidRefreshToken(ErrRefreshTokenReused)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, refreshToken.UserID)
+	if err != nil {
+		return LoginResponse{}, serverError.ErrInvalidRefreshToken(err)
+	}
+
+	if !userDetail.Status {
+		if err := u.userRepository.RevokeTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+			return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+		}
+		return LoginResponse{}, serverError.ErrUserBlocked(ErrUserInactive)
+	}
+
+	// Not returning error, only used for displaying the session
+	clientInfo := client.GetFromContext(ctx)
+	if err := u.userRepository.UpdateSessionLastSeen(ctx, refreshToken.FamilyID, clientInfo.IPAddress, clientInfo.UserAgent); err != nil {
+		log.Context(ctx).Errorf("failed updating session last seen, %v", err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventTokenRefreshed,
+	})
+
+	return u.issueTokens(ctx, userDetail, refreshToken.FamilyID)
 }
 
-// NewUsecase returns new user usecase.
-func NewUsecase(securityConfig config.Security, validator *validator.Validate, userRepository Repository) *usecase {
-	return &usecase{
-		securityConfig: securityConfig,
-		validator:      validator,
-		userRepository: userRepository,
+// Logout ends the session of the access token, token issued without session revoked along with the
+// refresh token family if provided.
+func (u *usecase) Logout(ctx context.Context, logoutReq LogoutRequest) error {
+	jwtPayload := jwt.GetPayloadFromContext(ctx)
+
+	if logoutReq.RefreshToken != "" {
+		refreshToken, err := u.userRepository.GetRefreshToken(ctx, hashToken(logoutReq.RefreshToken))
+		if err != nil {
+			return serverError.ErrInvalidRefreshToken(err)
+		}
+
+		if refreshToken.UserID != jwtPayload.UserID {
+			return serverError.ErrInvalidRefreshToken(ErrTokenOwnerMismatch)
+		}
+
+		if err := u.userRepository.RevokeTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+			return serverError.ErrGeneralDatabaseError(err)
+		}
+	}
+
+	if jwtPayload.SessionID != "" {
+		if err := u.endSession(ctx, jwtPayload.SessionID); err != nil {
+			return err
+		}
+	} else if err := u.revocationList.RevokeToken(ctx, jwtPayload); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
 	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: jwtPayload.UserID,
+		Email:  jwtPayload.Email,
+		Type:   AuthEventLogout,
+	})
+
+	return nil
 }
 
-func (u *usecase) Login(ctx context.Context, loginReq LoginRequest) (LoginResponse, error) {
-	// Find user detail in repository
-	userDetail, err := u.userRepository.FindUserByEmail(ctx, loginReq.Email)
+// GetSessions returns session with refresh token still usable, the current session marked.
+func (u *usecase) GetSessions(ctx context.Context) ([]Session, error) {
+	jwtPayload := jwt.GetPayloadFromContext(ctx)
+
+	since := time.Now().Add(-u.securityConfig.Jwt.RefreshDuration)
+	sessions, err := u.userRepository.GetActiveSessions(ctx, jwtPayload.UserID, since)
 	if err != nil {
-		return LoginResponse{}, err
+		return nil, serverError.ErrGeneralDatabaseError(err)
 	}
 
-	// Comparing the password with the hash
-	if err = bcrypt.CompareHashAndPassword([]byte(userDetail.Password), []byte(loginReq.Password)); err != nil {
-		log.Context(ctx).Error(err)
-		return LoginResponse{}, serverError.ErrInvalidUsernameOrPassword(err)
+	activeSessions := make([]Session, 0, len(sessions))
+	for _, session := range sessions {
+		// Latest refresh token issued when last seen, skip session revoked by revoking every user session
+		revoked, err := u.revocationList.IsRevoked(ctx, jwt.Payload{
+			UserID:     session.UserID,
+			SessionID:  session.FamilyID,
+			IssuedAt:   session.LastSeenAt.Unix(),
+			IssuedAtMs: session.LastSeenAt.UnixMilli(),
+		})
+		if err != nil {
+			return nil, serverError.ErrGeneralDatabaseError(err)
+		}
+		if revoked {
+			continue
+		}
+
+		session.Current = session.FamilyID == jwtPayload.SessionID
+		activeSessions = append(activeSessions, session)
+	}
+
+	return activeSessions, nil
+}
+
+// EndSession logs out the device of the session.
+func (u *usecase) EndSession(ctx context.Context, id int) error {
+	session, err := u.userRepository.GetSession(ctx, jwt.GetPayloadFromContext(ctx).UserID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return serverError.ErrDataNotFound(err)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := u.endSession(ctx, session.FamilyID); err != nil {
+		return err
 	}
 
-	// Create a new token object
-	token := jwt.New(jwt.SigningMethodHS256)
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: session.UserID,
+		Email:  jwt.GetPayloadFromContext(ctx).Email,
+		Type:   AuthEventSessionEnded,
+		Detail: fmt.Sprintf("session %v, %v", session.ID, session.UserAgent),
+	})
 
-	// Set the claims for the token
-	claims := token.Claims.(jwt.MapClaims)
-	claims["userId"] = userDetail.ID
-	claims["email"] = userDetail.Email
-	claims["exp"] = time.Now().Add(u.securityConfig.Jwt.Duration).Unix()
+	return nil
+}
+
+// GetAuthEvents returns the security history of the logged in user, newest event first.
+func (u *usecase) GetAuthEvents(ctx context.Context, listReq ListRequest) ([]AuthEvent, int, error) {
+	authEvents, total, err := u.userRepository.GetAuthEvents(ctx, jwt.GetPayloadFromContext(ctx).UserID, listReq.Page, listReq.Limit)
+	if err != nil {
+		return nil, 0, serverError.ErrGeneralDatabaseError(err)
+	}
 
-	// Generate the token string
-	tokenString, err := token.SignedString([]byte(u.securityConfig.Jwt.Key))
+	return authEvents, total, nil
+}
+
+// issueTokens generates short-lived access token and new refresh token within the family.
+func (u *usecase) issueTokens(ctx context.Context, userDetail User, familyID string) (LoginResponse, error) {
+	now := time.Now()
+	accessTokenExpiredAt := now.Add(u.securityConfig.Jwt.Duration).Unix()
+
+	accessToken, err := u.jwtManager.Generate(jwt.Payload{
+		SessionID:   familyID,
+		UserID:      userDetail.ID,
+		Email:       userDetail.Email,
+		Exp:         accessTokenExpiredAt,
+		Role:        userDetail.Role,
+		Permissions: userDetail.PermissionList(),
+	})
 	if err != nil {
 		log.Context(ctx).Errorf("failed generate token string, %v", err)
-		return LoginResponse{}, serverError.ErrInvalidUsernameOrPassword(err)
+		return LoginResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	refreshToken, err := generateRandomToken()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return LoginResponse{}, serverError.ErrGeneralError(err)
+	}
+
+	// Family lifetime extended on every rotation
+	if err := u.userRepository.SaveTokenFamily(ctx, familyID, userDetail.ID, u.securityConfig.Jwt.RefreshDuration); err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	newRefreshToken := RefreshToken{
+		UserID:     userDetail.ID,
+		FamilyID:   familyID,
+		IssuedAt:   now.Unix(),
+		IssuedAtMs: now.UnixMilli(),
+	}
+
+	if err := u.userRepository.SaveRefreshToken(ctx, hashToken(refreshToken), newRefreshToken, u.securityConfig.Jwt.RefreshDuration); err != nil {
+		return LoginResponse{}, serverError.ErrGeneralDatabaseError(err)
 	}
 
 	loginResponse := LoginResponse{
-		Email: userDetail.Email,
-		Token: tokenString,
+		Email:        userDetail.Email,
+		Token:        accessToken,
+		ExpiredAt:    accessTokenExpiredAt,
+		RefreshToken: refreshToken,
 	}
 
 	return loginResponse, nil
 }
 
 func (u *usecase) Register(ctx context.Context, registerReq RegisterRequest) (User, error) {
+	if err := u.validator.StructCtx(ctx, registerReq); err != nil {
+		return User{}, serverError.ErrInvalidRequest(err)
+	}
+
 	// Hashing the password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registerReq.Password), bcrypt.DefaultCost)
+	hashedPassword, err := u.passwordHasher.Hash(registerReq.Password)
 	if err != nil {
 		log.Context(ctx).Error(err)
 		return User{}, err
@@ -77,16 +1483,469 @@ func (u *usecase) Register(ctx context.Context, registerReq RegisterRequest) (Us
 	// Create new model for inserting to database
 	newUser := User{
 		FullName:    registerReq.FullName,
-		Email:       registerReq.Email,
+		Email:       normalizeEmail(registerReq.Email),
 		PhoneNumber: registerReq.PhoneNumber,
-		Password:    string(hashedPassword),
+		Password:    hashedPassword,
+		Role:        RoleUser,
 		Status:      true, // Active
+		KYCLevel:    KYCLevelUnverified,
 	}
 
 	newUser, err = u.userRepository.RegisterNewUser(ctx, newUser)
+	if gormpkg.IsUniqueViolation(err) {
+		return User{}, serverError.ErrConflict(ErrEmailRegistered)
+	}
 	if err != nil {
-		return User{}, err
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.recordRoleAssigned(ctx, newUser)
+
+	// Registration still succeed, user can request another verification email
+	if err := u.sendVerificationEmail(ctx, newUser); err != nil {
+		log.Context(ctx).Errorf("failed sending verification email, %v", err)
 	}
 
 	return newUser, nil
 }
+
+// VerifyEmail accepts token sent after registration or after requesting email change.
+func (u *usecase) VerifyEmail(ctx context.Context, verifyReq VerifyEmailRequest) error {
+	tokenHash := hashToken(verifyReq.Token)
+
+	userID, err := u.userRepository.UseSingleUseToken(ctx, TokenPurposeEmailVerification, tokenHash)
+	if errors.Is(err, ErrTokenNotFound) {
+		return u.confirmEmailChange(ctx, tokenHash)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.userRepository.UpdateEmailVerified(ctx, userID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return nil
+}
+
+// confirmEmailChange applies the verified new email, every session revoked since the token contains the old email.
+func (u *usecase) confirmEmailChange(ctx context.Context, tokenHash string) error {
+	emailChange, err := u.userRepository.UseEmailChange(ctx, tokenHash)
+	if errors.Is(err, ErrTokenNotFound) {
+		return serverError.ErrInvalidVerificationToken(err)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	err = u.userRepository.UpdateEmail(ctx, emailChange.UserID, emailChange.Email)
+	if gormpkg.IsUniqueViolation(err) {
+		return serverError.ErrConflict(ErrEmailRegistered)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.revokeAccess(ctx, emailChange.UserID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: emailChange.UserID,
+		Email:  emailChange.Email,
+		Type:   AuthEventEmailChanged,
+	})
+
+	return nil
+}
+
+// ResendVerificationEmail always succeed for unknown or verified email, so the response
+// can not be used for checking registered email.
+func (u *usecase) ResendVerificationEmail(ctx context.Context, resendReq ResendVerificationRequest) error {
+	resendReq.Email = normalizeEmail(resendReq.Email)
+
+	acquired, err := u.userRepository.AcquireRateLimit(ctx, rateLimitVerificationEmail, resendReq.Email, u.securityConfig.EmailVerification.ResendInterval)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !acquired {
+		return serverError.ErrTooManyRequests(ErrRateLimited)
+	}
+
+	userDetail, err := u.userRepository.FindUserByEmail(ctx, resendReq.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if userDetail.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := u.sendVerificationEmail(ctx, userDetail); err != nil {
+		return serverError.ErrGeneralError(err)
+	}
+
+	return nil
+}
+
+// ForgotPassword always succeed for unknown email and the email sent in background, so neither the response
+// nor the response time can be used for checking registered email.
+func (u *usecase) ForgotPassword(ctx context.Context, forgotReq ForgotPasswordRequest) error {
+	forgotReq.Email = normalizeEmail(forgotReq.Email)
+
+	acquired, err := u.userRepository.AcquireRateLimit(ctx, rateLimitPasswordReset, forgotReq.Email, u.securityConfig.PasswordReset.RequestInterval)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !acquired {
+		return serverError.ErrTooManyRequests(ErrRateLimited)
+	}
+
+	runInBackground(ctx, func(ctx context.Context) {
+		u.sendPasswordReset(ctx, forgotReq.Email)
+	})
+
+	return nil
+}
+
+// sendPasswordReset runs in background, error only logged.
+func (u *usecase) sendPasswordReset(ctx context.Context, email string) {
+	userDetail, err := u.userRepository.FindUserByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err != nil {
+		log.Context(ctx).Errorf("failed finding user for password reset, %v", err)
+		return
+	}
+
+	if !userDetail.Status {
+		return
+	}
+
+	if err := u.sendPasswordResetEmail(ctx, userDetail); err != nil {
+		log.Context(ctx).Errorf("failed sending password reset email, %v", err)
+	}
+}
+
+func (u *usecase) ResetPassword(ctx context.Context, resetReq ResetPasswordRequest) error {
+	if err := u.validator.StructCtx(ctx, resetReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	userID, err := u.userRepository.UseSingleUseToken(ctx, TokenPurposePasswordReset, hashToken(resetReq.Token))
+	if errors.Is(err, ErrTokenNotFound) {
+		return serverError.ErrInvalidVerificationToken(err)
+	}
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Event of the reset recorded with the user email
+	userDetail, err := u.userRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return u.updatePassword(ctx, userDetail, resetReq.NewPassword, AuthEventPasswordReset)
+}
+
+// ChangePassword revokes every session including the current one, user must login again.
+func (u *usecase) ChangePassword(ctx context.Context, changeReq ChangePasswordRequest) error {
+	if err := u.validator.StructCtx(ctx, changeReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err = u.passwordHasher.Verify(userDetail.Password, changeReq.OldPassword); err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrInvalidPassword(err)
+	}
+
+	return u.updatePassword(ctx, userDetail, changeReq.NewPassword, AuthEventPasswordChanged)
+}
+
+func (u *usecase) updatePassword(ctx context.Context, userDetail User, newPassword string, eventType AuthEventType) error {
+	hashedPassword, err := u.passwordHasher.Hash(newPassword)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrGeneralError(err)
+	}
+
+	if err := u.userRepository.UpdatePassword(ctx, userDetail.ID, hashedPassword); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Reset link requested before the change must not override the new password
+	if err := u.userRepository.RevokeSingleUseTokens(ctx, TokenPurposePasswordReset, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Revoke every access and refresh token issued with the old password
+	if err := u.revokeAccess(ctx, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   eventType,
+	})
+
+	return nil
+}
+
+func (u *usecase) GetProfile(ctx context.Context) (User, error) {
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	return userDetail, nil
+}
+
+// UpdateProfile saves the name and phone number immediately, new email only applied after verified.
+func (u *usecase) UpdateProfile(ctx context.Context, profileReq UpdateProfileRequest) (User, error) {
+	if err := u.validator.StructCtx(ctx, profileReq); err != nil {
+		return User{}, serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Every change validated before anything written, rejected email change leaves the profile unchanged
+	newEmail := normalizeEmail(profileReq.Email)
+	emailChanged := newEmail != "" && newEmail != userDetail.Email
+	if emailChanged {
+		if err := u.checkEmailChange(ctx, userDetail, newEmail); err != nil {
+			return User{}, err
+		}
+	}
+
+	// Changed phone number must be verified again
+	if profileReq.PhoneNumber != userDetail.PhoneNumber {
+		userDetail.PhoneVerifiedAt = nil
+	}
+
+	err = u.userRepository.UpdateProfile(ctx, userDetail.ID, profileReq.FullName, profileReq.PhoneNumber, userDetail.PhoneVerifiedAt)
+	if err != nil {
+		return User{}, serverError.ErrGeneralDatabaseError(err)
+	}
+	userDetail.FullName = profileReq.FullName
+	userDetail.PhoneNumber = profileReq.PhoneNumber
+
+	if emailChanged {
+		if err := u.requestEmailChange(ctx, userDetail, newEmail); err != nil {
+			return User{}, err
+		}
+	}
+
+	return userDetail, nil
+}
+
+// checkEmailChange rejects registered email and too frequent change request.
+func (u *usecase) checkEmailChange(ctx context.Context, userDetail User, newEmail string) error {
+	_, err := u.userRepository.FindUserByEmail(ctx, newEmail)
+	if err == nil {
+		return serverError.ErrConflict(ErrEmailRegistered)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	acquired, err := u.userRepository.AcquireRateLimit(ctx, rateLimitEmailChange, strconv.Itoa(userDetail.ID), u.securityConfig.EmailVerification.ResendInterval)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+	if !acquired {
+		return serverError.ErrTooManyRequests(ErrRateLimited)
+	}
+
+	return nil
+}
+
+// requestEmailChange sends verification link to the new email, email replaced after the link opened.
+func (u *usecase) requestEmailChange(ctx context.Context, userDetail User, newEmail string) error {
+	token, err := generateRandomToken()
+	if err != nil {
+		return serverError.ErrGeneralError(err)
+	}
+
+	ttl := u.securityConfig.EmailVerification.TTL
+	emailChange := EmailChange{UserID: userDetail.ID, Email: newEmail}
+	if err := u.userRepository.SaveEmailChange(ctx, hashToken(token), emailChange, ttl); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	// Sent to the new email, proving the user owns it
+	err = u.mailSender.Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Verify your new email address",
+		Body: fmt.Sprintf(
+			"Hi %v,\n\nPlease verify your new email address by opening the link below, the link expires in %v. "+
+				"Your email stays unchanged until verified.\n\n%v\n",
+			userDetail.FullName, ttl, fmt.Sprintf(u.securityConfig.EmailVerification.URL, token),
+		),
+	})
+	if err != nil {
+		return serverError.ErrGeneralError(err)
+	}
+
+	return nil
+}
+
+// DeactivateAccount requires the password, every session revoked and the account can not login anymore.
+func (u *usecase) DeactivateAccount(ctx context.Context, deactivateReq DeactivateAccountRequest) error {
+	if err := u.validator.StructCtx(ctx, deactivateReq); err != nil {
+		return serverError.ErrInvalidRequest(err)
+	}
+
+	userDetail, err := u.userRepository.FindUserByID(ctx, jwt.GetPayloadFromContext(ctx).UserID)
+	if err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err = u.passwordHasher.Verify(userDetail.Password, deactivateReq.Password); err != nil {
+		log.Context(ctx).Error(err)
+		return serverError.ErrInvalidPassword(err)
+	}
+
+	if err := u.userRepository.DeactivateUser(ctx, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	if err := u.revokeAccess(ctx, userDetail.ID); err != nil {
+		return serverError.ErrGeneralDatabaseError(err)
+	}
+
+	u.authEventWriter.Write(ctx, AuthEvent{
+		UserID: userDetail.ID,
+		Email:  userDetail.Email,
+		Type:   AuthEventDeactivated,
+	})
+
+	return nil
+}
+
+func (u *usecase) sendVerificationEmail(ctx context.Context, userDetail User) error {
+	ttl := u.securityConfig.EmailVerification.TTL
+	token, err := u.createSingleUseToken(ctx, TokenPurposeEmailVerification, userDetail.ID, ttl)
+	if err != nil {
+		return err
+	}
+
+	return u.mailSender.Send(ctx, mail.Message{
+		To:      userDetail.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %v,\n\nPlease verify your email address by opening the link below, the link expires in %v.\n\n%v\n",
+			userDetail.FullName, ttl, fmt.Sprintf(u.securityConfig.EmailVerification.URL, token),
+		),
+	})
+}
+
+// sendPasswordResetEmail invalidates the previous reset link, only the latest link can be used.
+func (u *usecase) sendPasswordResetEmail(ctx context.Context, userDetail User) error {
+	if err := u.userRepository.RevokeSingleUseTokens(ctx, TokenPurposePasswordReset, userDetail.ID); err != nil {
+		return err
+	}
+
+	ttl := u.securityConfig.PasswordReset.TTL
+	token, err := u.createSingleUseToken(ctx, TokenPurposePasswordReset, userDetail.ID, ttl)
+	if err != nil {
+		return err
+	}
+
+	return u.mailSender.Send(ctx, mail.Message{
+		To:      userDetail.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %v,\n\nWe received a request to reset your password, open the link below to choose a new password. "+
+				"The link expires in %v. Ignore this email if you did not request it.\n\n%v\n",
+			userDetail.FullName, ttl, fmt.Sprintf(u.securityConfig.PasswordReset.URL, token),
+		),
+	})
+}
+
+// createSingleUseToken returns random token, only the token hash stored.
+func (u *usecase) createSingleUseToken(ctx context.Context, purpose TokenPurpose, userID int, ttl time.Duration) (string, error) {
+	token, err := generateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := u.userRepository.SaveSingleUseToken(ctx, purpose, hashToken(token), userID, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// runInBackground runs the function after the response is sent, the request log waits until it finishes.
+func runInBackground(ctx context.Context, fn func(ctx context.Context)) {
+	waitGroup := log.Context(ctx).WaitGroup
+	waitGroup.Add(1)
+
+	go func() {
+		defer waitGroup.Done()
+		fn(context.WithoutCancel(ctx))
+	}()
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// generateNumericCode returns random digits for OTP sent by SMS.
+func generateNumericCode(length int) (string, error) {
+	var code strings.Builder
+	for range length {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code.WriteString(digit.String())
+	}
+
+	return code.String(), nil
+}
+
+// generateDummyPassword returns random password for the dummy hash, never matched by any login.
+func generateDummyPassword() string {
+	password, err := generateRandomToken()
+	if err != nil {
+		log.Fatalf("failed generating dummy password, %v", err)
+	}
+
+	return password
+}
+
+// generateRandomToken returns url safe random string with 256 bit entropy.
+func generateRandomToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// normalizeEmail makes email comparison case insensitive, matching the unique index on LOWER(email).
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// hashToken returns the token hash, only the hash stored in the server.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,35 @@
+package wellknown
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-skeleton-code/pkg/jwt"
+)
+
+type httpHandler struct {
+	jwtManager jwt.Manager
+}
+
+// NewHTTPHandler returns handler for public metadata, routes must be registered on the root path.
+func NewHTTPHandler(jwtManager jwt.Manager) interface {
+	InitRoutes(g *gin.RouterGroup)
+} {
+	return &httpHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+func (h *httpHandler) InitRoutes(g *gin.RouterGroup) {
+	wellKnown := g.Group("/.well-known")
+	{
+		wellKnown.GET("/jwks.json", h.JWKSHandler)
+	}
+}
+
+// JWKSHandler returns the key set in the standard format, not wrapped in the default response.
+func (h *httpHandler) JWKSHandler(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtManager.JWKS())
+}
@@ -1,17 +1,31 @@
 package app
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc"
 
 	"go-skeleton-code/pkg/log"
 	"go-skeleton-code/config"
+	"go-skeleton-code/internal/app/domains/admin"
+	"go-skeleton-code/internal/app/domains/apikey"
+	"go-skeleton-code/internal/app/domains/kyc"
 	"go-skeleton-code/internal/app/domains/order"
+	"go-skeleton-code/internal/app/domains/portfolio"
 	"go-skeleton-code/internal/app/domains/user"
+	"go-skeleton-code/internal/app/domains/wellknown"
+	"go-skeleton-code/pkg/encryption"
 	"go-skeleton-code/pkg/gorm"
+	"go-skeleton-code/pkg/jwt"
 	"go-skeleton-code/pkg/kafka"
+	"go-skeleton-code/pkg/mail"
+	"go-skeleton-code/pkg/oidc"
+	"go-skeleton-code/pkg/password"
 	"go-skeleton-code/pkg/redis"
+	"go-skeleton-code/pkg/sms"
+	"go-skeleton-code/pkg/storage"
+	"go-skeleton-code/pkg/validator"
 )
 
 func Init(gin *gin.Engine, g *grpc.Server, cfg *config.Config) chan bool {
@@ -19,30 +33,61 @@ func Init(gin *gin.Engine, g *grpc.Server, cfg *config.Config) chan bool {
 		exitSignal         = make(chan bool)
 		validator          = validator.New()
 		apiTimeout         = cfg.App.HTTP.CtxTimeout
-		redis              = redis.Init(cfg.Dependencies.Cache)
+		redisClient        = redis.Init(cfg.Dependencies.Cache)
 		readDatabase       = gorm.InitPostgres(cfg.Dependencies.Database.Read)
 		writeDatabase      = gorm.InitPostgres(cfg.Dependencies.Database.Write)
 		matchOrderConsumer = kafka.NewConsumer(cfg.Dependencies.MessageBroker, cfg.Dependencies.MessageBroker.Consumer.Topic.MatchOrder)
 		producer, writer   = kafka.NewProducer(cfg.Dependencies.MessageBroker.Brokers)
+		publisher          = redis.NewPublisher(redisClient)
+		mailSender         = mail.Init(cfg.Dependencies.Mail)
+		smsSender          = sms.Init(cfg.Dependencies.SMS)
+		fileStorage        = storage.Init(cfg.Dependencies.Storage)
+		encryptor          = encryption.NewAESEncryptor(cfg.Security.EncryptionKey)
+		passwordHasher     = password.NewHasher(cfg.Security.Password)
+		oidcProviders      = oidc.Init(cfg.Security.OIDC)
+		jwtManager         = jwt.NewManager(cfg.Security.Jwt)
+		revocationList     = jwt.NewRevocationList(redisClient, cfg.Security.Jwt)
+		cacheSubscriber    = redisClient.Subscribe(context.Background(), cfg.Dependencies.Cache.Channel.PairUpdate, cfg.Dependencies.Cache.Channel.CryptoUpdate)
 	)
 
 	// Init http router
 	{
 		// Repository
-		userRepository := user.NewRepository(readDatabase, writeDatabase)
-		orderRepository := order.NewRepository(readDatabase, writeDatabase)
+		userRepository := user.NewRepository(readDatabase, writeDatabase, redisClient)
+		orderRepository := order.NewCachedRepository(order.NewRepository(readDatabase, writeDatabase), redisClient, cfg.Dependencies.Cache)
+		adminRepository := admin.NewRepository(readDatabase, writeDatabase)
+		portfolioRepository := portfolio.NewRepository(readDatabase)
+		apiKeyRepository := apikey.NewRepository(readDatabase, writeDatabase, redisClient)
+		kycRepository := kyc.NewRepository(readDatabase, writeDatabase)
+
+		circuitBreaker := order.NewCircuitBreaker(redisClient, cfg.Trading.CircuitBreaker)
+
+		authEventWriter := user.NewAuthEventWriter(userRepository)
 
 		// Usecase
-		userUsecase := user.NewUsecase(cfg.Security, validator, userRepository)
-		orderUsecase := order.NewUsecase(writeDatabase, producer, validator, orderRepository, userRepository)
+		userUsecase := user.NewUsecase(cfg.Security, jwtManager, revocationList, mailSender, smsSender, encryptor, passwordHasher, authEventWriter, oidcProviders, validator, userRepository)
+		kycUsecase := kyc.NewUsecase(cfg.Trading.KYC, fileStorage, validator, kycRepository, userRepository)
+		orderUsecase := order.NewUsecase(writeDatabase, cfg.Dependencies.Cache, producer, publisher, circuitBreaker, validator, orderRepository, userRepository, kycUsecase)
+		adminUsecase := admin.NewUsecase(writeDatabase, cfg.Dependencies.Cache, publisher, validator, adminRepository, orderUsecase, userUsecase, kycUsecase)
+		portfolioUsecase := portfolio.NewUsecase(portfolioRepository)
+		apiKeyUsecase := apikey.NewUsecase(cfg.Security.APIKey, encryptor, revocationList, validator, apiKeyRepository, userRepository, kycUsecase)
 
 		// Handler
+		wellknown.NewHTTPHandler(jwtManager).InitRoutes(&gin.RouterGroup)
+
 		api := gin.Group("/api")
-		user.NewHTTPHandler(userUsecase, apiTimeout).InitRoutes(api)
-		order.NewHTTPHandler(orderUsecase, apiTimeout, cfg.Security).InitRoutes(api)
+		user.NewHTTPHandler(userUsecase, apiTimeout, jwtManager, revocationList).InitRoutes(api)
+		order.NewHTTPHandler(orderUsecase, apiTimeout, jwtManager, revocationList, apiKeyUsecase).InitRoutes(api)
+		admin.NewHTTPHandler(adminUsecase, apiTimeout, jwtManager, revocationList).InitRoutes(api)
+		portfolio.NewHTTPHandler(portfolioUsecase, apiTimeout, jwtManager, revocationList, apiKeyUsecase).InitRoutes(api)
+		apikey.NewHTTPHandler(apiKeyUsecase, apiTimeout, jwtManager, revocationList).InitRoutes(api)
+		kyc.NewHTTPHandler(kycUsecase, apiTimeout, cfg.Trading.KYC.MaxDocumentSize, jwtManager, revocationList).InitRoutes(api)
 
 		// Queue
 		order.NewQueueHandler(matchOrderConsumer, orderUsecase, apiTimeout).StartConsumer()
+
+		// Subscriber
+		order.NewPubSubHandler(cacheSubscriber, orderRepository, cfg.Dependencies.Cache, apiTimeout).StartSubscriber()
 	}
 
 	// Graceful shutdown
@@ -54,11 +99,15 @@ func Init(gin *gin.Engine, g *grpc.Server, cfg *config.Config) chan bool {
 			log.Error(err)
 		}
 
+		if err := cacheSubscriber.Close(); err != nil {
+			log.Error(err)
+		}
+
 		if err := writer.Close(); err != nil {
 			log.Error(err)
 		}
 
-		if err := redis.Close(); err != nil {
+		if err := redisClient.Close(); err != nil {
 			log.Error(err)
 		}
 
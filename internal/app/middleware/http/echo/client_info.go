@@ -0,0 +1,31 @@
+package http
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+
+	"go-skeleton-code/pkg/client"
+)
+
+// SaveClientInfo is an Echo middleware to save client IP address and user agent to the request context.
+func SaveClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Get parent context from Echo Locals
+			ctx, ok := c.Get("ctx").(context.Context)
+			if !ok {
+				ctx = context.Background()
+			}
+
+			// RealIP uses the server IP extractor, forwarded header only trusted from configured proxy
+			ctx = client.SaveToContext(ctx, client.Info{
+				IPAddress: c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+
+			c.Set("ctx", ctx)
+			return next(c)
+		}
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"go-skeleton-code/pkg/response/echo"
 )
 
-func ValidateJwtToken(secretKey []byte) echo.MiddlewareFunc {
+// ValidateJwtToken is an Echo middleware to validate JWT tokens from the Authorization header.
+// Token found in the revocation list is rejected.
+func ValidateJwtToken(jwtManager jwt.Manager, revocationList jwt.RevocationList) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// Get parent context from Echo Locals
@@ -33,10 +35,20 @@ func ValidateJwtToken(secretKey []byte) echo.MiddlewareFunc {
 				}
 			}
 
-			payload, err := jwt.Validate(tokenString, secretKey)
+			payload, err := jwtManager.Validate(tokenString)
 			if err != nil {
 				log.Context(ctx).Error(err)
-				return response.Failed(c, serverError.ErrUnauthorized(err))
+				return response.Failed(c, jwt.ServerError(err))
+			}
+
+			revoked, err := revocationList.IsRevoked(ctx, payload)
+			if err != nil {
+				return response.Failed(c, serverError.ErrGeneralDatabaseError(err))
+			}
+
+			if revoked {
+				log.Context(ctx).Warn(jwt.ErrTokenRevoked)
+				return response.Failed(c, jwt.ServerError(jwt.ErrTokenRevoked))
 			}
 
 			ctx = jwt.SavePayloadToContext(ctx, payload)
@@ -29,8 +29,8 @@ func ValidateRole(roles ...string) echo.MiddlewareFunc {
 				}
 			}
 
-			log.Context(ctx).Warn("unauthorized role")
-			return response.Failed(c, serverError.ErrUnauthorized(nil))
+			log.Context(ctx).Warn(jwt.ErrUnauthorizedRole)
+			return response.Failed(c, serverError.ErrUnauthorized(jwt.ErrUnauthorizedRole))
 		}
 	}
 }
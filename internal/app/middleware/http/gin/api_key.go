@@ -0,0 +1,86 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/log"
+	response "go-skeleton-code/pkg/response/gin"
+	"go-skeleton-code/pkg/signature"
+)
+
+// maxSignedBodySize limits the body read into memory for signature verification.
+const maxSignedBodySize = 1 << 20
+
+// APIKeyAuthenticator verifies the signed request and returns payload of the API key owner.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, signedRequest signature.Request) (jwt.Payload, error)
+}
+
+// ValidateAPIKey is a Gin middleware to validate request signed using API key secret.
+// The payload saved to the context is the same as ValidateJwtToken, so handlers work with both.
+func ValidateAPIKey(authenticator APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		timestamp, err := strconv.ParseInt(c.GetHeader(signature.HeaderTimestamp), 10, 64)
+		if err != nil {
+			log.Context(ctx).Error(err)
+			response.Failed(c, serverError.ErrInvalidSignature(err))
+			c.Abort()
+			return
+		}
+
+		// Body is signed as well, restore it for the handler
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodySize))
+		if err != nil {
+			response.Failed(c, serverError.ErrInvalidRequest(err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		payload, err := authenticator.Authenticate(ctx, signature.Request{
+			APIKey:    c.GetHeader(signature.HeaderAPIKey),
+			Timestamp: timestamp,
+			Signature: c.GetHeader(signature.HeaderSignature),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+		})
+		if err != nil {
+			log.Context(ctx).Error(err)
+			response.Failed(c, err)
+			c.Abort()
+			return
+		}
+
+		newCtx := jwt.SavePayloadToContext(ctx, payload)
+		c.Request = c.Request.WithContext(newCtx)
+
+		c.Next()
+	}
+}
+
+// ValidateJwtTokenOrAPIKey is a Gin middleware accepting either JWT token or signed request,
+// request with the API key header validated using ValidateAPIKey.
+func ValidateJwtTokenOrAPIKey(jwtManager jwt.Manager, revocationList jwt.RevocationList, authenticator APIKeyAuthenticator) gin.HandlerFunc {
+	validateJwtToken := ValidateJwtToken(jwtManager, revocationList)
+	validateAPIKey := ValidateAPIKey(authenticator)
+
+	return func(c *gin.Context) {
+		if c.GetHeader(signature.HeaderAPIKey) != "" {
+			validateAPIKey(c)
+			return
+		}
+
+		validateJwtToken(c)
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-skeleton-code/pkg/client"
+)
+
+// SaveClientInfo is a Gin middleware to save client IP address and user agent to the request context.
+func SaveClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		newCtx := client.SaveToContext(c.Request.Context(), client.Info{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(newCtx)
+
+		c.Next()
+	}
+}
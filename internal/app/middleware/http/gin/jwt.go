@@ -12,7 +12,8 @@ import (
 )
 
 // ValidateJwtToken is a Gin middleware to validate JWT tokens from the Authorization header.
-func ValidateJwtToken(secretKey []byte) gin.HandlerFunc {
+// Token found in the revocation list is rejected.
+func ValidateJwtToken(jwtManager jwt.Manager, revocationList jwt.RevocationList) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the context from the request
 		ctx := c.Request.Context()
@@ -29,14 +30,28 @@ func ValidateJwtToken(secretKey []byte) gin.HandlerFunc {
 		}
 
 		// Validate the JWT token
-		payload, err := jwt.Validate(tokenString, secretKey)
+		payload, err := jwtManager.Validate(tokenString)
 		if err != nil {
 			log.Context(ctx).Error(err)
-			response.Failed(c, serverError.ErrUnauthorized(err))
+			response.Failed(c, jwt.ServerError(err))
 			c.Abort() // Abort the request chain if validation fails
 			return
 		}
 
+		revoked, err := revocationList.IsRevoked(ctx, payload)
+		if err != nil {
+			response.Failed(c, serverError.ErrGeneralDatabaseError(err))
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			log.Context(ctx).Warn(jwt.ErrTokenRevoked)
+			response.Failed(c, jwt.ServerError(jwt.ErrTokenRevoked))
+			c.Abort()
+			return
+		}
+
 		// Save the JWT payload to the context
 		newCtx := jwt.SavePayloadToContext(ctx, payload)
 		c.Request = c.Request.WithContext(newCtx)
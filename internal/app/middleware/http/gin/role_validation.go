@@ -0,0 +1,68 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverError "go-skeleton-code/pkg/error"
+	"go-skeleton-code/pkg/jwt"
+	"go-skeleton-code/pkg/log"
+	response "go-skeleton-code/pkg/response/gin"
+)
+
+// ValidateRole is a Gin middleware to allow only specific roles, must be placed after ValidateJwtToken.
+func ValidateRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		jwtPayload := jwt.GetPayloadFromContext(ctx)
+
+		for _, role := range roles {
+			if role == jwtPayload.Role {
+				c.Next()
+				return
+			}
+		}
+
+		log.Context(ctx).Warn(jwt.ErrUnauthorizedRole)
+		response.Failed(c, serverError.ErrUnauthorized(jwt.ErrUnauthorizedRole))
+		c.Abort()
+	}
+}
+
+// RequirePermission is a Gin middleware to allow only token granted with every permission, must be placed after ValidateJwtToken.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		jwtPayload := jwt.GetPayloadFromContext(ctx)
+
+		for _, permission := range permissions {
+			if !jwtPayload.HasPermission(permission) {
+				log.Context(ctx).Warnf("%v, missing %v", jwt.ErrPermissionDenied, permission)
+				response.Failed(c, serverError.ErrUnauthorized(jwt.ErrPermissionDenied))
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScope is a Gin middleware to allow only API key granted with the scope, request using JWT token always allowed.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		jwtPayload := jwt.GetPayloadFromContext(ctx)
+
+		if !jwtPayload.HasScope(scope) {
+			log.Context(ctx).Warnf("%v, missing %v", jwt.ErrScopeNotGranted, scope)
+			response.Failed(c, serverError.ErrUnauthorized(jwt.ErrScopeNotGranted))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
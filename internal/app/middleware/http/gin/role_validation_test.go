@@ -0,0 +1,98 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"go-skeleton-code/pkg/jwt"
+)
+
+// serveWithPayload runs the middleware with the payload saved by ValidateJwtToken, returns the response status code.
+func serveWithPayload(t *testing.T, payload jwt.Payload, middleware gin.HandlerFunc) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/",
+		func(c *gin.Context) {
+			c.Request = c.Request.WithContext(jwt.SavePayloadToContext(c.Request.Context(), payload))
+			c.Next()
+		},
+		middleware,
+		func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	return recorder.Code
+}
+
+func TestValidateRole(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     string
+		expected int
+	}{
+		{name: "allowed role", role: "admin", expected: http.StatusOK},
+		{name: "other role", role: "user", expected: http.StatusUnauthorized},
+		{name: "without role", role: "", expected: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code := serveWithPayload(t, jwt.Payload{UserID: 1, Role: tc.role}, ValidateRole("admin", "support"))
+			if code != tc.expected {
+				t.Errorf("expected status %v, got %v", tc.expected, code)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	testCases := []struct {
+		name        string
+		permissions []string
+		expected    int
+	}{
+		{name: "every permission granted", permissions: []string{"kyc:review", "audit:read"}, expected: http.StatusOK},
+		{name: "only some permission granted", permissions: []string{"kyc:review"}, expected: http.StatusUnauthorized},
+		{name: "without permission", permissions: nil, expected: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := jwt.Payload{UserID: 1, Role: "admin", Permissions: tc.permissions}
+			code := serveWithPayload(t, payload, RequirePermission("kyc:review", "audit:read"))
+			if code != tc.expected {
+				t.Errorf("expected status %v, got %v", tc.expected, code)
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payload  jwt.Payload
+		expected int
+	}{
+		{name: "login token", payload: jwt.Payload{UserID: 1}, expected: http.StatusOK},
+		{name: "api key with scope", payload: jwt.Payload{UserID: 1, APIKeyID: "key", Scopes: []string{"read", "trade"}}, expected: http.StatusOK},
+		{name: "api key without scope", payload: jwt.Payload{UserID: 1, APIKeyID: "key", Scopes: []string{"read"}}, expected: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code := serveWithPayload(t, tc.payload, RequireScope("trade"))
+			if code != tc.expected {
+				t.Errorf("expected status %v, got %v", tc.expected, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process least recently used cache, every entry expired after ttl.
+type LRU[K comparable, V any] struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	list     *list.List
+	items    map[K]*list.Element
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiredAt time.Time
+}
+
+// NewLRU returns new LRU cache, oldest entry will be removed when capacity is reached.
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+
+	return &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		list:     list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Get returns cached value and whether the value found and not yet expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var empty V
+
+	element, found := c.items[key]
+	if !found {
+		return empty, false
+	}
+
+	item := element.Value.(*entry[K, V])
+	if time.Now().After(item.expiredAt) {
+		c.removeElement(element)
+		return empty, false
+	}
+
+	c.list.MoveToFront(element)
+	return item.value, true
+}
+
+// Set add or replace value in the cache.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiredAt := time.Now().Add(c.ttl)
+
+	if element, found := c.items[key]; found {
+		item := element.Value.(*entry[K, V])
+		item.value = value
+		item.expiredAt = expiredAt
+		c.list.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.list.PushFront(&entry[K, V]{key: key, value: value, expiredAt: expiredAt})
+
+	if c.list.Len() > c.capacity {
+		c.removeElement(c.list.Back())
+	}
+}
+
+// Delete remove value from the cache.
+func (c *LRU[K, V]) Delete(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
+}
+
+// Purge remove all value from the cache.
+func (c *LRU[K, V]) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.list.Init()
+	c.items = make(map[K]*list.Element)
+}
+
+func (c *LRU[K, V]) removeElement(element *list.Element) {
+	c.list.Remove(element)
+	delete(c.items, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,24 @@
+package client
+
+import "context"
+
+type contextKey struct{}
+
+// Info describes the client sending the request.
+type Info struct {
+	IPAddress string
+	UserAgent string
+}
+
+func SaveToContext(parent context.Context, info Info) context.Context {
+	return context.WithValue(parent, contextKey{}, info)
+}
+
+func GetFromContext(ctx context.Context) Info {
+	info, ok := ctx.Value(contextKey{}).(Info)
+	if ok {
+		return info
+	}
+
+	return Info{}
+}
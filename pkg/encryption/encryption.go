@@ -0,0 +1,83 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"go-skeleton-code/pkg/log"
+)
+
+var (
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+	ErrMissingKey        = errors.New("encryption key not configured")
+)
+
+// Encryptor is used for encrypting sensitive data before stored.
+//
+//counterfeiter:generate -o ./mock . Encryptor
+type Encryptor interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+type aesEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESEncryptor returns AES-GCM encryptor, key is base64 encoded 16, 24 or 32 bytes.
+func NewAESEncryptor(key string) Encryptor {
+	if key == "" {
+		log.Fatal(ErrMissingKey)
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		log.Fatalf("invalid encryption key, %v", err)
+	}
+
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		log.Fatalf("invalid encryption key, %v", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		log.Fatalf("failed creating encryptor, %v", err)
+	}
+
+	return &aesEncryptor{aead: aead}
+}
+
+// Encrypt returns base64 encoded nonce followed by the ciphertext.
+func (e *aesEncryptor) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (e *aesEncryptor) Decrypt(ciphertext string) (string, error) {
+	rawCiphertext, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rawCiphertext) < e.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, rawCiphertext := rawCiphertext[:e.aead.NonceSize()], rawCiphertext[e.aead.NonceSize():]
+	plaintext, err := e.aead.Open(nil, nonce, rawCiphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
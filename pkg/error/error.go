@@ -18,12 +18,71 @@ var (
 	ErrUserBlocked = func(err error) ServerError {
 		return ServerError{http.StatusUnauthorized, 902, "login failed user blocked", err}
 	}
+	ErrInvalidRefreshToken = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 903, "invalid refresh token", err}
+	}
+	ErrTokenExpired = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 904, "token expired", err}
+	}
+	ErrTokenNotValidYet = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 905, "token not valid yet", err}
+	}
+	ErrInvalidTokenClaim = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 906, "invalid token claims", err}
+	}
+	ErrInvalidToken = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 907, "invalid token", err}
+	}
+	ErrTokenRevoked = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 908, "token revoked", err}
+	}
+	ErrEmailNotVerified = func(err error) ServerError {
+		return ServerError{http.StatusForbidden, 909, "email not verified", err}
+	}
+	ErrTooManyRequests = func(err error) ServerError {
+		return ServerError{http.StatusTooManyRequests, 910, "too many requests", err}
+	}
+	ErrInvalidVerificationToken = func(err error) ServerError {
+		return ServerError{http.StatusBadRequest, 911, "invalid or expired token", err}
+	}
+	ErrInvalidPassword = func(err error) ServerError {
+		return ServerError{http.StatusBadRequest, 912, "invalid password", err}
+	}
+	ErrInvalidOTP = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 913, "invalid one time password", err}
+	}
+	ErrAccountLocked = func(err error) ServerError {
+		return ServerError{http.StatusLocked, 914, "account temporarily locked", err}
+	}
+	ErrInvalidAPIKey = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 915, "invalid api key", err}
+	}
+	ErrInvalidSignature = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 916, "invalid request signature", err}
+	}
+	ErrExternalLoginFailed = func(err error) ServerError {
+		return ServerError{http.StatusUnauthorized, 917, "external login failed", err}
+	}
 	ErrGeneralDatabaseError = func(err error) ServerError {
 		return ServerError{http.StatusInternalServerError, 800, "internal dependencies error", err}
 	}
 	ErrDataNotFound = func(err error) ServerError {
 		return ServerError{http.StatusBadRequest, 700, "data not found", err}
 	}
+	ErrInvalidRequest = func(err error) ServerError {
+		return ServerError{http.StatusBadRequest, 701, "invalid request", err}
+	}
+	ErrConflict = func(err error) ServerError {
+		return ServerError{http.StatusConflict, 702, "data already exists", err}
+	}
+	ErrTradingHalted = func(err error) ServerError {
+		return ServerError{http.StatusBadRequest, 600, "trading halted for the pair", err}
+	}
+	// The message tells the required KYC level
+	ErrKYCLevelRequired = func(level string) ServerError {
+		err := fmt.Errorf("kyc level %v required", level)
+		return ServerError{http.StatusForbidden, 601, err.Error(), err}
+	}
 )
 
 type ServerError struct {
@@ -0,0 +1,33 @@
+package gorm
+
+import (
+	"errors"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	postgresUniqueViolation = "23505"
+	mysqlDuplicateEntry     = 1062
+)
+
+// IsUniqueViolation reports whether the error caused by duplicate value on unique index or constraint.
+func IsUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == postgresUniqueViolation
+	}
+
+	var mysqlErr *mysqlDriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+
+	return false
+}
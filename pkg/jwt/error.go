@@ -0,0 +1,26 @@
+package jwt
+
+import (
+	"errors"
+
+	serverError "go-skeleton-code/pkg/error"
+)
+
+// ServerError maps token error to server error, client should request new access token
+// using the refresh token on expired token and login again on the other errors.
+func ServerError(err error) serverError.ServerError {
+	switch {
+	case errors.Is(err, ErrTokenExpired):
+		return serverError.ErrTokenExpired(err)
+	case errors.Is(err, ErrTokenNotValidYet), errors.Is(err, ErrTokenUsedBeforeIssued):
+		return serverError.ErrTokenNotValidYet(err)
+	case errors.Is(err, ErrInvalidIssuer), errors.Is(err, ErrInvalidAudience):
+		return serverError.ErrInvalidTokenClaim(err)
+	case errors.Is(err, ErrTokenRevoked):
+		return serverError.ErrTokenRevoked(err)
+	case errors.Is(err, ErrTokenNotFound):
+		return serverError.ErrUnauthorized(err)
+	default:
+		return serverError.ErrInvalidToken(err)
+	}
+}
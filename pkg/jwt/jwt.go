@@ -2,8 +2,10 @@ package jwt
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/spf13/cast"
@@ -12,18 +14,64 @@ import (
 type contextKey struct{}
 
 type Payload struct {
-	UserID int    `json:"userId"`
-	Email  string `json:"email"`
-	Exp    int64  `json:"exp"`
-	Role   string `json:"role"`
+	ID          string   `json:"jti"` // Unique token id, used for revoking single token
+	SessionID   string   `json:"sid"` // Login session, every token of the session revoked when the session ended
+	UserID      int      `json:"userId"`
+	Email       string   `json:"email"`
+	Exp         int64    `json:"exp"`
+	IssuedAt    int64    `json:"iat"`
+	IssuedAtMs  int64    `json:"iat_ms"` // Issued time in millisecond, compared with user revocation time
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	APIKeyID    string   `json:"-"` // Set when authenticated using API key instead of token
+	Scopes      []string `json:"-"` // Scopes granted to the API key
 }
 
 var (
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenNotFound      = errors.New("token not found")
 	ErrInvalidClaimFormat = errors.New("invalid claims format")
+	ErrUnauthorizedRole   = errors.New("unauthorized role")
+	ErrTokenRevoked       = errors.New("token revoked")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUnknownSigningKey  = errors.New("unknown signing key")
+	ErrScopeNotGranted    = errors.New("scope not granted to the api key")
+	ErrKeyPairMismatch    = errors.New("public key does not match the private key")
+
+	// Claim validation errors
+	ErrTokenExpired          = errors.New("token expired")
+	ErrTokenNotValidYet      = errors.New("token not valid yet")
+	ErrTokenUsedBeforeIssued = errors.New("token used before issued")
+	ErrInvalidIssuer         = errors.New("invalid token issuer")
+	ErrInvalidAudience       = errors.New("invalid token audience")
 )
 
+// HasPermission reports whether the token granted the permission.
+func (p Payload) HasPermission(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasScope reports whether the API key granted the scope, always true for token issued by login.
+func (p Payload) HasScope(scope string) bool {
+	if p.APIKeyID == "" {
+		return true
+	}
+
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
 func SavePayloadToContext(parent context.Context, payload Payload) context.Context {
 	return context.WithValue(parent, contextKey{}, payload)
 }
@@ -37,44 +85,62 @@ func GetPayloadFromContext(ctx context.Context) Payload {
 	return Payload{}
 }
 
-func Validate(tokenString string, secretKey []byte) (Payload, error) {
-	if tokenString == "" {
-		return Payload{}, ErrTokenNotFound
-	}
-
-	// Parse the JWT token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+// toClaims converts payload to token claims, token id and issued time filled when empty.
+func toClaims(payload Payload) (jwt.MapClaims, error) {
+	if payload.ID == "" {
+		tokenID, err := generateTokenID()
+		if err != nil {
+			return nil, err
 		}
+		payload.ID = tokenID
+	}
 
-		// Return the secret key for validation
-		return secretKey, nil
-	})
-
-	// Got error when parsing JWT token
-	if err != nil {
-		return Payload{}, err
+	if payload.IssuedAt == 0 {
+		now := time.Now()
+		payload.IssuedAt = now.Unix()
+		payload.IssuedAtMs = now.UnixMilli()
 	}
 
-	// Token is invalid
-	if !token.Valid {
-		return Payload{}, ErrInvalidToken
+	claims := jwt.MapClaims{
+		"jti":    payload.ID,
+		"iat":    payload.IssuedAt,
+		"iat_ms": payload.IssuedAtMs,
+		"userId": payload.UserID,
+		"email":  payload.Email,
+		"exp":    payload.Exp,
+	}
+	if payload.SessionID != "" {
+		claims["sid"] = payload.SessionID
+	}
+	if payload.Role != "" {
+		claims["role"] = payload.Role
+	}
+	if len(payload.Permissions) > 0 {
+		claims["permissions"] = payload.Permissions
 	}
 
-	// Access the claims as a map
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return Payload{}, ErrInvalidClaimFormat
+	return claims, nil
+}
+
+func fromClaims(claims jwt.MapClaims) Payload {
+	return Payload{
+		ID:          cast.ToString(claims["jti"]),
+		SessionID:   cast.ToString(claims["sid"]),
+		UserID:      cast.ToInt(claims["userId"]),
+		Email:       cast.ToString(claims["email"]),
+		Exp:         cast.ToInt64(claims["exp"]),
+		IssuedAt:    cast.ToInt64(claims["iat"]),
+		IssuedAtMs:  cast.ToInt64(claims["iat_ms"]),
+		Role:        cast.ToString(claims["role"]),
+		Permissions: cast.ToStringSlice(claims["permissions"]),
 	}
+}
 
-	payload := Payload{
-		UserID: cast.ToInt(claims["userId"]),
-		Email:  cast.ToString(claims["email"]),
-		Exp:    cast.ToInt64(claims["exp"]),
+func generateTokenID() (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
 	}
 
-	// Return the token payload
-	return payload, nil
+	return hex.EncodeToString(randomBytes), nil
 }
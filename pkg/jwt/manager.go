@@ -0,0 +1,320 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/spf13/cast"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+// Manager signs new token with the active key and verifies token with every configured key.
+//
+//counterfeiter:generate -o ./mock . Manager
+type Manager interface {
+	Generate(payload Payload) (string, error)
+	Validate(tokenString string) (Payload, error)
+	// JWKS returns public keys for verifying token outside this service
+	JWKS() JSONWebKeySet
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // EC curve
+	X         string `json:"x,omitempty"`   // EC x coordinate
+	Y         string `json:"y,omitempty"`   // EC y coordinate
+}
+
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey any
+	publicKey  any
+}
+
+type manager struct {
+	secretKey  []byte // HS256 shared secret
+	signingKey *signingKey
+	keys       map[string]*signingKey
+	jwks       JSONWebKeySet
+	issuer     string
+	audience   string
+	leeway     time.Duration
+}
+
+// NewManager loads every key in the configuration, stop the application when a key is invalid.
+func NewManager(jwtConfig config.Jwt) Manager {
+	m := &manager{
+		secretKey: []byte(jwtConfig.Key),
+		keys:      make(map[string]*signingKey),
+		jwks:      JSONWebKeySet{Keys: []JSONWebKey{}},
+		issuer:    jwtConfig.Issuer,
+		audience:  jwtConfig.Audience,
+		leeway:    jwtConfig.Leeway,
+	}
+
+	for _, keyConfig := range jwtConfig.Keys {
+		key, err := loadKey(keyConfig)
+		if err != nil {
+			log.Fatalf("failed loading jwt key %v, %v", keyConfig.ID, err)
+		}
+
+		jwk, err := toJSONWebKey(key)
+		if err != nil {
+			log.Fatalf("failed loading jwt key %v, %v", keyConfig.ID, err)
+		}
+
+		m.keys[key.id] = key
+		m.jwks.Keys = append(m.jwks.Keys, jwk)
+	}
+
+	if jwtConfig.SigningKeyID != "" {
+		key, found := m.keys[jwtConfig.SigningKeyID]
+		if !found || key.privateKey == nil {
+			log.Fatalf("jwt signing key %v not found or has no private key", jwtConfig.SigningKeyID)
+		}
+		m.signingKey = key
+	} else if len(m.secretKey) == 0 {
+		log.Fatal("jwt signing key or shared secret is required")
+	}
+
+	return m
+}
+
+func (m *manager) Generate(payload Payload) (string, error) {
+	claims, err := toClaims(payload)
+	if err != nil {
+		return "", err
+	}
+
+	claims["nbf"] = claims["iat"]
+	if m.issuer != "" {
+		claims["iss"] = m.issuer
+	}
+	if m.audience != "" {
+		claims["aud"] = m.audience
+	}
+
+	// Shared secret used when no asymmetric signing key configured
+	if m.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secretKey)
+	}
+
+	token := jwt.NewWithClaims(m.signingKey.method, claims)
+	token.Header["kid"] = m.signingKey.id
+
+	return token.SignedString(m.signingKey.privateKey)
+}
+
+func (m *manager) Validate(tokenString string) (Payload, error) {
+	if tokenString == "" {
+		return Payload{}, ErrTokenNotFound
+	}
+
+	// Parse the JWT token, claims validated separately to allow clock skew
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.Parse(tokenString, m.verificationKey)
+
+	// Got error when parsing JWT token
+	if err != nil {
+		return Payload{}, fmt.Errorf("%w, %v", ErrInvalidToken, err)
+	}
+
+	// Token is invalid
+	if !token.Valid {
+		return Payload{}, ErrInvalidToken
+	}
+
+	// Access the claims as a map
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return Payload{}, ErrInvalidClaimFormat
+	}
+
+	if err := m.validateClaims(claims); err != nil {
+		return Payload{}, err
+	}
+
+	// Return the token payload
+	return fromClaims(claims), nil
+}
+
+// validateClaims checks registered claims, time based claims tolerate the configured leeway.
+func (m *manager) validateClaims(claims jwt.MapClaims) error {
+	now := time.Now()
+
+	expiredAt, found := claims["exp"]
+	if !found || now.After(time.Unix(cast.ToInt64(expiredAt), 0).Add(m.leeway)) {
+		return ErrTokenExpired
+	}
+
+	if notBefore, found := claims["nbf"]; found && now.Add(m.leeway).Before(time.Unix(cast.ToInt64(notBefore), 0)) {
+		return ErrTokenNotValidYet
+	}
+
+	if issuedAt, found := claims["iat"]; found && now.Add(m.leeway).Before(time.Unix(cast.ToInt64(issuedAt), 0)) {
+		return ErrTokenUsedBeforeIssued
+	}
+
+	if m.issuer != "" && !claims.VerifyIssuer(m.issuer, true) {
+		return ErrInvalidIssuer
+	}
+
+	if m.audience != "" && !claims.VerifyAudience(m.audience, true) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func (m *manager) JWKS() JSONWebKeySet {
+	return m.jwks
+}
+
+// verificationKey returns key for the token, the algorithm must match the key to avoid algorithm confusion.
+func (m *manager) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	// Token without kid signed using shared secret
+	if keyID == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(m.secretKey) == 0 {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return m.secretKey, nil
+	}
+
+	key, found := m.keys[keyID]
+	if !found {
+		return nil, ErrUnknownSigningKey
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.publicKey, nil
+}
+
+// loadKey reads the key files, public key must match the private key when both configured.
+func loadKey(keyConfig config.JwtKey) (*signingKey, error) {
+	method := jwt.GetSigningMethod(keyConfig.Algorithm)
+	key := &signingKey{id: keyConfig.ID, method: method}
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		if keyConfig.PrivateKey != "" {
+			pemBytes, err := os.ReadFile(keyConfig.PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, err
+			}
+			key.privateKey, key.publicKey = privateKey, &privateKey.PublicKey
+		}
+
+		if keyConfig.PublicKey != "" {
+			pemBytes, err := os.ReadFile(keyConfig.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, err
+			}
+			if key.publicKey != nil && !publicKey.Equal(key.publicKey) {
+				return nil, ErrKeyPairMismatch
+			}
+			key.publicKey = publicKey
+		}
+
+	case *jwt.SigningMethodECDSA:
+		if keyConfig.PrivateKey != "" {
+			pemBytes, err := os.ReadFile(keyConfig.PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+
+			privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, err
+			}
+			key.privateKey, key.publicKey = privateKey, &privateKey.PublicKey
+		}
+
+		if keyConfig.PublicKey != "" {
+			pemBytes, err := os.ReadFile(keyConfig.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			publicKey, err := jwt.ParseECPublicKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, err
+			}
+			if key.publicKey != nil && !publicKey.Equal(key.publicKey) {
+				return nil, ErrKeyPairMismatch
+			}
+			key.publicKey = publicKey
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %v", keyConfig.Algorithm)
+	}
+
+	if key.publicKey == nil {
+		return nil, fmt.Errorf("private or public key is required")
+	}
+
+	return key, nil
+}
+
+func toJSONWebKey(key *signingKey) (JSONWebKey, error) {
+	jwk := JSONWebKey{
+		KeyID:     key.id,
+		Use:       "sig",
+		Algorithm: key.method.Alg(),
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+
+	case *ecdsa.PublicKey:
+		method := key.method.(*jwt.SigningMethodECDSA)
+		if publicKey.Curve.Params().BitSize != method.CurveBits {
+			return JSONWebKey{}, fmt.Errorf("curve %v does not match algorithm %v", publicKey.Curve.Params().Name, method.Alg())
+		}
+
+		// Coordinate must be padded to the curve size
+		size := (method.CurveBits + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = publicKey.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+	}
+
+	return jwk, nil
+}
@@ -0,0 +1,259 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"go-skeleton-code/pkg/jwt"
+	"sync"
+)
+
+type FakeManager struct {
+	GenerateStub        func(jwt.Payload) (string, error)
+	generateMutex       sync.RWMutex
+	generateArgsForCall []struct {
+		arg1 jwt.Payload
+	}
+	generateReturns struct {
+		result1 string
+		result2 error
+	}
+	generateReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	JWKSStub        func() jwt.JSONWebKeySet
+	jWKSMutex       sync.RWMutex
+	jWKSArgsForCall []struct {
+	}
+	jWKSReturns struct {
+		result1 jwt.JSONWebKeySet
+	}
+	jWKSReturnsOnCall map[int]struct {
+		result1 jwt.JSONWebKeySet
+	}
+	ValidateStub        func(string) (jwt.Payload, error)
+	validateMutex       sync.RWMutex
+	validateArgsForCall []struct {
+		arg1 string
+	}
+	validateReturns struct {
+		result1 jwt.Payload
+		result2 error
+	}
+	validateReturnsOnCall map[int]struct {
+		result1 jwt.Payload
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeManager) Generate(arg1 jwt.Payload) (string, error) {
+	fake.generateMutex.Lock()
+	ret, specificReturn := fake.generateReturnsOnCall[len(fake.generateArgsForCall)]
+	fake.generateArgsForCall = append(fake.generateArgsForCall, struct {
+		arg1 jwt.Payload
+	}{arg1})
+	stub := fake.GenerateStub
+	fakeReturns := fake.generateReturns
+	fake.recordInvocation("Generate", []interface{}{arg1})
+	fake.generateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeManager) GenerateCallCount() int {
+	fake.generateMutex.RLock()
+	defer fake.generateMutex.RUnlock()
+	return len(fake.generateArgsForCall)
+}
+
+func (fake *FakeManager) GenerateCalls(stub func(jwt.Payload) (string, error)) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = stub
+}
+
+func (fake *FakeManager) GenerateArgsForCall(i int) jwt.Payload {
+	fake.generateMutex.RLock()
+	defer fake.generateMutex.RUnlock()
+	argsForCall := fake.generateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) GenerateReturns(result1 string, result2 error) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = nil
+	fake.generateReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) GenerateReturnsOnCall(i int, result1 string, result2 error) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = nil
+	if fake.generateReturnsOnCall == nil {
+		fake.generateReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.generateReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) JWKS() jwt.JSONWebKeySet {
+	fake.jWKSMutex.Lock()
+	ret, specificReturn := fake.jWKSReturnsOnCall[len(fake.jWKSArgsForCall)]
+	fake.jWKSArgsForCall = append(fake.jWKSArgsForCall, struct {
+	}{})
+	stub := fake.JWKSStub
+	fakeReturns := fake.jWKSReturns
+	fake.recordInvocation("JWKS", []interface{}{})
+	fake.jWKSMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) JWKSCallCount() int {
+	fake.jWKSMutex.RLock()
+	defer fake.jWKSMutex.RUnlock()
+	return len(fake.jWKSArgsForCall)
+}
+
+func (fake *FakeManager) JWKSCalls(stub func() jwt.JSONWebKeySet) {
+	fake.jWKSMutex.Lock()
+	defer fake.jWKSMutex.Unlock()
+	fake.JWKSStub = stub
+}
+
+func (fake *FakeManager) JWKSReturns(result1 jwt.JSONWebKeySet) {
+	fake.jWKSMutex.Lock()
+	defer fake.jWKSMutex.Unlock()
+	fake.JWKSStub = nil
+	fake.jWKSReturns = struct {
+		result1 jwt.JSONWebKeySet
+	}{result1}
+}
+
+func (fake *FakeManager) JWKSReturnsOnCall(i int, result1 jwt.JSONWebKeySet) {
+	fake.jWKSMutex.Lock()
+	defer fake.jWKSMutex.Unlock()
+	fake.JWKSStub = nil
+	if fake.jWKSReturnsOnCall == nil {
+		fake.jWKSReturnsOnCall = make(map[int]struct {
+			result1 jwt.JSONWebKeySet
+		})
+	}
+	fake.jWKSReturnsOnCall[i] = struct {
+		result1 jwt.JSONWebKeySet
+	}{result1}
+}
+
+func (fake *FakeManager) Validate(arg1 string) (jwt.Payload, error) {
+	fake.validateMutex.Lock()
+	ret, specificReturn := fake.validateReturnsOnCall[len(fake.validateArgsForCall)]
+	fake.validateArgsForCall = append(fake.validateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ValidateStub
+	fakeReturns := fake.validateReturns
+	fake.recordInvocation("Validate", []interface{}{arg1})
+	fake.validateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeManager) ValidateCallCount() int {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	return len(fake.validateArgsForCall)
+}
+
+func (fake *FakeManager) ValidateCalls(stub func(string) (jwt.Payload, error)) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = stub
+}
+
+func (fake *FakeManager) ValidateArgsForCall(i int) string {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	argsForCall := fake.validateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) ValidateReturns(result1 jwt.Payload, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	fake.validateReturns = struct {
+		result1 jwt.Payload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) ValidateReturnsOnCall(i int, result1 jwt.Payload, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	if fake.validateReturnsOnCall == nil {
+		fake.validateReturnsOnCall = make(map[int]struct {
+			result1 jwt.Payload
+			result2 error
+		})
+	}
+	fake.validateReturnsOnCall[i] = struct {
+		result1 jwt.Payload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.generateMutex.RLock()
+	defer fake.generateMutex.RUnlock()
+	fake.jWKSMutex.RLock()
+	defer fake.jWKSMutex.RUnlock()
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ jwt.Manager = new(FakeManager)
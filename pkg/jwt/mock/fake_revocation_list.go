@@ -0,0 +1,346 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"go-skeleton-code/pkg/jwt"
+	"sync"
+)
+
+type FakeRevocationList struct {
+	IsRevokedStub        func(context.Context, jwt.Payload) (bool, error)
+	isRevokedMutex       sync.RWMutex
+	isRevokedArgsForCall []struct {
+		arg1 context.Context
+		arg2 jwt.Payload
+	}
+	isRevokedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isRevokedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeSessionStub        func(context.Context, string) error
+	revokeSessionMutex       sync.RWMutex
+	revokeSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	revokeSessionReturns struct {
+		result1 error
+	}
+	revokeSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeTokenStub        func(context.Context, jwt.Payload) error
+	revokeTokenMutex       sync.RWMutex
+	revokeTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 jwt.Payload
+	}
+	revokeTokenReturns struct {
+		result1 error
+	}
+	revokeTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeUserStub        func(context.Context, int) error
+	revokeUserMutex       sync.RWMutex
+	revokeUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	revokeUserReturns struct {
+		result1 error
+	}
+	revokeUserReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRevocationList) IsRevoked(arg1 context.Context, arg2 jwt.Payload) (bool, error) {
+	fake.isRevokedMutex.Lock()
+	ret, specificReturn := fake.isRevokedReturnsOnCall[len(fake.isRevokedArgsForCall)]
+	fake.isRevokedArgsForCall = append(fake.isRevokedArgsForCall, struct {
+		arg1 context.Context
+		arg2 jwt.Payload
+	}{arg1, arg2})
+	stub := fake.IsRevokedStub
+	fakeReturns := fake.isRevokedReturns
+	fake.recordInvocation("IsRevoked", []interface{}{arg1, arg2})
+	fake.isRevokedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRevocationList) IsRevokedCallCount() int {
+	fake.isRevokedMutex.RLock()
+	defer fake.isRevokedMutex.RUnlock()
+	return len(fake.isRevokedArgsForCall)
+}
+
+func (fake *FakeRevocationList) IsRevokedCalls(stub func(context.Context, jwt.Payload) (bool, error)) {
+	fake.isRevokedMutex.Lock()
+	defer fake.isRevokedMutex.Unlock()
+	fake.IsRevokedStub = stub
+}
+
+func (fake *FakeRevocationList) IsRevokedArgsForCall(i int) (context.Context, jwt.Payload) {
+	fake.isRevokedMutex.RLock()
+	defer fake.isRevokedMutex.RUnlock()
+	argsForCall := fake.isRevokedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRevocationList) IsRevokedReturns(result1 bool, result2 error) {
+	fake.isRevokedMutex.Lock()
+	defer fake.isRevokedMutex.Unlock()
+	fake.IsRevokedStub = nil
+	fake.isRevokedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevocationList) IsRevokedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isRevokedMutex.Lock()
+	defer fake.isRevokedMutex.Unlock()
+	fake.IsRevokedStub = nil
+	if fake.isRevokedReturnsOnCall == nil {
+		fake.isRevokedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isRevokedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevocationList) RevokeSession(arg1 context.Context, arg2 string) error {
+	fake.revokeSessionMutex.Lock()
+	ret, specificReturn := fake.revokeSessionReturnsOnCall[len(fake.revokeSessionArgsForCall)]
+	fake.revokeSessionArgsForCall = append(fake.revokeSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeSessionStub
+	fakeReturns := fake.revokeSessionReturns
+	fake.recordInvocation("RevokeSession", []interface{}{arg1, arg2})
+	fake.revokeSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRevocationList) RevokeSessionCallCount() int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return len(fake.revokeSessionArgsForCall)
+}
+
+func (fake *FakeRevocationList) RevokeSessionCalls(stub func(context.Context, string) error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = stub
+}
+
+func (fake *FakeRevocationList) RevokeSessionArgsForCall(i int) (context.Context, string) {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	argsForCall := fake.revokeSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRevocationList) RevokeSessionReturns(result1 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	fake.revokeSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) RevokeSessionReturnsOnCall(i int, result1 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	if fake.revokeSessionReturnsOnCall == nil {
+		fake.revokeSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) RevokeToken(arg1 context.Context, arg2 jwt.Payload) error {
+	fake.revokeTokenMutex.Lock()
+	ret, specificReturn := fake.revokeTokenReturnsOnCall[len(fake.revokeTokenArgsForCall)]
+	fake.revokeTokenArgsForCall = append(fake.revokeTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 jwt.Payload
+	}{arg1, arg2})
+	stub := fake.RevokeTokenStub
+	fakeReturns := fake.revokeTokenReturns
+	fake.recordInvocation("RevokeToken", []interface{}{arg1, arg2})
+	fake.revokeTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRevocationList) RevokeTokenCallCount() int {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	return len(fake.revokeTokenArgsForCall)
+}
+
+func (fake *FakeRevocationList) RevokeTokenCalls(stub func(context.Context, jwt.Payload) error) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = stub
+}
+
+func (fake *FakeRevocationList) RevokeTokenArgsForCall(i int) (context.Context, jwt.Payload) {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	argsForCall := fake.revokeTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRevocationList) RevokeTokenReturns(result1 error) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = nil
+	fake.revokeTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) RevokeTokenReturnsOnCall(i int, result1 error) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = nil
+	if fake.revokeTokenReturnsOnCall == nil {
+		fake.revokeTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) RevokeUser(arg1 context.Context, arg2 int) error {
+	fake.revokeUserMutex.Lock()
+	ret, specificReturn := fake.revokeUserReturnsOnCall[len(fake.revokeUserArgsForCall)]
+	fake.revokeUserArgsForCall = append(fake.revokeUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeUserStub
+	fakeReturns := fake.revokeUserReturns
+	fake.recordInvocation("RevokeUser", []interface{}{arg1, arg2})
+	fake.revokeUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRevocationList) RevokeUserCallCount() int {
+	fake.revokeUserMutex.RLock()
+	defer fake.revokeUserMutex.RUnlock()
+	return len(fake.revokeUserArgsForCall)
+}
+
+func (fake *FakeRevocationList) RevokeUserCalls(stub func(context.Context, int) error) {
+	fake.revokeUserMutex.Lock()
+	defer fake.revokeUserMutex.Unlock()
+	fake.RevokeUserStub = stub
+}
+
+func (fake *FakeRevocationList) RevokeUserArgsForCall(i int) (context.Context, int) {
+	fake.revokeUserMutex.RLock()
+	defer fake.revokeUserMutex.RUnlock()
+	argsForCall := fake.revokeUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRevocationList) RevokeUserReturns(result1 error) {
+	fake.revokeUserMutex.Lock()
+	defer fake.revokeUserMutex.Unlock()
+	fake.RevokeUserStub = nil
+	fake.revokeUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) RevokeUserReturnsOnCall(i int, result1 error) {
+	fake.revokeUserMutex.Lock()
+	defer fake.revokeUserMutex.Unlock()
+	fake.RevokeUserStub = nil
+	if fake.revokeUserReturnsOnCall == nil {
+		fake.revokeUserReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeUserReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevocationList) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isRevokedMutex.RLock()
+	defer fake.isRevokedMutex.RUnlock()
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	fake.revokeUserMutex.RLock()
+	defer fake.revokeUserMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRevocationList) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ jwt.RevocationList = new(FakeRevocationList)
@@ -0,0 +1,146 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/cast"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/cache"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	revokedTokenKey   = "revoked-token:%v"   // Revoked token id
+	revokedUserKey    = "revoked-user:%v"    // Token issued before this time in millisecond for the user is revoked
+	revokedSessionKey = "revoked-session:%v" // Revoked session id
+)
+
+// RevocationList keeps revoked token until the token expired.
+//
+//counterfeiter:generate -o ./mock . RevocationList
+type RevocationList interface {
+	// RevokeToken revokes single token until the token expired
+	RevokeToken(ctx context.Context, payload Payload) error
+	// RevokeUser revokes every token of the user issued before now
+	RevokeUser(ctx context.Context, userID int) error
+	// RevokeSession revokes every token issued for the session
+	RevokeSession(ctx context.Context, sessionID string) error
+	IsRevoked(ctx context.Context, payload Payload) (bool, error)
+}
+
+type revocationList struct {
+	client          *redis.Client
+	ttl             time.Duration // Longest lifetime of issued token
+	revokedTokens   *cache.LRU[string, bool]
+	revokedUsers    *cache.LRU[int, int64] // User id to revocation time in millisecond, 0 when not revoked
+	revokedSessions *cache.LRU[string, bool]
+}
+
+func NewRevocationList(client *redis.Client, jwtConfig config.Jwt) RevocationList {
+	return &revocationList{
+		client:          client,
+		ttl:             max(jwtConfig.Duration, jwtConfig.RefreshDuration),
+		revokedTokens:   cache.NewLRU[string, bool](jwtConfig.Revocation.LocalSize, jwtConfig.Revocation.LocalTTL),
+		revokedUsers:    cache.NewLRU[int, int64](jwtConfig.Revocation.LocalSize, jwtConfig.Revocation.LocalTTL),
+		revokedSessions: cache.NewLRU[string, bool](jwtConfig.Revocation.LocalSize, jwtConfig.Revocation.LocalTTL),
+	}
+}
+
+func (r *revocationList) RevokeToken(ctx context.Context, payload Payload) error {
+	defer log.Context(ctx).RecordDuration("revoke token").Stop()
+
+	// Token already expired, no need to store
+	ttl := time.Until(time.Unix(payload.Exp, 0))
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := r.client.Set(ctx, fmt.Sprintf(revokedTokenKey, payload.ID), true, ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	r.revokedTokens.Set(payload.ID, true)
+	return nil
+}
+
+func (r *revocationList) RevokeUser(ctx context.Context, userID int) error {
+	defer log.Context(ctx).RecordDuration("revoke user token").Stop()
+
+	revokedAt := time.Now().UnixMilli()
+	if err := r.client.Set(ctx, fmt.Sprintf(revokedUserKey, userID), revokedAt, r.ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	r.revokedUsers.Set(userID, revokedAt)
+	return nil
+}
+
+func (r *revocationList) RevokeSession(ctx context.Context, sessionID string) error {
+	defer log.Context(ctx).RecordDuration("revoke session token").Stop()
+
+	if err := r.client.Set(ctx, fmt.Sprintf(revokedSessionKey, sessionID), true, r.ttl).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	r.revokedSessions.Set(sessionID, true)
+	return nil
+}
+
+// IsRevoked checks the token id, the session and the user revocation time. Every result cached in memory for a
+// short period, so revocation from other instance applies after the local cache expired.
+func (r *revocationList) IsRevoked(ctx context.Context, payload Payload) (bool, error) {
+	if payload.ID != "" {
+		revoked, err := r.isRevokedKey(ctx, r.revokedTokens, revokedTokenKey, payload.ID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if payload.SessionID != "" {
+		revoked, err := r.isRevokedKey(ctx, r.revokedSessions, revokedSessionKey, payload.SessionID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if revokedAt, found := r.revokedUsers.Get(payload.UserID); found {
+		return payload.IssuedAtMs < revokedAt, nil
+	}
+
+	value, err := r.client.Get(ctx, fmt.Sprintf(revokedUserKey, payload.UserID)).Result()
+	if err != nil && err != redis.Nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	revokedAt := cast.ToInt64(value)
+	r.revokedUsers.Set(payload.UserID, revokedAt)
+
+	return payload.IssuedAtMs < revokedAt, nil
+}
+
+// isRevokedKey checks the local cache before redis.
+func (r *revocationList) isRevokedKey(ctx context.Context, local *cache.LRU[string, bool], keyFormat, id string) (bool, error) {
+	if revoked, found := local.Get(id); found {
+		return revoked, nil
+	}
+
+	total, err := r.client.Exists(ctx, fmt.Sprintf(keyFormat, id)).Result()
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return false, err
+	}
+
+	revoked := total > 0
+	local.Set(id, revoked)
+
+	return revoked, nil
+}
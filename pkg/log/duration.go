@@ -18,7 +18,22 @@ type processData struct {
 
 // Stop the total duration a process could take
 func (p processData) Stop() {
+	p.stop("")
+}
+
+// StopWithResult is same as Stop, with process result appended to the process name. e.g. cache hit or miss
+func (p processData) StopWithResult(result string) {
+	p.stop(result)
+}
+
+// stop called directly by Stop and StopWithResult, so both logged with the same caller skip level.
+func (p processData) stop(result string) {
+	name := p.name
+	if result != "" {
+		name = fmt.Sprintf("%s, %s", p.name, result)
+	}
+
 	duration := float64(time.Since(p.timeStart).Nanoseconds()) / 1e6
-	msg := fmt.Sprintf("[%.3fms] %s", duration, p.name)
-	p.request.subLogs = append(p.request.subLogs, subLog{Level: GetCaller(durationCallerName, subLogSkipLevel), Message: msg})
+	msg := fmt.Sprintf("[%.3fms] %s", duration, name)
+	p.request.subLogs = append(p.request.subLogs, subLog{Level: GetCaller(durationCallerName, subLogSkipLevel+1), Message: msg})
 }
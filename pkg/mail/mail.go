@@ -0,0 +1,34 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package mail
+
+import (
+	"context"
+
+	"go-skeleton-code/config"
+)
+
+const (
+	SenderLog  = "log"
+	SenderSMTP = "smtp"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//counterfeiter:generate -o ./mock . Sender
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Init returns sender based on the configuration, log sender used when not configured.
+func Init(cfg config.Mail) Sender {
+	switch cfg.Sender {
+	case SenderSMTP:
+		return NewSMTPSender(cfg)
+	default:
+		return NewLogSender()
+	}
+}
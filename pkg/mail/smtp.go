@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+type smtpSender struct {
+	host    string
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+func NewSMTPSender(cfg config.Mail) Sender {
+	return &smtpSender{
+		host:    cfg.Host,
+		address: fmt.Sprintf("%v:%v", cfg.Host, cfg.Port),
+		from:    cfg.From,
+		auth:    smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host),
+	}
+}
+
+func (s *smtpSender) Send(ctx context.Context, message Message) error {
+	defer log.Context(ctx).RecordDuration("send email").Stop()
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("From: %v\r\n", s.from))
+	content.WriteString(fmt.Sprintf("To: %v\r\n", message.To))
+	content.WriteString(fmt.Sprintf("Subject: %v\r\n", message.Subject))
+	content.WriteString("MIME-Version: 1.0\r\n")
+	content.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	content.WriteString(message.Body)
+
+	if err := s.sendMail(ctx, message.To, []byte(content.String())); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// sendMail is smtp.SendMail bound to the context, connection closed when the context is done.
+func (s *smtpSender) sendMail(ctx context.Context, to string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok && s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(msg); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
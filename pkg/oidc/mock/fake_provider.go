@@ -0,0 +1,272 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"go-skeleton-code/pkg/oidc"
+	"sync"
+)
+
+type FakeProvider struct {
+	AuthCodeURLStub        func(context.Context, string, string, string) (string, error)
+	authCodeURLMutex       sync.RWMutex
+	authCodeURLArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	authCodeURLReturns struct {
+		result1 string
+		result2 error
+	}
+	authCodeURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ExchangeStub        func(context.Context, string, string, string) (oidc.Identity, error)
+	exchangeMutex       sync.RWMutex
+	exchangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	exchangeReturns struct {
+		result1 oidc.Identity
+		result2 error
+	}
+	exchangeReturnsOnCall map[int]struct {
+		result1 oidc.Identity
+		result2 error
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProvider) AuthCodeURL(arg1 context.Context, arg2 string, arg3 string, arg4 string) (string, error) {
+	fake.authCodeURLMutex.Lock()
+	ret, specificReturn := fake.authCodeURLReturnsOnCall[len(fake.authCodeURLArgsForCall)]
+	fake.authCodeURLArgsForCall = append(fake.authCodeURLArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AuthCodeURLStub
+	fakeReturns := fake.authCodeURLReturns
+	fake.recordInvocation("AuthCodeURL", []interface{}{arg1, arg2, arg3, arg4})
+	fake.authCodeURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProvider) AuthCodeURLCallCount() int {
+	fake.authCodeURLMutex.RLock()
+	defer fake.authCodeURLMutex.RUnlock()
+	return len(fake.authCodeURLArgsForCall)
+}
+
+func (fake *FakeProvider) AuthCodeURLCalls(stub func(context.Context, string, string, string) (string, error)) {
+	fake.authCodeURLMutex.Lock()
+	defer fake.authCodeURLMutex.Unlock()
+	fake.AuthCodeURLStub = stub
+}
+
+func (fake *FakeProvider) AuthCodeURLArgsForCall(i int) (context.Context, string, string, string) {
+	fake.authCodeURLMutex.RLock()
+	defer fake.authCodeURLMutex.RUnlock()
+	argsForCall := fake.authCodeURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeProvider) AuthCodeURLReturns(result1 string, result2 error) {
+	fake.authCodeURLMutex.Lock()
+	defer fake.authCodeURLMutex.Unlock()
+	fake.AuthCodeURLStub = nil
+	fake.authCodeURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) AuthCodeURLReturnsOnCall(i int, result1 string, result2 error) {
+	fake.authCodeURLMutex.Lock()
+	defer fake.authCodeURLMutex.Unlock()
+	fake.AuthCodeURLStub = nil
+	if fake.authCodeURLReturnsOnCall == nil {
+		fake.authCodeURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.authCodeURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) Exchange(arg1 context.Context, arg2 string, arg3 string, arg4 string) (oidc.Identity, error) {
+	fake.exchangeMutex.Lock()
+	ret, specificReturn := fake.exchangeReturnsOnCall[len(fake.exchangeArgsForCall)]
+	fake.exchangeArgsForCall = append(fake.exchangeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ExchangeStub
+	fakeReturns := fake.exchangeReturns
+	fake.recordInvocation("Exchange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.exchangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProvider) ExchangeCallCount() int {
+	fake.exchangeMutex.RLock()
+	defer fake.exchangeMutex.RUnlock()
+	return len(fake.exchangeArgsForCall)
+}
+
+func (fake *FakeProvider) ExchangeCalls(stub func(context.Context, string, string, string) (oidc.Identity, error)) {
+	fake.exchangeMutex.Lock()
+	defer fake.exchangeMutex.Unlock()
+	fake.ExchangeStub = stub
+}
+
+func (fake *FakeProvider) ExchangeArgsForCall(i int) (context.Context, string, string, string) {
+	fake.exchangeMutex.RLock()
+	defer fake.exchangeMutex.RUnlock()
+	argsForCall := fake.exchangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeProvider) ExchangeReturns(result1 oidc.Identity, result2 error) {
+	fake.exchangeMutex.Lock()
+	defer fake.exchangeMutex.Unlock()
+	fake.ExchangeStub = nil
+	fake.exchangeReturns = struct {
+		result1 oidc.Identity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) ExchangeReturnsOnCall(i int, result1 oidc.Identity, result2 error) {
+	fake.exchangeMutex.Lock()
+	defer fake.exchangeMutex.Unlock()
+	fake.ExchangeStub = nil
+	if fake.exchangeReturnsOnCall == nil {
+		fake.exchangeReturnsOnCall = make(map[int]struct {
+			result1 oidc.Identity
+			result2 error
+		})
+	}
+	fake.exchangeReturnsOnCall[i] = struct {
+		result1 oidc.Identity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeProvider) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeProvider) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProvider) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authCodeURLMutex.RLock()
+	defer fake.authCodeURLMutex.RUnlock()
+	fake.exchangeMutex.RLock()
+	defer fake.exchangeMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oidc.Provider = new(FakeProvider)
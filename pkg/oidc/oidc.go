@@ -0,0 +1,84 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oidc provider not found")
+	ErrDiscoveryFailed  = errors.New("oidc discovery failed")
+	ErrExchangeFailed   = errors.New("oidc authorization code exchange failed")
+	ErrInvalidIDToken   = errors.New("invalid oidc id token")
+	ErrNonceMismatch    = errors.New("oidc nonce mismatch")
+)
+
+// Identity is the user authenticated by the provider.
+type Identity struct {
+	Subject       string // Unique user id within the provider
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider signs in user using authorization code flow with PKCE.
+//
+//counterfeiter:generate -o ./mock . Provider
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns provider login page, the user redirected back with the code and state
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange exchanges the code and returns identity from the verified ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (Identity, error)
+}
+
+// Providers is the configured provider by name.
+type Providers map[string]Provider
+
+// Get returns the provider by name.
+func (p Providers) Get(name string) (Provider, error) {
+	provider, found := p[name]
+	if !found {
+		return nil, ErrProviderNotFound
+	}
+
+	return provider, nil
+}
+
+// Init creates every configured provider, stop the application when the configuration is invalid.
+// Provider metadata discovered on first use, so unavailable provider does not stop the application.
+func Init(oidcConfig config.OIDC) Providers {
+	providers := make(Providers)
+	for _, providerConfig := range oidcConfig.Providers {
+		if providerConfig.Name == "" || providerConfig.Issuer == "" || providerConfig.ClientID == "" || providerConfig.RedirectURL == "" {
+			log.Fatalf("oidc provider %v requires name, issuer, client id and redirect url", providerConfig.Name)
+		}
+
+		providers[providerConfig.Name] = newProvider(providerConfig, oidcConfig.Timeout)
+	}
+
+	return providers
+}
+
+// GenerateCodeVerifier returns random PKCE code verifier.
+func GenerateCodeVerifier() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// CodeChallenge returns S256 PKCE code challenge of the verifier.
+func CodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,350 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/spf13/cast"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	discoveryPath       = "/.well-known/openid-configuration"
+	codeChallengeMethod = "S256"
+	keyRefreshInterval  = time.Minute // Minimum interval between fetching the key set for unknown key id
+	leeway              = time.Minute // Allowed clock skew with the provider
+)
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type provider struct {
+	config config.OIDCProvider
+	client *http.Client
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]any // Key id to public key
+	keysFetchedAt time.Time
+}
+
+func newProvider(providerConfig config.OIDCProvider, timeout time.Duration) *provider {
+	return &provider{
+		config: providerConfig,
+		client: &http.Client{Timeout: timeout},
+		keys:   make(map[string]any),
+	}
+}
+
+func (p *provider) Name() string {
+	return p.config.Name
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {codeChallengeMethod},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (Identity, error) {
+	defer log.Context(ctx).RecordDuration(fmt.Sprintf("exchange %v authorization code", p.config.Name)).Stop()
+
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	statusCode, err := p.do(req, &token)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w, %v", ErrExchangeFailed, err)
+	}
+	if statusCode != http.StatusOK || token.IDToken == "" {
+		return Identity{}, fmt.Errorf("%w, status %v %v %v", ErrExchangeFailed, statusCode, token.Error, token.ErrorDescription)
+	}
+
+	return p.verifyIDToken(ctx, discovery, token.IDToken, nonce)
+}
+
+// verifyIDToken checks the signature using the provider key set, the expiration, the issuer, the audience and the nonce.
+func (p *provider) verifyIDToken(ctx context.Context, discovery *discoveryDocument, idToken, nonce string) (Identity, error) {
+	// Expiration checked separately to allow clock skew
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		return p.verificationKey(ctx, discovery, token)
+	})
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w, %v", ErrInvalidIDToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Add(-leeway).Unix(), true) {
+		return Identity{}, fmt.Errorf("%w, token expired", ErrInvalidIDToken)
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return Identity{}, fmt.Errorf("%w, unexpected issuer %v", ErrInvalidIDToken, claims["iss"])
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return Identity{}, fmt.Errorf("%w, unexpected audience %v", ErrInvalidIDToken, claims["aud"])
+	}
+
+	if cast.ToString(claims["nonce"]) != nonce {
+		return Identity{}, ErrNonceMismatch
+	}
+
+	identity := Identity{
+		Subject:       cast.ToString(claims["sub"]),
+		Email:         cast.ToString(claims["email"]),
+		EmailVerified: cast.ToBool(claims["email_verified"]), // Some provider sends the value as string
+		Name:          cast.ToString(claims["name"]),
+	}
+	if identity.Subject == "" {
+		return Identity{}, fmt.Errorf("%w, missing subject", ErrInvalidIDToken)
+	}
+
+	return identity, nil
+}
+
+// verificationKey returns the key matching the token, key set fetched again for unknown key id.
+func (p *provider) verificationKey(ctx context.Context, discovery *discoveryDocument, token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, found := p.keys[keyID]
+	if !found && time.Since(p.keysFetchedAt) > keyRefreshInterval {
+		if err := p.fetchKeys(ctx, discovery.JWKSURI); err != nil {
+			return nil, err
+		}
+		key, found = p.keys[keyID]
+	}
+
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %v", keyID)
+	}
+
+	// Key type must match the algorithm to avoid algorithm confusion
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	}
+
+	return key, nil
+}
+
+// fetchKeys replaces the key set, must be called while holding the lock.
+func (p *provider) fetchKeys(ctx context.Context, jwksURI string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return err
+	}
+
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	statusCode, err := p.do(req, &keySet)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("fetching key set returns status %v", statusCode)
+	}
+
+	keys := make(map[string]any)
+	for _, jwk := range keySet.Keys {
+		publicKey, err := jwk.publicKey()
+		if err != nil {
+			log.Context(ctx).Warnf("skipping %v key %v, %v", p.config.Name, jwk.KeyID, err)
+			continue
+		}
+		keys[jwk.KeyID] = publicKey
+	}
+
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	return nil
+}
+
+// discover fetches provider metadata once, retried on the next call when failed.
+func (p *provider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovery discoveryDocument
+	statusCode, err := p.do(req, &discovery)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", ErrDiscoveryFailed, err)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w, status %v", ErrDiscoveryFailed, statusCode)
+	}
+
+	if discovery.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%w, issuer %v does not match %v", ErrDiscoveryFailed, discovery.Issuer, p.config.Issuer)
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// do sends the request and decodes JSON response body.
+func (p *provider) do(req *http.Request, result any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return resp.StatusCode, err
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %v", k.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %v", k.KeyType)
+	}
+}
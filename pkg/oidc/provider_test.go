@@ -0,0 +1,354 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"go-skeleton-code/config"
+)
+
+const (
+	testClientID     = "client-id"
+	testCode         = "authorization-code"
+	testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testNonce        = "nonce"
+	rsaKeyID         = "rsa-key"
+	ecKeyID          = "ec-key"
+)
+
+// testServer is OIDC provider serving discovery, key set and token endpoint.
+type testServer struct {
+	*httptest.Server
+	rsaKey          *rsa.PrivateKey
+	ecKey           *ecdsa.PrivateKey
+	discoveryIssuer string // Issuer returned by discovery, the server URL when empty
+	idToken         string // Returned by the token endpoint
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &testServer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		issuer := server.discoveryIssuer
+		if issuer == "" {
+			issuer = server.URL
+		}
+
+		writeJSON(w, http.StatusOK, discoveryDocument{
+			Issuer:                issuer,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+			JWKSURI:               server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"keys": []jsonWebKey{
+			{
+				KeyType: "RSA",
+				KeyID:   rsaKeyID,
+				N:       base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				KeyType: "EC",
+				KeyID:   ecKeyID,
+				Curve:   "P-256",
+				X:       base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+				Y:       base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+			},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeJSON(w, http.StatusBadRequest, tokenResponse{Error: "invalid_request"})
+			return
+		}
+
+		// PKCE, the verifier must match the challenge sent to the authorization endpoint
+		if r.PostForm.Get("code") != testCode || CodeChallenge(r.PostForm.Get("code_verifier")) != CodeChallenge(testCodeVerifier) {
+			writeJSON(w, http.StatusBadRequest, tokenResponse{Error: "invalid_grant"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, tokenResponse{IDToken: server.idToken})
+	})
+
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *testServer) provider() *provider {
+	return newProvider(config.OIDCProvider{
+		Name:        "test",
+		Issuer:      s.URL,
+		ClientID:    testClientID,
+		RedirectURL: "https://example.com/callback",
+	}, 5*time.Second)
+}
+
+// claims returns valid ID token claims, modified by each test case.
+func (s *testServer) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            s.URL,
+		"aud":            testClientID,
+		"sub":            "subject",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "User",
+		"nonce":          testNonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, keyID string, claims jwt.MapClaims, key any) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// Example from RFC 7636 appendix B
+	if challenge := CodeChallenge(testCodeVerifier); challenge != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("unexpected code challenge %v", challenge)
+	}
+
+	first, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// RFC 7636 requires 43 to 128 characters
+	if len(first) < 43 || len(first) > 128 {
+		t.Errorf("code verifier length %v out of range", len(first))
+	}
+	if first == second {
+		t.Error("code verifier must be random")
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	server := newTestServer(t)
+
+	authorizationURL, err := server.provider().AuthCodeURL(context.Background(), "state", testNonce, CodeChallenge(testCodeVerifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Scheme+"://"+parsed.Host+parsed.Path != server.URL+"/authorize" {
+		t.Errorf("unexpected authorization endpoint %v", authorizationURL)
+	}
+
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"state":                 "state",
+		"nonce":                 testNonce,
+		"code_challenge":        CodeChallenge(testCodeVerifier),
+		"code_challenge_method": "S256",
+	}
+	for name, value := range expected {
+		if actual := parsed.Query().Get(name); actual != value {
+			t.Errorf("expected %v %v, got %v", name, value, actual)
+		}
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	server := newTestServer(t)
+	server.discoveryIssuer = "https://attacker.example.com"
+
+	_, err := server.provider().AuthCodeURL(context.Background(), "state", testNonce, CodeChallenge(testCodeVerifier))
+	if !errors.Is(err, ErrDiscoveryFailed) {
+		t.Errorf("expected discovery failed, got %v", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	server := newTestServer(t)
+
+	rsaPublicKey, err := x509.MarshalPKIXPublicKey(&server.rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPublicKey})
+
+	withClaim := func(name string, value any) jwt.MapClaims {
+		claims := server.claims()
+		claims[name] = value
+		return claims
+	}
+
+	testCases := []struct {
+		name         string
+		idToken      string
+		codeVerifier string
+		nonce        string
+		expected     error
+	}{
+		{
+			name:    "valid RSA token",
+			idToken: sign(t, jwt.SigningMethodRS256, rsaKeyID, server.claims(), server.rsaKey),
+		},
+		{
+			name:    "valid EC token",
+			idToken: sign(t, jwt.SigningMethodES256, ecKeyID, server.claims(), server.ecKey),
+		},
+		{
+			name:    "expired within leeway",
+			idToken: sign(t, jwt.SigningMethodRS256, rsaKeyID, withClaim("exp", time.Now().Add(-leeway/2).Unix()), server.rsaKey),
+		},
+		{
+			name:     "expired",
+			idToken:  sign(t, jwt.SigningMethodRS256, rsaKeyID, withClaim("exp", time.Now().Add(-2*leeway).Unix()), server.rsaKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "other issuer",
+			idToken:  sign(t, jwt.SigningMethodRS256, rsaKeyID, withClaim("iss", "https://attacker.example.com"), server.rsaKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "other audience",
+			idToken:  sign(t, jwt.SigningMethodRS256, rsaKeyID, withClaim("aud", "other-client"), server.rsaKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "nonce mismatch",
+			idToken:  sign(t, jwt.SigningMethodRS256, rsaKeyID, server.claims(), server.rsaKey),
+			nonce:    "other-nonce",
+			expected: ErrNonceMismatch,
+		},
+		{
+			name:     "missing subject",
+			idToken:  sign(t, jwt.SigningMethodRS256, rsaKeyID, withClaim("sub", ""), server.rsaKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "unknown key id",
+			idToken:  sign(t, jwt.SigningMethodRS256, "unknown", server.claims(), server.rsaKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "signed by other key",
+			idToken:  sign(t, jwt.SigningMethodES256, ecKeyID, server.claims(), mustGenerateECKey(t)),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			// HMAC signed using the public key, accepted when the key type not checked against the algorithm
+			name:     "HMAC with RSA public key",
+			idToken:  sign(t, jwt.SigningMethodHS256, rsaKeyID, server.claims(), rsaPublicKeyPEM),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "EC algorithm with RSA key id",
+			idToken:  sign(t, jwt.SigningMethodES256, rsaKeyID, server.claims(), server.ecKey),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:     "unsigned token",
+			idToken:  sign(t, jwt.SigningMethodNone, rsaKeyID, server.claims(), jwt.UnsafeAllowNoneSignatureType),
+			expected: ErrInvalidIDToken,
+		},
+		{
+			name:         "wrong code verifier",
+			idToken:      sign(t, jwt.SigningMethodRS256, rsaKeyID, server.claims(), server.rsaKey),
+			codeVerifier: "other-verifier",
+			expected:     ErrExchangeFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.codeVerifier == "" {
+				tc.codeVerifier = testCodeVerifier
+			}
+			if tc.nonce == "" {
+				tc.nonce = testNonce
+			}
+			server.idToken = tc.idToken
+
+			identity, err := server.provider().Exchange(context.Background(), testCode, tc.codeVerifier, tc.nonce)
+			if tc.expected != nil {
+				if !errors.Is(err, tc.expected) {
+					t.Errorf("expected error %v, got %v", tc.expected, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			expected := Identity{Subject: "subject", Email: "user@example.com", EmailVerified: true, Name: "User"}
+			if identity != expected {
+				t.Errorf("expected identity %+v, got %+v", expected, identity)
+			}
+		})
+	}
+}
+
+func mustGenerateECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
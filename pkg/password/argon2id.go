@@ -0,0 +1,102 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+type argon2idAlgorithm struct {
+	memory      uint32 // KiB
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+// hash returns the PHC string format, e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>.
+func (a argon2idAlgorithm) hash(password string) (string, error) {
+	salt := make([]byte, a.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.iterations, a.memory, a.parallelism, a.keyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, a.memory, a.iterations, a.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a argon2idAlgorithm) verify(hash, password string) error {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedPassword
+	}
+
+	return nil
+}
+
+func (a argon2idAlgorithm) needsRehash(hash string) bool {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.memory != a.memory ||
+		params.iterations != a.iterations ||
+		params.parallelism != a.parallelism ||
+		uint32(len(salt)) != a.saltLength ||
+		uint32(len(key)) != a.keyLength
+}
+
+func (a argon2idAlgorithm) matches(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+// parseArgon2id returns parameters, salt and key recorded in the hash.
+func parseArgon2id(hash string) (argon2idAlgorithm, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return argon2idAlgorithm{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2idAlgorithm{}, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return argon2idAlgorithm{}, nil, nil, ErrUnknownAlgorithm
+	}
+
+	var params argon2idAlgorithm
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return argon2idAlgorithm{}, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idAlgorithm{}, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2idAlgorithm{}, nil, nil, ErrInvalidHash
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,48 @@
+package password
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptAlgorithm struct {
+	cost int
+}
+
+func (a bcryptAlgorithm) validate() error {
+	if a.cost < bcrypt.MinCost || a.cost > bcrypt.MaxCost {
+		return bcrypt.InvalidCostError(a.cost)
+	}
+
+	return nil
+}
+
+// hash returns modular crypt format, the cost recorded in the hash, e.g. $2a$10$<salt and key>.
+func (a bcryptAlgorithm) hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), a.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashedPassword), nil
+}
+
+func (a bcryptAlgorithm) verify(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatchedPassword
+	}
+
+	return err
+}
+
+func (a bcryptAlgorithm) needsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != a.cost
+}
+
+func (a bcryptAlgorithm) matches(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
@@ -0,0 +1,114 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package password
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	ErrMismatchedPassword = errors.New("password does not match the hash")
+	ErrUnknownAlgorithm   = errors.New("unknown password hash algorithm")
+	ErrInvalidHash        = errors.New("invalid password hash")
+)
+
+// Hasher hashes password using configured algorithm, the algorithm and parameters recorded in the hash so
+// hash created using previous configuration can still be verified.
+//
+//counterfeiter:generate -o ./mock . Hasher
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) error
+	NeedsRehash(hash string) bool // Hash created using other algorithm or parameters than configured
+}
+
+// algorithm is implemented by every supported hash algorithm.
+type algorithm interface {
+	hash(password string) (string, error)
+	verify(hash, password string) error
+	needsRehash(hash string) bool
+	matches(hash string) bool // Hash created using this algorithm
+}
+
+type hasher struct {
+	current    algorithm
+	algorithms []algorithm
+	slots      chan struct{} // Limits concurrent hash computation, each argon2id computation allocates its memory cost
+}
+
+// NewHasher returns hasher creating new hash with the configured algorithm.
+func NewHasher(cfg config.Password) Hasher {
+	argon2id := argon2idAlgorithm{
+		memory:      cfg.Argon2id.Memory,
+		iterations:  cfg.Argon2id.Iterations,
+		parallelism: cfg.Argon2id.Parallelism,
+		saltLength:  cfg.Argon2id.SaltLength,
+		keyLength:   cfg.Argon2id.KeyLength,
+	}
+	bcrypt := bcryptAlgorithm{cost: cfg.BcryptCost}
+
+	maxConcurrent := cfg.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+
+	h := &hasher{algorithms: []algorithm{argon2id, bcrypt}, slots: make(chan struct{}, maxConcurrent)}
+	switch strings.ToLower(cfg.Algorithm) {
+	case AlgorithmArgon2id:
+		if argon2id.memory == 0 || argon2id.iterations == 0 || argon2id.parallelism == 0 || argon2id.saltLength == 0 || argon2id.keyLength == 0 {
+			log.Fatal("argon2id parameters must be greater than zero")
+		}
+		h.current = argon2id
+	case AlgorithmBcrypt:
+		if err := bcrypt.validate(); err != nil {
+			log.Fatalf("invalid bcrypt cost, %v", err)
+		}
+		h.current = bcrypt
+	default:
+		log.Fatalf("unknown password hash algorithm %v", cfg.Algorithm)
+	}
+
+	return h
+}
+
+func (h *hasher) Hash(password string) (string, error) {
+	h.acquire()
+	defer h.release()
+
+	return h.current.hash(password)
+}
+
+func (h *hasher) Verify(hash, password string) error {
+	h.acquire()
+	defer h.release()
+
+	for _, algorithm := range h.algorithms {
+		if algorithm.matches(hash) {
+			return algorithm.verify(hash, password)
+		}
+	}
+
+	return ErrUnknownAlgorithm
+}
+
+func (h *hasher) NeedsRehash(hash string) bool {
+	return !h.current.matches(hash) || h.current.needsRehash(hash)
+}
+
+// acquire waits until the number of running hash computation below the limit.
+func (h *hasher) acquire() {
+	h.slots <- struct{}{}
+}
+
+func (h *hasher) release() {
+	<-h.slots
+}
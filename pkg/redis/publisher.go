@@ -0,0 +1,42 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+
+	"go-skeleton-code/pkg/log"
+)
+
+//counterfeiter:generate -o ./mock . Publisher
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload any) error
+}
+
+type publisher struct {
+	client *redis.Client
+}
+
+func NewPublisher(client *redis.Client) Publisher {
+	return &publisher{client: client}
+}
+
+// Publish is used for broadcasting message to every subscriber of the channel
+func (p *publisher) Publish(ctx context.Context, channel string, payload any) error {
+	defer log.Context(ctx).RecordDuration("redis publisher").Stop()
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	if err := p.client.Publish(ctx, channel, payloadJSON).Err(); err != nil {
+		log.Context(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	serverError "go-skeleton-code/pkg/error"
 	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/validator"
 )
 
 type DefaultResponse struct {
@@ -80,6 +81,11 @@ func Failed(c echo.Context, err error) error {
 		response.Message = serverErr.Message
 	}
 
+	// Return the failed fields for invalid request
+	if fieldErrors := validator.FieldErrors(err); fieldErrors != nil {
+		response.Data = fieldErrors
+	}
+
 	log.Context(c.Get("ctx").(context.Context)).ExtraData["error"] = rawError.Error()
 	return c.JSON(httpRespCode, response)
 }
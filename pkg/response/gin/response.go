@@ -10,6 +10,7 @@ import (
 
 	serverError "go-skeleton-code/pkg/error"
 	"go-skeleton-code/pkg/log"
+	"go-skeleton-code/pkg/validator"
 )
 
 type DefaultResponse struct {
@@ -80,6 +81,11 @@ func Failed(g *gin.Context, err error) {
 		response.Message = serverErr.Message
 	}
 
+	// Return the failed fields for invalid request
+	if fieldErrors := validator.FieldErrors(err); fieldErrors != nil {
+		response.Data = fieldErrors
+	}
+
 	// Log the error in the provided context
 	log.Context(g.Request.Context()).ExtraData["error"] = rawError.Error()
 
@@ -0,0 +1,51 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Headers sent with every signed request.
+const (
+	HeaderAPIKey    = "X-API-Key"
+	HeaderTimestamp = "X-API-Timestamp" // Unix time in milliseconds
+	HeaderSignature = "X-API-Signature" // Hex encoded HMAC-SHA256
+)
+
+// Request is the signed part of the HTTP request.
+type Request struct {
+	APIKey    string
+	Timestamp int64
+	Signature string
+	Method    string
+	Path      string // Including the query string
+	Body      []byte
+}
+
+// message returns the signed content, every part separated by a new line.
+func (r Request) message() []byte {
+	return []byte(fmt.Sprintf("%d\n%s\n%s\n%s", r.Timestamp, r.Method, r.Path, r.Body))
+}
+
+// Sign returns the signature of the request using the secret.
+func Sign(secret string, request Request) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(request.message())
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify compares the request signature in constant time.
+func Verify(secret string, request Request) bool {
+	signature, err := hex.DecodeString(request.Signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(request.message())
+
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -0,0 +1,19 @@
+package sms
+
+import (
+	"context"
+
+	"go-skeleton-code/pkg/log"
+)
+
+type logSender struct{}
+
+// NewLogSender returns sender that only write the message to the log, used for local run.
+func NewLogSender() Sender {
+	return &logSender{}
+}
+
+func (s *logSender) Send(ctx context.Context, message Message) error {
+	log.Context(ctx).Infof("sending sms to %v, body: %v", message.To, message.Body)
+	return nil
+}
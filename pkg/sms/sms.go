@@ -0,0 +1,36 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package sms
+
+import (
+	"context"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	SenderLog = "log"
+)
+
+type Message struct {
+	To   string // Phone number in E.164 format
+	Body string
+}
+
+// Sender delivers SMS through the configured provider.
+//
+//counterfeiter:generate -o ./mock . Sender
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Init returns sender based on the configuration, log sender used when not configured.
+func Init(cfg config.SMS) Sender {
+	switch cfg.Sender {
+	case SenderLog, "":
+		return NewLogSender()
+	default:
+		log.Fatalf("unknown sms sender %v", cfg.Sender)
+		return nil
+	}
+}
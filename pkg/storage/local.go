@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go-skeleton-code/pkg/log"
+)
+
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage returns storage writing file under the root directory, the directory created when missing.
+func NewLocalStorage(root string) Storage {
+	if root == "" {
+		log.Fatal("local storage path is required")
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		log.Fatalf("failed creating local storage directory, %v", err)
+	}
+
+	return &localStorage{root: root}
+}
+
+// Save writes into temporary file first, so partially written file never visible under the key.
+func (s *localStorage) Save(ctx context.Context, key string, content io.Reader) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filePath)
+}
+
+func (s *localStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// filePath rejects key escaping the root directory.
+func (s *localStorage) filePath(key string) (string, error) {
+	cleanKey := path.Clean("/" + key)[1:]
+	if cleanKey == "" || cleanKey != key || strings.Contains(key, `\`) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleanKey)), nil
+}
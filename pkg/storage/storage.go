@@ -0,0 +1,40 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go-skeleton-code/config"
+	"go-skeleton-code/pkg/log"
+)
+
+const (
+	DriverLocal = "local"
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid storage key")
+	ErrNotFound   = errors.New("file not found")
+)
+
+// Storage keeps uploaded file, key is slash separated relative path, e.g. kyc/1/passport.png.
+//
+//counterfeiter:generate -o ./mock . Storage
+type Storage interface {
+	Save(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Init returns storage based on the configuration, local filesystem used when not configured.
+func Init(cfg config.Storage) Storage {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		return NewLocalStorage(cfg.Local.Path)
+	default:
+		log.Fatalf("unknown storage driver %v", cfg.Driver)
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits     = 6
+	Period     = 30 * time.Second
+	secretSize = 20 // 160 bit as recommended by RFC 4226
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns new base32 encoded secret.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI returns otpauth URI to be rendered as QR code by the authenticator app.
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	label := url.PathEscape(fmt.Sprintf("%v:%v", issuer, account))
+	return fmt.Sprintf("otpauth://totp/%v?%v", label, query.Encode())
+}
+
+// Step returns time step of the time.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// GenerateCode returns code for the time step.
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	hash := mac.Sum(nil)
+
+	// Dynamic truncation, RFC 4226 section 5.3
+	offset := hash[len(hash)-1] & 0x0f
+	value := binary.BigEndian.Uint32(hash[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate checks the code within skew steps before and after the time, returns the matched step
+// so the caller can reject the same code used twice.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool) {
+	currentStep := Step(t)
+	for i := -skew; i <= skew; i++ {
+		step := currentStep + int64(i)
+
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
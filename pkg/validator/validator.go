@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type (
+	Validate         = validator.Validate
+	ValidationErrors = validator.ValidationErrors
+)
+
+// Enum is implemented by string types with fixed set of values, validated using the enum rule.
+type Enum interface {
+	IsValid() bool
+}
+
+// FieldError describes a single field failing validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// New creates validator with the custom rules, field reported using the json name.
+func New() *Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation("password", validatePassword)
+	_ = validate.RegisterValidation("enum", validateEnum)
+
+	return validate
+}
+
+// validatePassword requires at least 8 characters with upper case, lower case and digit.
+func validatePassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if len(password) < 8 {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		}
+	}
+
+	return hasUpper && hasLower && hasDigit
+}
+
+func validateEnum(fl validator.FieldLevel) bool {
+	enum, ok := fl.Field().Interface().(Enum)
+	return ok && enum.IsValid()
+}
+
+// FieldErrors converts validation error into list of field errors, returns nil for other error.
+func FieldErrors(err error) []FieldError {
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fieldError.Field(),
+			Rule:    fieldError.Tag(),
+			Message: message(fieldError),
+		})
+	}
+
+	return fieldErrors
+}
+
+func message(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_if", "required_unless":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "e164":
+		return "must be a valid phone number in E.164 format"
+	case "ip", "cidr", "ip|cidr":
+		return "must be a valid IP address or CIDR"
+	case "password":
+		return "must be at least 8 characters with upper case, lower case and digit"
+	case "enum":
+		return fmt.Sprintf("value %v is not allowed", fieldError.Value())
+	case "oneof":
+		return fmt.Sprintf("must be one of %v", fieldError.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %v%v", fieldError.Param(), lengthUnit(fieldError.Kind()))
+	case "max":
+		return fmt.Sprintf("must be at most %v%v", fieldError.Param(), lengthUnit(fieldError.Kind()))
+	case "gt":
+		return fmt.Sprintf("must be greater than %v", fieldError.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %v", fieldError.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %v", fieldError.Param())
+	case "nefield":
+		return fmt.Sprintf("must be different from %v", fieldError.Param())
+	default:
+		return fmt.Sprintf("failed on %v rule", fieldError.Tag())
+	}
+}
+
+// lengthUnit describes what min and max measure, length for string and collection, value for number.
+func lengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}